@@ -0,0 +1,264 @@
+package aferoassert
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	// ErrInvalidArchivePath indicates that an archive entry points outside of the archive.
+	ErrInvalidArchivePath = errors.New("invalid archive path")
+	// ErrUnsupportedCompression indicates that there is no decompressor for the compression of an archive.
+	ErrUnsupportedCompression = errors.New("unsupported compression")
+)
+
+// Decompressor decompresses a stream, see RegisterDecompressor.
+type Decompressor func(r io.Reader) (io.ReadCloser, error)
+
+var decompressors = struct {
+	mu sync.RWMutex
+	m  map[Compression]Decompressor
+}{
+	m: map[Compression]Decompressor{
+		Gzip: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+		Bzip2: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(bzip2.NewReader(r)), nil
+		},
+	},
+}
+
+// RegisterDecompressor registers a decompressor for a compression format, so FsFromTar can load the archives
+// compressed with it, for example Xz. Gzip and Bzip2 are built in, Zstd is registered by importing
+// go.nhat.io/aferoassert/zstd. Registering a nil decompressor removes the existing one.
+func RegisterDecompressor(c Compression, d Decompressor) {
+	decompressors.mu.Lock()
+	defer decompressors.mu.Unlock()
+
+	if d == nil {
+		delete(decompressors.m, c)
+
+		return
+	}
+
+	decompressors.m[c] = d
+}
+
+func decompressorFor(c Compression) (Decompressor, bool) {
+	decompressors.mu.RLock()
+	defer decompressors.mu.RUnlock()
+
+	d, ok := decompressors.m[c]
+
+	return d, ok
+}
+
+// FsFromZip loads a zip archive into an in-memory file system, so the assertions can be run against the packaged
+// artifacts. The permissions and the modification times of the entries are preserved.
+func FsFromZip(archive string) (afero.Fs, error) {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close() // nolint: errcheck
+
+	fs := afero.NewMemMapFs()
+
+	for _, f := range r.File {
+		if err := extractZipFile(fs, f); err != nil {
+			return nil, err
+		}
+	}
+
+	return fs, nil
+}
+
+func extractZipFile(fs afero.Fs, f *zip.File) error {
+	name, err := archivePath(f.Name)
+	if err != nil {
+		return err
+	}
+
+	info := f.FileInfo()
+
+	if info.IsDir() {
+		return extractDir(fs, name, info.Mode(), info.ModTime())
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+
+	defer rc.Close() // nolint: errcheck
+
+	return extractFile(fs, name, rc, info.Mode(), info.ModTime())
+}
+
+// FsFromTarGz loads a gzipped tar archive into an in-memory file system, so the assertions can be run against the
+// packaged artifacts. The permissions and the modification times of the entries are preserved, the entries that are
+// neither a directory nor a regular file are skipped.
+func FsFromTarGz(archive string) (afero.Fs, error) {
+	f, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	defer gz.Close() // nolint: errcheck
+
+	return readTar(gz)
+}
+
+// FsFromTar loads a tar archive into an in-memory file system, like FsFromTarGz. The archive is either uncompressed or
+// compressed with a format that has a decompressor, see RegisterDecompressor, such as .tar.gz, .tar.bz2 or .tar.zst.
+// The format is detected from the magic bytes.
+func FsFromTar(archive string) (afero.Fs, error) {
+	f, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	br := bufio.NewReader(f)
+
+	header, err := br.Peek(compressionMagicSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	compression := detectCompression(header)
+	if compression == 0 {
+		return readTar(br)
+	}
+
+	d, ok := decompressorFor(compression)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, compression)
+	}
+
+	r, err := d(br)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close() // nolint: errcheck
+
+	return readTar(r)
+}
+
+func readTar(archive io.Reader) (afero.Fs, error) {
+	fs := afero.NewMemMapFs()
+	r := tar.NewReader(archive)
+
+	for {
+		h, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return fs, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := extractTarEntry(fs, r, h); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func extractTarEntry(fs afero.Fs, r io.Reader, h *tar.Header) error {
+	name, err := archivePath(h.Name)
+	if err != nil {
+		return err
+	}
+
+	info := h.FileInfo()
+
+	switch h.Typeflag {
+	case tar.TypeDir:
+		return extractDir(fs, name, info.Mode(), info.ModTime())
+
+	case tar.TypeReg, tar.TypeRegA: // nolint: staticcheck
+		return extractFile(fs, name, r, info.Mode(), info.ModTime())
+	}
+
+	return nil
+}
+
+// archivePath converts the path of an archive entry to a relative path and rejects the paths that escape the archive.
+func archivePath(name string) (string, error) {
+	p := path.Clean(strings.TrimPrefix(name, "/"))
+
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("%w: %s", ErrInvalidArchivePath, name)
+	}
+
+	return filepath.FromSlash(p), nil
+}
+
+func extractDir(fs afero.Fs, name string, mode os.FileMode, modTime time.Time) error {
+	if err := fs.MkdirAll(name, mode.Perm()); err != nil {
+		return err
+	}
+
+	if err := fs.Chmod(name, mode.Perm()); err != nil {
+		return err
+	}
+
+	return fs.Chtimes(name, modTime, modTime)
+}
+
+func extractFile(fs afero.Fs, name string, r io.Reader, mode os.FileMode, modTime time.Time) error {
+	if err := fs.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	f, err := fs.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil { // nolint: gosec
+		_ = f.Close() // nolint: errcheck
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := fs.Chmod(name, mode.Perm()); err != nil {
+		return err
+	}
+
+	return fs.Chtimes(name, modTime, modTime)
+}
@@ -0,0 +1,246 @@
+package aferoassert_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+type archiveEntry struct {
+	name    string
+	content string
+	mode    os.FileMode
+}
+
+var archiveEntries = []archiveEntry{
+	{name: "dist/", mode: os.ModeDir | 0o755},
+	{name: "dist/bin/app", content: "#!/bin/sh\n", mode: 0o755},
+	{name: "dist/README.md", content: "# App\n", mode: 0o644},
+}
+
+const archiveTree = `
+- dist 'perm:"0755"':
+    - README.md 'perm:"0644" content:"# App\n"'
+    - bin:
+        - app 'perm:"0755"'
+`
+
+func writeZip(t *testing.T, entries []archiveEntry) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "archive.zip")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer f.Close() // nolint: errcheck
+
+	w := zip.NewWriter(f)
+
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		h.SetMode(e.mode)
+
+		fw, err := w.CreateHeader(h)
+		require.NoError(t, err)
+
+		_, err = fw.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	return path
+}
+
+func writeTarGz(t *testing.T, entries []archiveEntry) string {
+	t.Helper()
+
+	return writeTar(t, "archive.tar.gz", entries, func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	})
+}
+
+func writeTar(t *testing.T, name string, entries []archiveEntry, compress func(w io.Writer) (io.WriteCloser, error)) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer f.Close() // nolint: errcheck
+
+	cw, err := compress(f)
+	require.NoError(t, err)
+
+	w := tar.NewWriter(cw)
+
+	for _, e := range entries {
+		h := &tar.Header{Name: e.name, Mode: int64(e.mode.Perm()), Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+
+		if e.mode.IsDir() {
+			h.Typeflag = tar.TypeDir
+		}
+
+		require.NoError(t, w.WriteHeader(h))
+
+		_, err := w.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+	require.NoError(t, cw.Close())
+
+	return path
+}
+
+// nopWriteCloser writes an uncompressed archive.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestFsFromZip(t *testing.T) {
+	t.Parallel()
+
+	fs, err := aferoassert.FsFromZip(writeZip(t, archiveEntries))
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, archiveTree, "."), mockT.message())
+}
+
+func TestFsFromTarGz(t *testing.T) {
+	t.Parallel()
+
+	fs, err := aferoassert.FsFromTarGz(writeTarGz(t, archiveEntries))
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, archiveTree, "."), mockT.message())
+}
+
+func TestFsFromTar(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		archive  func(t *testing.T) string
+	}{
+		{
+			scenario: "tar",
+			archive: func(t *testing.T) string {
+				t.Helper()
+
+				return writeTar(t, "archive.tar", archiveEntries, func(w io.Writer) (io.WriteCloser, error) {
+					return nopWriteCloser{Writer: w}, nil
+				})
+			},
+		},
+		{
+			scenario: "tar.gz",
+			archive: func(t *testing.T) string {
+				t.Helper()
+
+				return writeTarGz(t, archiveEntries)
+			},
+		},
+		{
+			scenario: "tar.bz2",
+			archive: func(*testing.T) string {
+				return "testdata/archive.tar.bz2"
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			fs, err := aferoassert.FsFromTar(tc.archive(t))
+			require.NoError(t, err)
+
+			mockT := &testingT{}
+
+			assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, archiveTree, "."), mockT.message())
+		})
+	}
+}
+
+func TestFsFromTar_RegisterDecompressor(t *testing.T) {
+	t.Parallel()
+
+	magic := []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+
+	// The fake xz archive is the magic bytes followed by an uncompressed tar.
+	archive := writeTar(t, "archive.tar.xz", archiveEntries, func(w io.Writer) (io.WriteCloser, error) {
+		_, err := w.Write(magic)
+
+		return nopWriteCloser{Writer: w}, err
+	})
+
+	_, err := aferoassert.FsFromTar(archive)
+	require.ErrorIs(t, err, aferoassert.ErrUnsupportedCompression)
+	assert.EqualError(t, err, "unsupported compression: xz")
+
+	aferoassert.RegisterDecompressor(aferoassert.Xz, func(r io.Reader) (io.ReadCloser, error) {
+		if _, err := io.ReadFull(r, make([]byte, len(magic))); err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(r), nil
+	})
+
+	t.Cleanup(func() {
+		aferoassert.RegisterDecompressor(aferoassert.Xz, nil)
+	})
+
+	fs, err := aferoassert.FsFromTar(archive)
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, archiveTree, "."), mockT.message())
+}
+
+func TestFsFromArchive_InvalidPath(t *testing.T) {
+	t.Parallel()
+
+	entries := []archiveEntry{{name: "../evil", mode: 0o644}}
+
+	_, err := aferoassert.FsFromZip(writeZip(t, entries))
+	require.ErrorIs(t, err, aferoassert.ErrInvalidArchivePath)
+
+	_, err = aferoassert.FsFromTarGz(writeTarGz(t, entries))
+	require.ErrorIs(t, err, aferoassert.ErrInvalidArchivePath)
+}
+
+func TestFsFromArchive_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := aferoassert.FsFromZip("unknown.zip")
+	assert.Error(t, err)
+
+	_, err = aferoassert.FsFromTarGz("unknown.tar.gz")
+	assert.Error(t, err)
+
+	_, err = aferoassert.FsFromTar("unknown.tar.zst")
+	assert.Error(t, err)
+}
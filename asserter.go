@@ -0,0 +1,247 @@
+package aferoassert
+
+import (
+	"io"
+	iofs "io/fs"
+	"os"
+	"testing/fstest"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+var _ Asserter = (*Assertions)(nil)
+
+// Asserter provides the assertions bound to a TestingT and a file system, so the test helpers of an application can
+// accept it and be unit-tested with a mock instead of a real file system. Assertions, created by New, is the default
+// implementation, for example:
+//
+//	func AssertInstalled(a aferoassert.Asserter, app string) bool {
+//		return a.FileExists(filepath.Join("bin", app)) && a.Perm(filepath.Join("bin", app), 0o755)
+//	}
+//
+//	AssertInstalled(aferoassert.New(t, fs), "app")
+type Asserter interface {
+	// Exists checks whether a path exists.
+	Exists(path string, msgAndArgs ...interface{}) bool
+
+	// NoExists checks whether a file does not exist in a given path.
+	NoExists(path string, msgAndArgs ...interface{}) bool
+
+	// FileExists checks whether a file exists in the given path.
+	FileExists(path string, msgAndArgs ...interface{}) bool
+
+	// NoFileExists checks whether a file does not exist in a given path.
+	NoFileExists(path string, msgAndArgs ...interface{}) bool
+
+	// DirExists checks whether a directory exists in the given path.
+	DirExists(path string, msgAndArgs ...interface{}) bool
+
+	// NoDirExists checks whether a directory does not exist in the given path.
+	NoDirExists(path string, msgAndArgs ...interface{}) bool
+
+	// Perm checks whether a path has the expected permission or not.
+	Perm(path string, expected os.FileMode, msgAndArgs ...interface{}) bool
+
+	// FileSize checks whether a file has the expected size.
+	FileSize(path string, expected int64, msgAndArgs ...interface{}) bool
+
+	// FileSparse checks whether a file is sparse.
+	FileSparse(path string, msgAndArgs ...interface{}) bool
+
+	// FileContent checks whether a file content is as expected or not.
+	FileContent(path string, expected string, msgAndArgs ...interface{}) bool
+
+	// FileContentRegexp checks whether a file content matches the expectation or not.
+	FileContentRegexp(path string, expected interface{}, msgAndArgs ...interface{}) bool
+
+	// FileSemanticEqual checks whether a file content is equal to the expectation using the registered comparer.
+	FileSemanticEqual(path string, expected string, msgAndArgs ...interface{}) bool
+
+	// TreeEqual checks whether a directory is the same as the expectation or not.
+	TreeEqual(tree FileTree, path string, msgAndArgs ...interface{}) bool
+
+	// YAMLTreeEqual checks whether a directory is the same as the expectation or not.
+	YAMLTreeEqual(expected, path string, msgAndArgs ...interface{}) bool
+
+	// TreeContains checks whether a directory contains a file tree or not.
+	TreeContains(tree FileTree, path string, msgAndArgs ...interface{}) bool
+
+	// YAMLTreeContains checks whether a directory contains a file tree or not.
+	YAMLTreeContains(expected, path string, msgAndArgs ...interface{}) bool
+
+	// EmbeddedTreeEqual checks whether a directory has exactly the files of an embedded file system.
+	EmbeddedTreeEqual(embedded iofs.FS, root string, msgAndArgs ...interface{}) bool
+
+	// EmbeddedFileEqual checks whether a file has the same content as a file of an embedded file system.
+	EmbeddedFileEqual(embedded iofs.FS, name string, path string, msgAndArgs ...interface{}) bool
+
+	// MapFSEqual checks whether a directory has exactly the files of a fstest.MapFS with the same content.
+	MapFSEqual(expected fstest.MapFS, root string, msgAndArgs ...interface{}) bool
+
+	// MapFSContains checks whether a directory contains the files of a fstest.MapFS with the same content.
+	MapFSContains(expected fstest.MapFS, root string, msgAndArgs ...interface{}) bool
+
+	// FsConformance runs a battery of checks against the file system to verify that it behaves like the standard ones.
+	FsConformance(msgAndArgs ...interface{}) bool
+
+	// FsEqual checks whether a directory has the same files, permissions and content in both file systems, the bound file
+	// system is the actual one.
+	FsEqual(expected afero.Fs, root string, msgAndArgs ...interface{}) bool
+
+	// AssertUnchanged checks whether a directory is the same as when the snapshot was taken, see Fingerprint.
+	AssertUnchanged(root string, snapshot Snapshot, msgAndArgs ...interface{}) bool
+
+	// AssertOnlyChanged checks whether only the allowed paths of a directory were created, removed or modified since the
+	// snapshot was taken.
+	AssertOnlyChanged(root string, snapshot Snapshot, allowed []string, msgAndArgs ...interface{}) bool
+
+	// DirGolden checks whether a directory has exactly the files of a reference directory on disk, or updates the
+	// reference directory when the test runs with -update.
+	DirGolden(actualRoot, goldenDir string, msgAndArgs ...interface{}) bool
+
+	// DirChecksumEqual checks whether the checksum of a directory is the expected one.
+	DirChecksumEqual(root string, expected string, msgAndArgs ...interface{}) bool
+
+	// SecureTree audits a directory and reports all the violations of the security policy together.
+	SecureTree(root string, policy SecurityPolicy, msgAndArgs ...interface{}) bool
+
+	// AssertPermPolicy checks whether every path of a directory satisfies the rules of the permission policy.
+	AssertPermPolicy(root string, policy PermPolicy, msgAndArgs ...interface{}) bool
+
+	// AssertNoWrites checks whether no operation modified the recorded file system or opened a file for writing.
+	AssertNoWrites(rec *RecordedFs, msgAndArgs ...interface{}) bool
+
+	// AssertRemoved checks whether the path was successfully removed from the recorded file system.
+	AssertRemoved(rec *RecordedFs, path string, msgAndArgs ...interface{}) bool
+
+	// AssertOpenedOnce checks whether the path was opened exactly once in the recorded file system.
+	AssertOpenedOnce(rec *RecordedFs, path string, msgAndArgs ...interface{}) bool
+
+	// AssertFsReadOnly checks whether the file system rejects the mutations with a permission error.
+	AssertFsReadOnly(msgAndArgs ...interface{}) bool
+
+	// InLayer checks whether a path exists in the file system, as a layer of a union file system.
+	InLayer(path string, msgAndArgs ...interface{}) bool
+
+	// NotInBase checks whether a path does not exist in the file system, as the base of a union file system.
+	NotInBase(path string, msgAndArgs ...interface{}) bool
+
+	// UnionResolvesTo checks whether a read of a path in the file system, as a union, is served from the expected layer.
+	UnionResolvesTo(layer afero.Fs, path string, msgAndArgs ...interface{}) bool
+
+	// CachedAfterRead reads a file through the file system, as a cache file system, and checks whether the cache layer
+	// then contains the file.
+	CachedAfterRead(cache afero.Fs, path string, msgAndArgs ...interface{}) bool
+
+	// CacheExpired checks whether the next read of a file, in the file system as the base layer, is not served from the
+	// cache layer.
+	CacheExpired(cache afero.Fs, path string, cacheTime time.Duration, msgAndArgs ...interface{}) bool
+
+	// OpenFileContent checks whether the content of an open file is equal to the expectation.
+	OpenFileContent(f afero.File, expected string, msgAndArgs ...interface{}) bool
+
+	// OpenFileSize checks whether the size of an open file is equal to the expectation.
+	OpenFileSize(f afero.File, expected int64, msgAndArgs ...interface{}) bool
+
+	// OpenFileAt checks whether an open file has the expected bytes at the offset.
+	OpenFileAt(f afero.File, offset int64, expected []byte, msgAndArgs ...interface{}) bool
+
+	// Xattr checks whether a path has the extended attribute with the expected value.
+	Xattr(path, name, value string, msgAndArgs ...interface{}) bool
+
+	// TreeWithinQuota checks whether the files of a directory take at most maxBytes bytes and are at most maxFiles.
+	TreeWithinQuota(root string, maxBytes, maxFiles int64, msgAndArgs ...interface{}) bool
+
+	// FsContentSetEqual checks whether the regular files of a directory have the same set of contents in both file
+	// systems, regardless of their paths.
+	FsContentSetEqual(expected afero.Fs, root string, msgAndArgs ...interface{}) bool
+
+	// WaitForPath waits until a path exists, it fails if the path does not exist before the timeout.
+	WaitForPath(path string, timeout time.Duration, msgAndArgs ...interface{}) bool
+
+	// AssertConcurrently runs independent checks on the file system in a pool of workers and reports their failures in
+	// order.
+	AssertConcurrently(checks ...Check) bool
+
+	// FileCRC32 checks whether the CRC-32 (IEEE) of the content of a file is the expected one.
+	FileCRC32(path string, expected uint32, msgAndArgs ...interface{}) bool
+
+	// FileXXHash checks whether the 64-bit xxHash of the content of a file is the expected one.
+	FileXXHash(path string, expected uint64, msgAndArgs ...interface{}) bool
+
+	// TreePerms checks whether every regular file under a directory has the file permission and every directory under it
+	// has the directory permission.
+	TreePerms(root string, filePerm, dirPerm os.FileMode, msgAndArgs ...interface{}) bool
+
+	// NoSpecialFiles checks whether a directory contains only regular files, directories and symlinks.
+	NoSpecialFiles(root string, msgAndArgs ...interface{}) bool
+
+	// NoHardlinks checks whether none of the regular files of a directory has more than one link.
+	NoHardlinks(root string, msgAndArgs ...interface{}) bool
+
+	// RealPathEqual checks whether a path of a file system that maps the paths, such as afero.BasePathFs, is mapped to the
+	// expected real path.
+	RealPathEqual(virtualPath, expectedReal string, msgAndArgs ...interface{}) bool
+
+	// Mirrored checks whether a directory is an exact mirror of another directory of the same file system.
+	Mirrored(srcRoot, dstRoot string, msgAndArgs ...interface{}) bool
+
+	// YAMLTreeEqualFile checks whether a directory is the same as the YAML expectation read from a file.
+	YAMLTreeEqualFile(expectationFs afero.Fs, expectationPath, path string, msgAndArgs ...interface{}) bool
+
+	// YAMLTreeContainsFile checks whether a directory contains the YAML expectation read from a file.
+	YAMLTreeContainsFile(expectationFs afero.Fs, expectationPath, path string, msgAndArgs ...interface{}) bool
+
+	// TreeEqualFromReader checks whether a directory is the same as the YAML expectation read from a reader.
+	TreeEqualFromReader(r io.Reader, path string, msgAndArgs ...interface{}) bool
+
+	// TreeContainsFromReader checks whether a directory contains the YAML expectation read from a reader.
+	TreeContainsFromReader(r io.Reader, path string, msgAndArgs ...interface{}) bool
+
+	// TreeEqualFromBytes checks whether a directory is the same as the YAML expectation.
+	TreeEqualFromBytes(expected []byte, path string, msgAndArgs ...interface{}) bool
+
+	// TreeContainsFromBytes checks whether a directory contains the YAML expectation.
+	TreeContainsFromBytes(expected []byte, path string, msgAndArgs ...interface{}) bool
+
+	// JSONFileValue checks whether the value at a JSON path of a JSON file is equal to the expectation.
+	JSONFileValue(path, query string, expected interface{}, msgAndArgs ...interface{}) bool
+
+	// FileValid checks whether a file is valid according to the validator registered for its extension.
+	FileValid(path string, msgAndArgs ...interface{}) bool
+
+	// FileLinesContain checks whether a file contains all the lines, in any order.
+	FileLinesContain(path string, lines ...string) bool
+
+	// FileCompressedAs checks whether a file is compressed with one of the expected formats by checking its magic bytes.
+	FileCompressedAs(path string, expected Compression, msgAndArgs ...interface{}) bool
+
+	// FileHeader checks whether a file starts with the expected bytes.
+	FileHeader(path string, expected []byte, msgAndArgs ...interface{}) bool
+
+	// FileHeaderAt checks whether a file has the expected bytes at the offset.
+	FileHeaderAt(path string, offset int64, expected []byte, msgAndArgs ...interface{}) bool
+
+	// ImageDimensions checks whether an image has the expected width and height.
+	ImageDimensions(path string, width, height int, msgAndArgs ...interface{}) bool
+
+	// SymlinkIsRelative checks whether a path is a symlink with a relative target.
+	SymlinkIsRelative(path string, msgAndArgs ...interface{}) bool
+
+	// SymlinkIsAbsolute checks whether a path is a symlink with an absolute target.
+	SymlinkIsAbsolute(path string, msgAndArgs ...interface{}) bool
+
+	// NewestFileMatches checks whether the most recently modified entry of a directory has a name matching the pattern.
+	NewestFileMatches(dir, pattern string, msgAndArgs ...interface{}) bool
+
+	// RotationPolicy checks whether the rotated files of a log in a directory follow the naming scheme and are ordered by
+	// their modification times.
+	RotationPolicy(dir, base string, maxRotated int, msgAndArgs ...interface{}) bool
+
+	// ModTimesAscending checks whether the modification times of the paths are strictly increasing.
+	ModTimesAscending(paths ...string) bool
+
+	// PermWithUmask checks whether a path has the permission that results from the requested one with the umask.
+	PermWithUmask(path string, requested, umask os.FileMode, msgAndArgs ...interface{}) bool
+}
@@ -0,0 +1,59 @@
+package aferoassert_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert"
+)
+
+// assertInstalled is an application test helper that accepts an Asserter.
+func assertInstalled(a aferoassert.Asserter, app string) bool {
+	return a.FileExists(filepath.Join("bin", app)) && a.Perm(filepath.Join("bin", app), 0o755)
+}
+
+// mockAsserter records the checked paths, the methods that are not overridden panic.
+type mockAsserter struct {
+	aferoassert.Asserter
+
+	files []string
+	perms []string
+}
+
+func (a *mockAsserter) FileExists(path string, _ ...interface{}) bool {
+	a.files = append(a.files, path)
+
+	return true
+}
+
+func (a *mockAsserter) Perm(path string, _ os.FileMode, _ ...interface{}) bool {
+	a.perms = append(a.perms, path)
+
+	return false
+}
+
+func TestAsserter_Mock(t *testing.T) {
+	t.Parallel()
+
+	a := &mockAsserter{}
+
+	assert.False(t, assertInstalled(a, "app"))
+	assert.Equal(t, []string{"bin/app"}, a.files)
+	assert.Equal(t, []string{"bin/app"}, a.perms)
+}
+
+func TestAsserter_Default(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	var a aferoassert.Asserter = aferoassert.New(mockT, fs)
+
+	assert.True(t, a.FileExists("root/file 1"), mockT.message())
+	assert.False(t, a.FileExists("root/unknown"))
+	assert.Len(t, mockT.errors, 1)
+}
@@ -1,16 +1,16 @@
 package aferoassert
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	iofs "io/fs"
 	"os"
-	"path/filepath"
-	"strings"
+	"syscall"
 
 	"github.com/spf13/afero"
-	"github.com/stretchr/testify/assert"
-	"gopkg.in/yaml.v3"
 )
 
 // TestingT is an interface wrapper around *testing.T.
@@ -22,7 +22,41 @@ type tHelper interface {
 	Helper()
 }
 
-func stat(fs afero.Fs, path string) (os.FileInfo, error) {
+func stat(c *config, fs afero.Fs, path string) (os.FileInfo, error) {
+	if c.index != nil && !c.followLinks {
+		if fi, err, ok := c.index.stat(path); ok {
+			c.traceStat(path, fi, err, " (cached)")
+
+			return fi, err
+		}
+	}
+
+	fi, err := doStat(c, fs, path)
+
+	c.visited++
+
+	c.traceStat(path, fi, err, "")
+
+	return fi, err
+}
+
+func (c *config) traceStat(path string, fi os.FileInfo, err error, suffix string) {
+	if !c.trace {
+		return
+	}
+
+	if err != nil {
+		c.tracef("stat(%q): %s%s", path, err, suffix)
+	} else {
+		c.tracef("stat(%q): %s%s", path, fi.Mode(), suffix)
+	}
+}
+
+func doStat(c *config, fs afero.Fs, path string) (os.FileInfo, error) {
+	if c.followLinks {
+		return fs.Stat(path)
+	}
+
 	if fs, ok := fs.(afero.Lstater); ok {
 		fi, _, err := fs.LstatIfPossible(path)
 
@@ -32,6 +66,37 @@ func stat(fs afero.Fs, path string) (os.FileInfo, error) {
 	return fs.Stat(path)
 }
 
+// statFailure describes why stat failed and suggests the likely cause. The real path is included when the file system
+// maps the paths, such as afero.BasePathFs.
+func statFailure(fs afero.Fs, path string, err error) string {
+	realPath := realPathHint(fs, path)
+
+	switch {
+	case errors.Is(err, iofs.ErrNotExist):
+		return fmt.Sprintf("unable to find file %q%s%s", path, realPath, didYouMean(fs, path))
+
+	case errors.Is(err, iofs.ErrPermission):
+		return fmt.Sprintf("permission denied when running stat(%q)%s: %s, make sure all of its parent directories are searchable (+x)", path, realPath, err)
+
+	case errors.Is(err, syscall.ENOTDIR):
+		return fmt.Sprintf("unable to find file %q%s because one of its parents is not a directory: %s", path, realPath, err)
+	}
+
+	return fmt.Sprintf("error when running stat(%q)%s: %s", path, realPath, err)
+}
+
+// statFailureInfo describes the error of stat like statFailure, with the FailureNotFound kind if the path does not
+// exist.
+func statFailureInfo(fs afero.Fs, path string, err error) FailureInfo {
+	info := FailureInfo{Path: path, Message: statFailure(fs, path, err)}
+
+	if errors.Is(err, iofs.ErrNotExist) {
+		info.Kind = FailureNotFound
+	}
+
+	return info
+}
+
 // Exists checks whether a file or directory exists in the given path. It also fails if there is an error when trying to
 // check the file.
 func Exists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
@@ -39,12 +104,16 @@ func Exists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) boo
 		h.Helper()
 	}
 
-	if _, err := stat(fs, path); err != nil {
-		if os.IsNotExist(err) {
-			return assert.Fail(t, fmt.Sprintf("unable to find file %q", path), msgAndArgs...)
-		}
+	c, msgAndArgs := newConfig(msgAndArgs)
 
-		return assert.Fail(t, fmt.Sprintf("error when running stat(%q): %s", path, err), msgAndArgs...)
+	return c.run(t, "Exists", func(t TestingT) bool {
+		return assertExists(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertExists(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if _, err := stat(c, fs, path); err != nil {
+		return c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
 	}
 
 	return true
@@ -56,11 +125,19 @@ func NoExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) b
 		h.Helper()
 	}
 
-	if _, err := stat(fs, path); err != nil {
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NoExists", func(t TestingT) bool {
+		return assertNoExists(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertNoExists(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if _, err := stat(c, fs, path); err != nil {
 		return true
 	}
 
-	return assert.Fail(t, fmt.Sprintf("file %q exists", path), msgAndArgs...)
+	return c.fail(t, FailureInfo{Kind: FailureUnexpected, Path: path, Message: fmt.Sprintf("file %q exists", path)}, msgAndArgs...)
 }
 
 // FileExists checks whether a file exists in the given path. It also fails if
@@ -70,20 +147,70 @@ func FileExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{})
 		h.Helper()
 	}
 
-	info, err := stat(fs, path)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileExists", func(t TestingT) bool {
+		return assertFileExists(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertFileExists(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	_, ok := statFile(t, c, fs, path, msgAndArgs...)
+
+	return ok
+}
+
+// statFile stats a path that has to be a file, so the assertions on the file reuse the same FileInfo.
+func statFile(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) (os.FileInfo, bool) {
+	info, err := stat(c, fs, path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return assert.Fail(t, fmt.Sprintf("unable to find file %q", path), msgAndArgs...)
+		return nil, c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
+	}
+
+	if info.IsDir() {
+		return nil, c.fail(t, FailureInfo{Kind: FailureType, Path: path, Message: fmt.Sprintf("%q is a directory", path)}, msgAndArgs...)
+	}
+
+	return info, true
+}
+
+// openRegularFile opens a file for the content assertions. The existence and the type are derived from the open error
+// and from File.Stat, so there is no extra Stat round trip.
+func openRegularFile(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) (afero.File, bool) {
+	f, err := openFile(c, fs, path)
+	if err != nil {
+		if errors.Is(err, iofs.ErrNotExist) || errors.Is(err, iofs.ErrPermission) || errors.Is(err, syscall.ENOTDIR) {
+			return nil, c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
+		}
+
+		return nil, c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not open %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	info, err := f.Stat()
+
+	c.visited++
+
+	if c.trace {
+		if err != nil {
+			c.tracef("fstat(%q): %s", path, err)
+		} else {
+			c.tracef("fstat(%q): %s", path, info.Mode())
 		}
+	}
+
+	if err != nil {
+		_ = f.Close() // nolint: errcheck
 
-		return assert.Fail(t, fmt.Sprintf("error when running stat(%q): %s", path, err), msgAndArgs...)
+		return nil, c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not stat %q: %s", path, err)}, msgAndArgs...)
 	}
 
 	if info.IsDir() {
-		return assert.Fail(t, fmt.Sprintf("%q is a directory", path), msgAndArgs...)
+		_ = f.Close() // nolint: errcheck
+
+		return nil, c.fail(t, FailureInfo{Kind: FailureType, Path: path, Message: fmt.Sprintf("%q is a directory", path)}, msgAndArgs...)
 	}
 
-	return true
+	return f, true
 }
 
 // NoFileExists checks whether a file does not exist in a given path. It fails
@@ -93,7 +220,15 @@ func NoFileExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{
 		h.Helper()
 	}
 
-	info, err := stat(fs, path)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NoFileExists", func(t TestingT) bool {
+		return assertNoFileExists(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertNoFileExists(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	info, err := stat(c, fs, path)
 	if err != nil {
 		return true
 	}
@@ -102,7 +237,7 @@ func NoFileExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{
 		return true
 	}
 
-	return assert.Fail(t, fmt.Sprintf("file %q exists", path), msgAndArgs...)
+	return c.fail(t, FailureInfo{Kind: FailureUnexpected, Path: path, Message: fmt.Sprintf("file %q exists", path)}, msgAndArgs...)
 }
 
 // DirExists checks whether a directory exists in the given path. It also fails
@@ -112,17 +247,21 @@ func DirExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{})
 		h.Helper()
 	}
 
-	info, err := stat(fs, path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return assert.Fail(t, fmt.Sprintf("unable to find file %q", path), msgAndArgs...)
-		}
+	c, msgAndArgs := newConfig(msgAndArgs)
 
-		return assert.Fail(t, fmt.Sprintf("error when running stat(%q): %s", path, err), msgAndArgs...)
+	return c.run(t, "DirExists", func(t TestingT) bool {
+		return assertDirExists(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertDirExists(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	info, err := stat(c, fs, path)
+	if err != nil {
+		return c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
 	}
 
 	if !info.IsDir() {
-		return assert.Fail(t, fmt.Sprintf("%q is a file", path), msgAndArgs...)
+		return c.fail(t, FailureInfo{Kind: FailureType, Path: path, Message: fmt.Sprintf("%q is a file", path)}, msgAndArgs...)
 	}
 
 	return true
@@ -135,7 +274,15 @@ func NoDirExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}
 		h.Helper()
 	}
 
-	info, err := stat(fs, path)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NoDirExists", func(t TestingT) bool {
+		return assertNoDirExists(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertNoDirExists(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	info, err := stat(c, fs, path)
 	if err != nil {
 		return true
 	}
@@ -144,60 +291,151 @@ func NoDirExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}
 		return true
 	}
 
-	return assert.Fail(t, fmt.Sprintf("directory %q exists", path), msgAndArgs...)
+	return c.fail(t, FailureInfo{Kind: FailureUnexpected, Path: path, Message: fmt.Sprintf("directory %q exists", path)}, msgAndArgs...)
 }
 
-// Perm checks whether a path has the expected permission or not.
+// Perm checks whether a path has the expected permission or not. The setuid, setgid and sticky bits are compared too,
+// they can be set either with os.FileMode, such as os.ModeSetgid|0o755, or in the Unix octal notation, such as 0o2755.
 func Perm(t TestingT, fs afero.Fs, path string, expected os.FileMode, msgAndArgs ...interface{}) bool {
 	if h, ok := t.(tHelper); ok {
 		h.Helper()
 	}
 
-	info, err := stat(fs, path)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "Perm", func(t TestingT) bool {
+		return assertPerm(t, c, fs, path, expected, msgAndArgs...)
+	})
+}
+
+func assertPerm(t TestingT, c *config, fs afero.Fs, path string, expected os.FileMode, msgAndArgs ...interface{}) bool {
+	info, err := stat(c, fs, path)
 	if err != nil {
-		return assert.Fail(t, fmt.Sprintf("error when running stat(%q): %s", path, err), msgAndArgs...)
+		return c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
+	}
+
+	if c.ignorePerm {
+		return true
 	}
 
-	actual := info.Mode() & os.ModePerm
+	expected = normalizePerm(expected)
+	actual := info.Mode() & permBits
 
-	if expected != actual {
-		return assert.Fail(t, fmt.Sprintf("%q permission is 0%o, expected 0%o", path, actual, expected), msgAndArgs...)
+	if !permMatches(c, fs, expected, actual) {
+		return c.fail(t, FailureInfo{
+			Kind:     FailurePerm,
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q permission is %s, expected %s", path, formatPerm(actual), formatPerm(expected)),
+		}, msgAndArgs...)
 	}
 
 	return true
 }
 
-// FileContent checks whether a file content is as expected or not.
-func FileContent(t TestingT, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) bool {
-	if !FileExists(t, fs, path, msgAndArgs...) {
+// FileSize checks whether a file has the expected size. With AllocatedSize, the number of bytes allocated on disk is
+// checked instead of the logical size.
+func FileSize(t TestingT, fs afero.Fs, path string, expected int64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileSize", func(t TestingT) bool {
+		return assertFileSize(t, c, fs, path, expected, msgAndArgs...)
+	})
+}
+
+func assertFileSize(t TestingT, c *config, fs afero.Fs, path string, expected int64, msgAndArgs ...interface{}) bool {
+	info, ok := statFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
 		return false
 	}
 
-	f, err := fs.Open(path)
-	if err != nil {
-		return assert.Fail(t, fmt.Sprintf("could not open %q: %s", path, err), msgAndArgs...)
+	if c.allocatedSize {
+		actual, ok := allocatedSize(info)
+		if !ok {
+			return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not get allocated size of %q", path)}, msgAndArgs...)
+		}
+
+		if actual != expected {
+			return c.fail(t, FailureInfo{
+				Kind:     FailureSize,
+				Path:     path,
+				Expected: expected,
+				Actual:   actual,
+				Message:  fmt.Sprintf("%q allocated size is %d, expected %d", path, actual, expected),
+			}, msgAndArgs...)
+		}
+
+		return true
 	}
 
-	defer f.Close() // nolint: errcheck
+	if actual := info.Size(); actual != expected {
+		return c.fail(t, FailureInfo{
+			Kind:     FailureSize,
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q size is %d, expected %d", path, actual, expected),
+		}, msgAndArgs...)
+	}
 
-	buf := new(bytes.Buffer)
+	return true
+}
 
-	if _, err := io.Copy(buf, f); err != nil {
-		return assert.Fail(t, fmt.Sprintf("could not read %q: %s", path, err), msgAndArgs...)
+// FileSparse checks whether a file is sparse, which means fewer bytes are allocated on disk than its logical size.
+// The file system has to expose the allocated blocks via Sys(), like afero.OsFs on Unix does.
+func FileSparse(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
 
-	return assert.Equal(t, expected, buf.String(), msgAndArgs...)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileSparse", func(t TestingT) bool {
+		return assertFileSparse(t, c, fs, path, msgAndArgs...)
+	})
 }
 
-// FileContentRegexp checks whether a file content matches the expectation or not.
-func FileContentRegexp(t TestingT, fs afero.Fs, path string, expected interface{}, msgAndArgs ...interface{}) bool {
-	if !FileExists(t, fs, path, msgAndArgs...) {
+func assertFileSparse(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	info, ok := statFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
 		return false
 	}
 
-	f, err := fs.Open(path)
-	if err != nil {
-		return assert.Fail(t, fmt.Sprintf("could not open %q: %s", path, err), msgAndArgs...)
+	allocated, ok := allocatedSize(info)
+	if !ok {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not get allocated size of %q", path)}, msgAndArgs...)
+	}
+
+	if allocated >= info.Size() {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("%q is not sparse, %d bytes allocated for size %d", path, allocated, info.Size())}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// FileContent checks whether a file content is as expected or not. When the content does not match, the failure
+// message contains a unified diff of the expected and the actual content, see WithDiffContext.
+func FileContent(t TestingT, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileContent", func(t TestingT) bool {
+		return assertFileContent(t, c, fs, path, expected, msgAndArgs...)
+	})
+}
+
+func assertFileContent(t TestingT, c *config, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) bool {
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
 	}
 
 	defer f.Close() // nolint: errcheck
@@ -205,144 +443,187 @@ func FileContentRegexp(t TestingT, fs afero.Fs, path string, expected interface{
 	buf := new(bytes.Buffer)
 
 	if _, err := io.Copy(buf, f); err != nil {
-		return assert.Fail(t, fmt.Sprintf("could not read %q: %s", path, err), msgAndArgs...)
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	if expected, actual := c.normalize(expected), c.normalize(buf.String()); expected != actual {
+		return c.fail(t, FailureInfo{
+			Kind:     FailureContent,
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q content is not as expected:\n\n%s", path, c.diff(expected, actual)),
+		}, msgAndArgs...)
 	}
 
-	return assert.Regexp(t, expected, buf.String(), msgAndArgs...)
+	return true
 }
 
-// TreeEqual checks whether a directory is the same as the expectation or not.
-func TreeEqual(t TestingT, fs afero.Fs, tree FileTree, path string, msgAndArgs ...interface{}) bool {
+// FileContentRegexp checks whether a file content matches the expectation or not.
+func FileContentRegexp(t TestingT, fs afero.Fs, path string, expected interface{}, msgAndArgs ...interface{}) bool {
 	if h, ok := t.(tHelper); ok {
 		h.Helper()
 	}
 
-	return assertTree(t, fs, tree, path, true, msgAndArgs...)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileContentRegexp", func(t TestingT) bool {
+		return assertFileContentRegexp(t, c, fs, path, expected, msgAndArgs...)
+	})
 }
 
-// YAMLTreeEqual checks whether a directory is the same as the expectation or not.
-func YAMLTreeEqual(t TestingT, fs afero.Fs, expected, path string, msgAndArgs ...interface{}) bool {
-	if h, ok := t.(tHelper); ok {
-		h.Helper()
+func assertFileContentRegexp(t TestingT, c *config, fs afero.Fs, path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
 	}
 
-	var ft FileTree
+	defer f.Close() // nolint: errcheck
 
-	if err := yaml.Unmarshal([]byte(expected), &ft); err != nil {
-		return assert.Fail(t, "could not unmarshal expectation", msgAndArgs...)
+	var r io.Reader = f
+
+	if c.readLimit > 0 {
+		r = io.LimitReader(f, c.readLimit)
 	}
 
-	return TreeEqual(t, fs, ft, path, msgAndArgs...)
-}
+	// The content is matched while it is read, only the beginning is kept for the failure message.
+	preview := &previewBuffer{limit: regexpPreviewSize}
+	rr := &errRuneReader{r: bufio.NewReader(io.TeeReader(r, preview))}
 
-// TreeContains checks whether a directory contains a file tree or not.
-func TreeContains(t TestingT, fs afero.Fs, tree FileTree, path string, msgAndArgs ...interface{}) bool {
-	if h, ok := t.(tHelper); ok {
-		h.Helper()
+	matched := toRegexp(expected).MatchReader(rr)
+
+	if rr.err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, rr.err)}, msgAndArgs...)
+	}
+
+	if !matched {
+		actual := preview.String()
+
+		return c.fail(t, FailureInfo{
+			Kind:     FailureContent,
+			Path:     path,
+			Expected: toRegexp(expected).String(),
+			Actual:   actual,
+			Message:  fmt.Sprintf("Expect \"%v\" to match \"%v\"", c.truncate(actual), toRegexp(expected).String()),
+		}, msgAndArgs...)
 	}
 
-	return assertTree(t, fs, tree, path, false, msgAndArgs...)
+	return true
 }
 
-// YAMLTreeContains checks whether a directory contains a file tree or not.
-func YAMLTreeContains(t TestingT, fs afero.Fs, expected, path string, msgAndArgs ...interface{}) bool {
+// FileSemanticEqual checks whether a file content is equal to the expectation using the comparer registered for the
+// file extension, see RegisterComparer. The files without a registered comparer are compared byte by byte.
+func FileSemanticEqual(t TestingT, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) bool {
 	if h, ok := t.(tHelper); ok {
 		h.Helper()
 	}
 
-	var ft FileTree
+	c, msgAndArgs := newConfig(msgAndArgs)
 
-	if err := yaml.Unmarshal([]byte(expected), &ft); err != nil {
-		return assert.Fail(t, "could not unmarshal expectation", msgAndArgs...)
-	}
-
-	return TreeContains(t, fs, ft, path, msgAndArgs...)
+	return c.run(t, "FileSemanticEqual", func(t TestingT) bool {
+		return assertFileSemanticEqual(t, c, fs, path, expected, msgAndArgs...)
+	})
 }
 
-// nolint: funlen, cyclop
-func assertTree(t TestingT, fs afero.Fs, tree FileTree, root string, exhaustive bool, msgAndArgs ...interface{}) bool {
-	root = filepath.Clean(root)
-	expectations := tree.Flatten("")
-	result := true
+func assertFileSemanticEqual(t TestingT, c *config, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) bool {
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
 
-	fail := func(failureMessage string, args ...interface{}) bool {
-		result = false
+	defer f.Close() // nolint: errcheck
 
-		return assert.Fail(t, fmt.Sprintf(failureMessage, args...), msgAndArgs...)
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
 	}
 
-	err := afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	if msg, ok := compareContent(c, path, raw, expected); !ok {
+		return c.fail(t, FailureInfo{Kind: FailureContent, Path: path, Message: msg}, msgAndArgs...)
+	}
 
-		if path == root {
-			return nil
-		}
+	return true
+}
 
-		expectedPath := strings.TrimPrefix(path, root+string(os.PathSeparator))
-		expected, ok := expectations[expectedPath]
+// assertContent compares the file content with the comparer registered for the file extension and returns the failure
+// message if they are not equal.
+func assertContent(fs afero.Fs, path string, expected string, c *config) (string, bool) {
+	raw, err := readFile(c, fs, path)
+	if err != nil {
+		return fmt.Sprintf("could not read %q: %s", path, err), false
+	}
 
-		if !ok {
-			if exhaustive {
-				fail("unexpected file %q", path)
-			}
+	return compareContent(c, path, raw, expected)
+}
 
-			return nil
-		}
+// compareContent compares the content with the comparer registered for the file extension.
+func compareContent(c *config, path string, raw []byte, expected string) (string, bool) {
+	expected, actual := c.normalize(expected), c.normalize(string(raw))
 
-		if expected.IsDir {
-			if !info.IsDir() {
-				fail("%q is not a directory", path)
+	equal, err := comparerFor(path)([]byte(expected), []byte(actual))
+	if err != nil {
+		return fmt.Sprintf("could not compare %q: %s", path, err), false
+	}
 
-				return nil
-			}
-		} else if info.IsDir() {
-			fail("%q is a directory", path)
+	if !equal {
+		return fmt.Sprintf("%q content is not as expected:\n\n%s", path, c.diff(expected, actual)), false
+	}
 
-			return nil
-		}
+	return "", true
+}
 
-		if m := expected.Tags.Mode(); m != nil {
-			expected := fileModeToString(*m)
-			actual := fileModeToString(info.Mode())
+// TreeEqual checks whether a directory is the same as the expectation or not.
+func TreeEqual(t TestingT, fs afero.Fs, tree FileTree, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 
-			if expected != actual {
-				fail("%q mode is %s, expected %s", path, actual, expected)
-			}
-		}
+	c, msgAndArgs := newConfig(msgAndArgs)
 
-		if expected := expected.Tags.Perm(); expected != nil {
-			actual := info.Mode() & os.ModePerm
+	return c.run(t, "TreeEqual", func(t TestingT) bool {
+		return assertTree(t, c, fs, tree, path, true, msgAndArgs...)
+	})
+}
 
-			if *expected != actual {
-				fail("%q perm is 0%o, expected 0%o", path, actual, *expected)
-			}
-		}
+// YAMLTreeEqual checks whether a directory is the same as the expectation or not.
+func YAMLTreeEqual(t TestingT, fs afero.Fs, expected, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 
-		delete(expectations, expectedPath)
+	c, msgAndArgs := newConfig(msgAndArgs)
 
-		return nil
-	})
-	if err != nil {
-		return fail("could not walk through %q: %s", root, err)
-	}
+	return assertTreeFrom(t, c, "YAMLTreeEqual", func() (FileTree, string, error) {
+		ft, err := parseTree("", []byte(expected))
 
-	if !result {
-		return false
-	}
+		return ft, path, err
+	}, fs, path, true, msgAndArgs...)
+}
 
-	if len(expectations) == 0 {
-		return true
+// TreeContains checks whether a directory contains a file tree or not.
+func TreeContains(t TestingT, fs afero.Fs, tree FileTree, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
 
-	var sb strings.Builder
+	c, msgAndArgs := newConfig(msgAndArgs)
 
-	_, _ = sb.WriteString("expected these files in %q but not found:\n")
+	return c.run(t, "TreeContains", func(t TestingT) bool {
+		return assertTree(t, c, fs, tree, path, false, msgAndArgs...)
+	})
+}
 
-	for k := range expectations {
-		_, _ = fmt.Fprintf(&sb, "- %s\n", k)
+// YAMLTreeContains checks whether a directory contains a file tree or not.
+func YAMLTreeContains(t TestingT, fs afero.Fs, expected, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
 
-	return fail(sb.String(), root)
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return assertTreeFrom(t, c, "YAMLTreeContains", func() (FileTree, string, error) {
+		ft, err := parseTree("", []byte(expected))
+
+		return ft, path, err
+	}, fs, path, false, msgAndArgs...)
 }
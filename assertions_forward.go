@@ -0,0 +1,677 @@
+package aferoassert
+
+import (
+	"io"
+	iofs "io/fs"
+	"os"
+	"testing/fstest"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Assertions provides the assertions bound to a TestingT and a file system, so they don't have to be passed on every
+// call.
+type Assertions struct {
+	t  TestingT
+	fs afero.Fs
+}
+
+// New creates the assertions for the TestingT and the file system, for example:
+//
+//	a := aferoassert.New(t, fs)
+//
+//	a.FileExists("config.yaml")
+//	a.YAMLTreeEqual(tree, ".")
+func New(t TestingT, fs afero.Fs) *Assertions {
+	return &Assertions{t: t, fs: fs}
+}
+
+// Exists checks whether a path exists.
+func (a *Assertions) Exists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Exists(a.t, a.fs, path, msgAndArgs...)
+}
+
+// NoExists checks whether a file does not exist in a given path.
+func (a *Assertions) NoExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoExists(a.t, a.fs, path, msgAndArgs...)
+}
+
+// FileExists checks whether a file exists in the given path.
+func (a *Assertions) FileExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileExists(a.t, a.fs, path, msgAndArgs...)
+}
+
+// NoFileExists checks whether a file does not exist in a given path.
+func (a *Assertions) NoFileExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoFileExists(a.t, a.fs, path, msgAndArgs...)
+}
+
+// DirExists checks whether a directory exists in the given path.
+func (a *Assertions) DirExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return DirExists(a.t, a.fs, path, msgAndArgs...)
+}
+
+// NoDirExists checks whether a directory does not exist in the given path.
+func (a *Assertions) NoDirExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoDirExists(a.t, a.fs, path, msgAndArgs...)
+}
+
+// Perm checks whether a path has the expected permission or not.
+func (a *Assertions) Perm(path string, expected os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Perm(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileSize checks whether a file has the expected size.
+func (a *Assertions) FileSize(path string, expected int64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileSize(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileSparse checks whether a file is sparse.
+func (a *Assertions) FileSparse(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileSparse(a.t, a.fs, path, msgAndArgs...)
+}
+
+// FileContent checks whether a file content is as expected or not.
+func (a *Assertions) FileContent(path string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileContent(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileContentRegexp checks whether a file content matches the expectation or not.
+func (a *Assertions) FileContentRegexp(path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileContentRegexp(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileSemanticEqual checks whether a file content is equal to the expectation using the registered comparer.
+func (a *Assertions) FileSemanticEqual(path string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileSemanticEqual(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// TreeEqual checks whether a directory is the same as the expectation or not.
+func (a *Assertions) TreeEqual(tree FileTree, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeEqual(a.t, a.fs, tree, path, msgAndArgs...)
+}
+
+// YAMLTreeEqual checks whether a directory is the same as the expectation or not.
+func (a *Assertions) YAMLTreeEqual(expected, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return YAMLTreeEqual(a.t, a.fs, expected, path, msgAndArgs...)
+}
+
+// TreeContains checks whether a directory contains a file tree or not.
+func (a *Assertions) TreeContains(tree FileTree, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeContains(a.t, a.fs, tree, path, msgAndArgs...)
+}
+
+// YAMLTreeContains checks whether a directory contains a file tree or not.
+func (a *Assertions) YAMLTreeContains(expected, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return YAMLTreeContains(a.t, a.fs, expected, path, msgAndArgs...)
+}
+
+// EmbeddedTreeEqual checks whether a directory has exactly the files of an embedded file system.
+func (a *Assertions) EmbeddedTreeEqual(embedded iofs.FS, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return EmbeddedTreeEqual(a.t, embedded, a.fs, root, msgAndArgs...)
+}
+
+// EmbeddedFileEqual checks whether a file has the same content as a file of an embedded file system.
+func (a *Assertions) EmbeddedFileEqual(embedded iofs.FS, name string, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return EmbeddedFileEqual(a.t, embedded, name, a.fs, path, msgAndArgs...)
+}
+
+// MapFSEqual checks whether a directory has exactly the files of a fstest.MapFS with the same content.
+func (a *Assertions) MapFSEqual(expected fstest.MapFS, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return MapFSEqual(a.t, expected, a.fs, root, msgAndArgs...)
+}
+
+// MapFSContains checks whether a directory contains the files of a fstest.MapFS with the same content.
+func (a *Assertions) MapFSContains(expected fstest.MapFS, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return MapFSContains(a.t, expected, a.fs, root, msgAndArgs...)
+}
+
+// FsConformance runs a battery of checks against the file system to verify that it behaves like the standard ones.
+func (a *Assertions) FsConformance(msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FsConformance(a.t, a.fs, msgAndArgs...)
+}
+
+// FsEqual checks whether a directory has the same files, permissions and content in both file systems, the bound file
+// system is the actual one.
+func (a *Assertions) FsEqual(expected afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FsEqual(a.t, expected, a.fs, root, msgAndArgs...)
+}
+
+// AssertUnchanged checks whether a directory is the same as when the snapshot was taken, see Fingerprint.
+func (a *Assertions) AssertUnchanged(root string, snapshot Snapshot, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertUnchanged(a.t, a.fs, root, snapshot, msgAndArgs...)
+}
+
+// AssertOnlyChanged checks whether only the allowed paths of a directory were created, removed or modified since the
+// snapshot was taken.
+func (a *Assertions) AssertOnlyChanged(root string, snapshot Snapshot, allowed []string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertOnlyChanged(a.t, a.fs, root, snapshot, allowed, msgAndArgs...)
+}
+
+// DirGolden checks whether a directory has exactly the files of a reference directory on disk, or updates the
+// reference directory when the test runs with -update.
+func (a *Assertions) DirGolden(actualRoot, goldenDir string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return DirGolden(a.t, a.fs, actualRoot, goldenDir, msgAndArgs...)
+}
+
+// DirChecksumEqual checks whether the checksum of a directory is the expected one.
+func (a *Assertions) DirChecksumEqual(root string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return DirChecksumEqual(a.t, a.fs, root, expected, msgAndArgs...)
+}
+
+// SecureTree audits a directory and reports all the violations of the security policy together.
+func (a *Assertions) SecureTree(root string, policy SecurityPolicy, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return SecureTree(a.t, a.fs, root, policy, msgAndArgs...)
+}
+
+// AssertPermPolicy checks whether every path of a directory satisfies the rules of the permission policy.
+func (a *Assertions) AssertPermPolicy(root string, policy PermPolicy, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertPermPolicy(a.t, a.fs, root, policy, msgAndArgs...)
+}
+
+// AssertNoWrites checks whether no operation modified the recorded file system or opened a file for writing.
+func (a *Assertions) AssertNoWrites(rec *RecordedFs, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertNoWrites(a.t, rec, msgAndArgs...)
+}
+
+// AssertRemoved checks whether the path was successfully removed from the recorded file system.
+func (a *Assertions) AssertRemoved(rec *RecordedFs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertRemoved(a.t, rec, path, msgAndArgs...)
+}
+
+// AssertOpenedOnce checks whether the path was opened exactly once in the recorded file system.
+func (a *Assertions) AssertOpenedOnce(rec *RecordedFs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertOpenedOnce(a.t, rec, path, msgAndArgs...)
+}
+
+// AssertFsReadOnly checks whether the file system rejects the mutations with a permission error.
+func (a *Assertions) AssertFsReadOnly(msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertFsReadOnly(a.t, a.fs, msgAndArgs...)
+}
+
+// InLayer checks whether a path exists in the file system, as a layer of a union file system.
+func (a *Assertions) InLayer(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return InLayer(a.t, a.fs, path, msgAndArgs...)
+}
+
+// NotInBase checks whether a path does not exist in the file system, as the base of a union file system.
+func (a *Assertions) NotInBase(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NotInBase(a.t, a.fs, path, msgAndArgs...)
+}
+
+// UnionResolvesTo checks whether a read of a path in the file system, as a union, is served from the expected layer.
+func (a *Assertions) UnionResolvesTo(layer afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return UnionResolvesTo(a.t, a.fs, layer, path, msgAndArgs...)
+}
+
+// CachedAfterRead reads a file through the file system, as a cache file system, and checks whether the cache layer
+// then contains the file.
+func (a *Assertions) CachedAfterRead(cache afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return CachedAfterRead(a.t, a.fs, cache, path, msgAndArgs...)
+}
+
+// CacheExpired checks whether the next read of a file, in the file system as the base layer, is not served from the
+// cache layer.
+func (a *Assertions) CacheExpired(cache afero.Fs, path string, cacheTime time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return CacheExpired(a.t, a.fs, cache, path, cacheTime, msgAndArgs...)
+}
+
+// OpenFileContent checks whether the content of an open file is equal to the expectation.
+func (a *Assertions) OpenFileContent(f afero.File, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return OpenFileContent(a.t, f, expected, msgAndArgs...)
+}
+
+// OpenFileSize checks whether the size of an open file is equal to the expectation.
+func (a *Assertions) OpenFileSize(f afero.File, expected int64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return OpenFileSize(a.t, f, expected, msgAndArgs...)
+}
+
+// OpenFileAt checks whether an open file has the expected bytes at the offset.
+func (a *Assertions) OpenFileAt(f afero.File, offset int64, expected []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return OpenFileAt(a.t, f, offset, expected, msgAndArgs...)
+}
+
+// Xattr checks whether a path has the extended attribute with the expected value.
+func (a *Assertions) Xattr(path, name, value string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Xattr(a.t, a.fs, path, name, value, msgAndArgs...)
+}
+
+// TreeWithinQuota checks whether the files of a directory take at most maxBytes bytes and are at most maxFiles.
+func (a *Assertions) TreeWithinQuota(root string, maxBytes, maxFiles int64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeWithinQuota(a.t, a.fs, root, maxBytes, maxFiles, msgAndArgs...)
+}
+
+// FsContentSetEqual checks whether the regular files of a directory have the same set of contents in both file
+// systems, regardless of their paths.
+func (a *Assertions) FsContentSetEqual(expected afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FsContentSetEqual(a.t, expected, a.fs, root, msgAndArgs...)
+}
+
+// WaitForPath waits until a path exists, it fails if the path does not exist before the timeout.
+func (a *Assertions) WaitForPath(path string, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return WaitForPath(a.t, a.fs, path, timeout, msgAndArgs...)
+}
+
+// AssertConcurrently runs independent checks on the file system in a pool of workers and reports their failures in
+// order.
+func (a *Assertions) AssertConcurrently(checks ...Check) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return AssertConcurrently(a.t, a.fs, checks...)
+}
+
+// FileCRC32 checks whether the CRC-32 (IEEE) of the content of a file is the expected one.
+func (a *Assertions) FileCRC32(path string, expected uint32, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileCRC32(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileXXHash checks whether the 64-bit xxHash of the content of a file is the expected one.
+func (a *Assertions) FileXXHash(path string, expected uint64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileXXHash(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// TreePerms checks whether every regular file under a directory has the file permission and every directory under it
+// has the directory permission.
+func (a *Assertions) TreePerms(root string, filePerm, dirPerm os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreePerms(a.t, a.fs, root, filePerm, dirPerm, msgAndArgs...)
+}
+
+// NoSpecialFiles checks whether a directory contains only regular files, directories and symlinks.
+func (a *Assertions) NoSpecialFiles(root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoSpecialFiles(a.t, a.fs, root, msgAndArgs...)
+}
+
+// NoHardlinks checks whether none of the regular files of a directory has more than one link.
+func (a *Assertions) NoHardlinks(root string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoHardlinks(a.t, a.fs, root, msgAndArgs...)
+}
+
+// RealPathEqual checks whether a path of a file system that maps the paths, such as afero.BasePathFs, is mapped to the
+// expected real path.
+func (a *Assertions) RealPathEqual(virtualPath, expectedReal string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return RealPathEqual(a.t, a.fs, virtualPath, expectedReal, msgAndArgs...)
+}
+
+// Mirrored checks whether a directory is an exact mirror of another directory of the same file system.
+func (a *Assertions) Mirrored(srcRoot, dstRoot string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Mirrored(a.t, a.fs, srcRoot, dstRoot, msgAndArgs...)
+}
+
+// YAMLTreeEqualFile checks whether a directory is the same as the YAML expectation read from a file.
+func (a *Assertions) YAMLTreeEqualFile(expectationFs afero.Fs, expectationPath, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return YAMLTreeEqualFile(a.t, a.fs, expectationFs, expectationPath, path, msgAndArgs...)
+}
+
+// YAMLTreeContainsFile checks whether a directory contains the YAML expectation read from a file.
+func (a *Assertions) YAMLTreeContainsFile(expectationFs afero.Fs, expectationPath, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return YAMLTreeContainsFile(a.t, a.fs, expectationFs, expectationPath, path, msgAndArgs...)
+}
+
+// TreeEqualFromReader checks whether a directory is the same as the YAML expectation read from a reader.
+func (a *Assertions) TreeEqualFromReader(r io.Reader, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeEqualFromReader(a.t, a.fs, r, path, msgAndArgs...)
+}
+
+// TreeContainsFromReader checks whether a directory contains the YAML expectation read from a reader.
+func (a *Assertions) TreeContainsFromReader(r io.Reader, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeContainsFromReader(a.t, a.fs, r, path, msgAndArgs...)
+}
+
+// TreeEqualFromBytes checks whether a directory is the same as the YAML expectation.
+func (a *Assertions) TreeEqualFromBytes(expected []byte, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeEqualFromBytes(a.t, a.fs, expected, path, msgAndArgs...)
+}
+
+// TreeContainsFromBytes checks whether a directory contains the YAML expectation.
+func (a *Assertions) TreeContainsFromBytes(expected []byte, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeContainsFromBytes(a.t, a.fs, expected, path, msgAndArgs...)
+}
+
+// JSONFileValue checks whether the value at a JSON path of a JSON file is equal to the expectation.
+func (a *Assertions) JSONFileValue(path, query string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return JSONFileValue(a.t, a.fs, path, query, expected, msgAndArgs...)
+}
+
+// FileValid checks whether a file is valid according to the validator registered for its extension.
+func (a *Assertions) FileValid(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileValid(a.t, a.fs, path, msgAndArgs...)
+}
+
+// FileLinesContain checks whether a file contains all the lines, in any order.
+func (a *Assertions) FileLinesContain(path string, lines ...string) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileLinesContain(a.t, a.fs, path, lines...)
+}
+
+// FileCompressedAs checks whether a file is compressed with one of the expected formats by checking its magic bytes.
+func (a *Assertions) FileCompressedAs(path string, expected Compression, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileCompressedAs(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileHeader checks whether a file starts with the expected bytes.
+func (a *Assertions) FileHeader(path string, expected []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileHeader(a.t, a.fs, path, expected, msgAndArgs...)
+}
+
+// FileHeaderAt checks whether a file has the expected bytes at the offset.
+func (a *Assertions) FileHeaderAt(path string, offset int64, expected []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileHeaderAt(a.t, a.fs, path, offset, expected, msgAndArgs...)
+}
+
+// ImageDimensions checks whether an image has the expected width and height.
+func (a *Assertions) ImageDimensions(path string, width, height int, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return ImageDimensions(a.t, a.fs, path, width, height, msgAndArgs...)
+}
+
+// SymlinkIsRelative checks whether a path is a symlink with a relative target.
+func (a *Assertions) SymlinkIsRelative(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return SymlinkIsRelative(a.t, a.fs, path, msgAndArgs...)
+}
+
+// SymlinkIsAbsolute checks whether a path is a symlink with an absolute target.
+func (a *Assertions) SymlinkIsAbsolute(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return SymlinkIsAbsolute(a.t, a.fs, path, msgAndArgs...)
+}
+
+// NewestFileMatches checks whether the most recently modified entry of a directory has a name matching the pattern.
+func (a *Assertions) NewestFileMatches(dir, pattern string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NewestFileMatches(a.t, a.fs, dir, pattern, msgAndArgs...)
+}
+
+// RotationPolicy checks whether the rotated files of a log in a directory follow the naming scheme and are ordered by
+// their modification times.
+func (a *Assertions) RotationPolicy(dir, base string, maxRotated int, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return RotationPolicy(a.t, a.fs, dir, base, maxRotated, msgAndArgs...)
+}
+
+// ModTimesAscending checks whether the modification times of the paths are strictly increasing.
+func (a *Assertions) ModTimesAscending(paths ...string) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return ModTimesAscending(a.t, a.fs, paths...)
+}
+
+// PermWithUmask checks whether a path has the permission that results from the requested one with the umask.
+func (a *Assertions) PermWithUmask(path string, requested, umask os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return PermWithUmask(a.t, a.fs, path, requested, umask, msgAndArgs...)
+}
@@ -0,0 +1,33 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file.txt", []byte("hello"), 0o644))
+
+	mockT := &testingT{}
+	a := aferoassert.New(mockT, fs)
+
+	assert.True(t, a.DirExists("root"))
+	assert.True(t, a.FileExists("root/file.txt"))
+	assert.True(t, a.FileContent("root/file.txt", "hello"))
+	assert.True(t, a.Perm("root/file.txt", 0o644))
+	assert.True(t, a.YAMLTreeEqual(`- file.txt`, "root"))
+	assert.Empty(t, mockT.errors)
+
+	assert.False(t, a.NoFileExists("root/file.txt"))
+	assert.False(t, a.FileContent("root/file.txt", "world", aferoassert.WithDiffContext(0)))
+	assert.Len(t, mockT.errors, 2)
+}
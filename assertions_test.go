@@ -2,8 +2,12 @@ package aferoassert_test
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"syscall"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -15,6 +19,18 @@ import (
 	"go.nhat.io/aferoassert"
 )
 
+type testingT struct {
+	errors []string
+}
+
+func (t *testingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *testingT) message() string {
+	return strings.Join(t.errors, "\n")
+}
+
 func getTempSymlinkPath(file string) (string, error) {
 	link := file + "_symlink"
 	err := os.Symlink(file, link)
@@ -84,6 +100,57 @@ func TestExists_CouldNotStat(t *testing.T) {
 	assert.False(t, aferoassert.Exists(mockT, fs, ".github"))
 }
 
+func TestExists_StatFailure(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		err      error
+		expected string
+	}{
+		{
+			scenario: "wrapped not exist error",
+			err:      fmt.Errorf("remote: %w", os.ErrNotExist),
+			expected: `unable to find file "dir/file"`,
+		},
+		{
+			scenario: "permission denied",
+			err:      &os.PathError{Op: "stat", Path: "dir/file", Err: syscall.EACCES},
+			expected: `permission denied when running stat("dir/file"): stat dir/file: permission denied, make sure all of its parent directories are searchable (+x)`,
+		},
+		{
+			scenario: "not a directory",
+			err:      &os.PathError{Op: "stat", Path: "dir/file", Err: syscall.ENOTDIR},
+			expected: `unable to find file "dir/file" because one of its parents is not a directory: stat dir/file: not a directory`,
+		},
+		{
+			scenario: "other error",
+			err:      errors.New("stat error"),
+			expected: `error when running stat("dir/file"): stat error`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			fs := aferomock.MockFs(func(fs *aferomock.Fs) {
+				fs.On("Stat", "dir/file").
+					Return(nil, tc.err)
+
+				fs.On("Open", "dir").Maybe().
+					Return(nil, os.ErrNotExist)
+			})(t)
+
+			mockT := &testingT{}
+
+			assert.False(t, aferoassert.Exists(mockT, fs, "dir/file"))
+			assert.Contains(t, mockT.message(), tc.expected)
+		})
+	}
+}
+
 func TestNoExists(t *testing.T) {
 	osFs := afero.NewOsFs()
 
@@ -181,6 +248,59 @@ func TestFileExists_CouldNotStat(t *testing.T) {
 	assert.False(t, aferoassert.FileExists(mockT, fs, ".github"))
 }
 
+func TestFileExists_DidYouMean(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/config.yaml", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/README.md", nil, 0o644))
+
+	testCases := []struct {
+		scenario string
+		path     string
+		expected string
+	}{
+		{
+			scenario: "wrong extension",
+			path:     "root/config.yml",
+			expected: `unable to find file "root/config.yml", did you mean "root/config.yaml"?`,
+		},
+		{
+			scenario: "case difference",
+			path:     "root/readme.md",
+			expected: `unable to find file "root/readme.md", did you mean "root/README.md"?`,
+		},
+		{
+			scenario: "typo",
+			path:     "root/cofnig.yaml",
+			expected: `unable to find file "root/cofnig.yaml", did you mean "root/config.yaml"?`,
+		},
+		{
+			scenario: "no suggestion",
+			path:     "root/unknown",
+			expected: `unable to find file "root/unknown"` + "\n",
+		},
+		{
+			scenario: "parent does not exist",
+			path:     "unknown/config.yaml",
+			expected: `unable to find file "unknown/config.yaml"` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.False(t, aferoassert.FileExists(mockT, fs, tc.path))
+			assert.Contains(t, mockT.message(), tc.expected)
+		})
+	}
+}
+
 func TestNoFileExists(t *testing.T) {
 	osFs := afero.NewOsFs()
 
@@ -327,6 +447,68 @@ func TestPerm_CouldNotStat(t *testing.T) {
 	assert.False(t, aferoassert.Perm(mockT, fs, ".github", 0o644))
 }
 
+func TestFileSize(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.txt", []byte("hello world!"), 0o644))
+
+	mockT := new(testing.T)
+	assert.True(t, aferoassert.FileSize(mockT, fs, "file.txt", 12))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSize(mockT, fs, "file.txt", 11))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSize(mockT, fs, "unknown.txt", 12))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSize(mockT, fs, "file.txt", 12, aferoassert.AllocatedSize()))
+
+	// The file is stat'ed once.
+	var stats aferoassert.Stats
+
+	assert.True(t, aferoassert.FileSize(mockT, fs, "file.txt", 12, aferoassert.WithStats(&stats)))
+	assert.Equal(t, 1, stats.FilesVisited)
+}
+
+func TestFileSparse(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	osFs := afero.NewOsFs()
+
+	sparse := filepath.Join(dir, "sparse")
+	dense := filepath.Join(dir, "dense")
+
+	f, err := osFs.Create(sparse)
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(1<<20))
+	require.NoError(t, f.Close())
+
+	require.NoError(t, afero.WriteFile(osFs, dense, []byte(strings.Repeat("x", 1<<16)), 0o644))
+
+	mockT := new(testing.T)
+	assert.True(t, aferoassert.FileSparse(mockT, osFs, sparse))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.FileSize(mockT, osFs, sparse, 1<<20))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.FileSize(mockT, osFs, sparse, 0, aferoassert.AllocatedSize()))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSparse(mockT, osFs, dense))
+
+	memFs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(memFs, "sparse", nil, 0o644))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSparse(mockT, memFs, "sparse"))
+}
+
 func TestFileContent_Success(t *testing.T) {
 	fs := afero.NewMemMapFs()
 
@@ -345,10 +527,40 @@ func TestFileContent_Success(t *testing.T) {
 	assert.False(t, aferoassert.FileContent(mockT, fs, ".github/file.txt", "wrong!"))
 }
 
+func TestFileContent_Diff(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	err := afero.WriteFile(fs, "file.txt", []byte("a\nb\nc\nd\ne\nf\ng\n"), 0o644)
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.FileContent(mockT, fs, "file.txt", "a\nb\nc\nx\ne\nf\ng\n", aferoassert.WithDiffContext(1)))
+
+	msg := mockT.message()
+
+	assert.Contains(t, msg, `"file.txt" content is not as expected`)
+	assert.Contains(t, msg, "--- Expected")
+	assert.Contains(t, msg, "+++ Actual")
+	assert.Contains(t, msg, "@@ -3,3 +3,3 @@")
+	assert.Contains(t, msg, "-x")
+	assert.Contains(t, msg, "+d")
+}
+
+// statErrorFile is a file that could not be stat'ed.
+type statErrorFile struct {
+	afero.File
+}
+
+func (f *statErrorFile) Stat() (os.FileInfo, error) {
+	return nil, errors.New("stat error")
+}
+
 func TestFileContent_CouldNotStat(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
-			Return(nil, errors.New("stat error"))
+		fs.On("Open", ".github/file.txt").
+			Return(&statErrorFile{File: mem.NewFileHandle(mem.CreateFile("file.txt"))}, nil)
 	})(t)
 
 	mockT := new(testing.T)
@@ -357,7 +569,10 @@ func TestFileContent_CouldNotStat(t *testing.T) {
 
 func TestFileContent_FileNotExists(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
+		fs.On("Open", ".github/file.txt").
+			Return(nil, os.ErrNotExist)
+
+		fs.On("Open", ".github").
 			Return(nil, os.ErrNotExist)
 	})(t)
 
@@ -367,11 +582,6 @@ func TestFileContent_FileNotExists(t *testing.T) {
 
 func TestFileContent_CouldNotOpen(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
-			Return(aferomock.NewFileInfo(func(i *aferomock.FileInfo) {
-				i.On("IsDir").Return(false)
-			}), nil)
-
 		fs.On("Open", ".github/file.txt").
 			Return(nil, errors.New("open error"))
 	})(t)
@@ -382,11 +592,6 @@ func TestFileContent_CouldNotOpen(t *testing.T) {
 
 func TestFileContent_FileIsClosed(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
-			Return(aferomock.NewFileInfo(func(i *aferomock.FileInfo) {
-				i.On("IsDir").Return(false)
-			}), nil)
-
 		f := mem.NewFileHandle(mem.CreateFile("file.txt"))
 		_ = f.Close() // nolint: errcheck
 
@@ -421,8 +626,8 @@ func TestFileContentRegexp_Success(t *testing.T) {
 
 func TestFileContentRegexp_CouldNotStat(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
-			Return(nil, errors.New("stat error"))
+		fs.On("Open", ".github/file.txt").
+			Return(&statErrorFile{File: mem.NewFileHandle(mem.CreateFile("file.txt"))}, nil)
 	})(t)
 
 	mockT := new(testing.T)
@@ -431,7 +636,10 @@ func TestFileContentRegexp_CouldNotStat(t *testing.T) {
 
 func TestFileContentRegexp_FileNotExists(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
+		fs.On("Open", ".github/file.txt").
+			Return(nil, os.ErrNotExist)
+
+		fs.On("Open", ".github").
 			Return(nil, os.ErrNotExist)
 	})(t)
 
@@ -441,11 +649,6 @@ func TestFileContentRegexp_FileNotExists(t *testing.T) {
 
 func TestFileContentRegexp_CouldNotOpen(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
-			Return(aferomock.NewFileInfo(func(i *aferomock.FileInfo) {
-				i.On("IsDir").Return(false)
-			}), nil)
-
 		fs.On("Open", ".github/file.txt").
 			Return(nil, errors.New("open error"))
 	})(t)
@@ -456,11 +659,6 @@ func TestFileContentRegexp_CouldNotOpen(t *testing.T) {
 
 func TestFileContentRegexp_FileIsClosed(t *testing.T) {
 	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
-		fs.On("Stat", ".github/file.txt").
-			Return(aferomock.NewFileInfo(func(i *aferomock.FileInfo) {
-				i.On("IsDir").Return(false)
-			}), nil)
-
 		f := mem.NewFileHandle(mem.CreateFile("file.txt"))
 		_ = f.Close() // nolint: errcheck
 
@@ -472,6 +670,31 @@ func TestFileContentRegexp_FileIsClosed(t *testing.T) {
 	assert.False(t, aferoassert.FileContentRegexp(mockT, fs, ".github/file.txt", "'"))
 }
 
+func TestFileSemanticEqual(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.json", []byte(`{"a": 1, "b": 2}`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "file.txt", []byte(`{"a": 1, "b": 2}`), 0o644))
+
+	mockT := new(testing.T)
+	assert.True(t, aferoassert.FileSemanticEqual(mockT, fs, "file.json", `{"b":2,"a":1}`))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSemanticEqual(mockT, fs, "file.json", `{"a": 2}`))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSemanticEqual(mockT, fs, "file.txt", `{"b":2,"a":1}`))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSemanticEqual(mockT, fs, "unknown.json", `{}`))
+
+	errT := &testingT{}
+	assert.False(t, aferoassert.FileSemanticEqual(errT, fs, "file.json", `{`))
+	assert.Contains(t, errT.message(), `could not compare "file.json": could not decode expected json`)
+}
+
 func TestTreeEqual_Success(t *testing.T) {
 	osFs := afero.NewOsFs()
 
@@ -535,6 +758,155 @@ func TestTreeEqual_Fail_ExpectMoreFiles(t *testing.T) {
 	assert.False(t, aferoassert.YAMLTreeEqual(mockT, osFs, tree, ".github"))
 }
 
+func TestTreeEqual_Fail_WithActualTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("file 1"), 0o644))
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, `- file 2`, "root", aferoassert.WithActualTree()))
+
+	assert.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `actual tree of "root"`)
+	assert.Contains(t, mockT.message(), `- file 1 'perm:"0644"'`)
+}
+
+func TestTreeEqual_Fail_WithActualTree_Failures(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("file 1"), 0o644))
+
+	failures := aferoassert.YAMLTreeEqualE(fs, `- file 2`, "root", aferoassert.WithActualTree())
+
+	// The snapshot is appended to the first failure only.
+	require.Len(t, failures, 2)
+	assert.Contains(t, failures[0].Message, `actual tree of "root"`)
+	assert.Contains(t, failures[0].Message, `- file 1 'perm:"0644"'`)
+	assert.NotContains(t, failures[1].Message, "actual tree")
+}
+
+func TestTreeEqual_Fail_MissingFilesAreSorted(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/unknown", []byte("unknown"), 0o644))
+
+	tree := `
+- folder 2:
+    - file 4
+    - file 3
+- file 2
+- file 1
+`
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root"))
+
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `unexpected file "root/unknown"`)
+
+	msg := mockT.errors[1]
+	indexes := []int{
+		strings.Index(msg, "- file 1"),
+		strings.Index(msg, "- file 2"),
+		strings.Index(msg, "- folder 2\n"),
+		strings.Index(msg, "- folder 2/"),
+		strings.Index(msg, "    - file 3"),
+		strings.Index(msg, "    - file 4"),
+	}
+
+	for i := range indexes {
+		require.NotEqual(t, -1, indexes[i], "item %d is not in the message:\n%s", i, msg)
+
+		if i > 0 {
+			assert.Less(t, indexes[i-1], indexes[i], msg)
+		}
+	}
+}
+
+func TestTreeContains_Fail_DidYouMean(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/folder/file.yaml", nil, 0o644))
+
+	tree := `
+- folder:
+    - file.yml
+`
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, tree, "root"))
+	assert.Contains(t, mockT.message(), `- file.yml, did you mean "root/folder/file.yaml"?`)
+}
+
+func TestTreeContains_Content(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/config.json", []byte(`{"name": "aferoassert"}`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file.txt", []byte("hello\nworld\n"), 0o644))
+
+	tree := `
+- config.json 'content:"{\"name\":\"aferoassert\"}"'
+- file.txt 'content:"hello\nworld\n"'
+`
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeContains(mockT, fs, tree, "root"), mockT.message())
+
+	tree = `
+- file.txt 'content:"hello\nthere\n"'
+`
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, tree, "root"))
+	assert.Contains(t, mockT.message(), `"root/file.txt" content is not as expected`)
+	assert.Contains(t, mockT.message(), "+world")
+}
+
+type openRecordingFs struct {
+	afero.Fs
+
+	opened []string
+}
+
+func (fs *openRecordingFs) Open(name string) (afero.File, error) {
+	fs.opened = append(fs.opened, name)
+
+	return fs.Fs.Open(name)
+}
+
+func TestTreeContains_SkipUnexpectedDirectories(t *testing.T) {
+	t.Parallel()
+
+	fs := &openRecordingFs{Fs: afero.NewMemMapFs()}
+
+	require.NoError(t, afero.WriteFile(fs, "root/a/file", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/b/c/file", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/b/d/file", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/e/file", nil, 0o644))
+
+	tree := `
+- b:
+    - c:
+        - file
+`
+
+	fs.opened = nil
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeContains(mockT, fs, tree, "root"), mockT.message())
+	assert.Equal(t, []string{"root", "root/b", "root/b/c"}, fs.opened)
+}
+
 func TestTreeContains_Success(t *testing.T) {
 	osFs := afero.NewOsFs()
 
@@ -624,3 +996,62 @@ func TestTreeContains_Fail_DirIsExpected(t *testing.T) {
 	mockT := new(testing.T)
 	assert.False(t, aferoassert.YAMLTreeContains(mockT, osFs, tree, ".github"))
 }
+
+func TestFileContentRegexp_Streaming(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	content := "header: v1\n" + strings.Repeat("line\n", 10000) + "footer\n"
+
+	require.NoError(t, afero.WriteFile(fs, "file.log", []byte(content), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileContentRegexp(mockT, fs, "file.log", `footer\n$`), mockT.message())
+	assert.True(t, aferoassert.FileContentRegexp(mockT, fs, "file.log", `^header: v1`, aferoassert.WithReadLimit(16)), mockT.message())
+
+	assert.False(t, aferoassert.FileContentRegexp(mockT, fs, "file.log", `footer`, aferoassert.WithReadLimit(16)))
+	assert.False(t, aferoassert.FileContentRegexp(mockT, fs, "file.log", `unknown`))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `Expect "header: v1`)
+	assert.Contains(t, mockT.errors[0], `" to match "footer"`)
+	assert.Contains(t, mockT.errors[1], `..." to match "unknown"`)
+}
+
+func TestTreeEqual_TypeTag(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(dir, "tmp"), 0o755))
+	require.NoError(t, fs.Chmod(filepath.Join(dir, "tmp"), os.ModeSticky|0o777))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "file"), nil, 0o644))
+	require.NoError(t, os.Symlink("file", filepath.Join(dir, "link")))
+
+	tree := `
+- tmp 'type:"Dir"':
+- file 'type:"0"'
+- link 'type:"Symlink"'
+`
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, dir), mockT.message())
+
+	// The mode tag compares all the named bits, not only the type ones.
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, "- tmp 'mode:\"Dir\"':\n- file\n- link\n", dir))
+	assert.Contains(t, mockT.message(), `tmp" mode is Dir|Sticky, expected Dir`)
+
+	tree = `
+- tmp:
+- file 'type:"Symlink"'
+- link 'type:"NamedPipe"'
+`
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, dir))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `file" type is regular, expected Symlink`)
+	assert.Contains(t, mockT.errors[1], `link" type is Symlink, expected NamedPipe`)
+}
@@ -0,0 +1,70 @@
+package aferoassert
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// realPather is implemented by the file systems that map the paths to the underlying file system, such as
+// afero.BasePathFs.
+type realPather interface {
+	RealPath(name string) (string, error)
+}
+
+// realPathHint returns the underlying path to append to a failure message, if the file system maps the paths.
+func realPathHint(fs afero.Fs, path string) string {
+	p, ok := fs.(realPather)
+	if !ok {
+		return ""
+	}
+
+	realPath, err := p.RealPath(path)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" (real path %q)", realPath)
+}
+
+// RealPathEqual checks whether a path of a file system that maps the paths to the underlying file system, such as
+// afero.BasePathFs, is mapped to the expected real path, for example:
+//
+//	fs := afero.NewBasePathFs(afero.NewOsFs(), "/srv/data")
+//
+//	aferoassert.RealPathEqual(t, fs, "config.yaml", "/srv/data/config.yaml")
+func RealPathEqual(t TestingT, fs afero.Fs, virtualPath, expectedReal string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "RealPathEqual", func(t TestingT) bool {
+		return assertRealPathEqual(t, c, fs, virtualPath, expectedReal, msgAndArgs...)
+	})
+}
+
+func assertRealPathEqual(t TestingT, c *config, fs afero.Fs, virtualPath, expectedReal string, msgAndArgs ...interface{}) bool {
+	p, ok := fs.(realPather)
+	if !ok {
+		return c.fail(t, FailureInfo{Path: virtualPath, Message: fmt.Sprintf("%s does not map the paths to real paths", fs.Name())}, msgAndArgs...)
+	}
+
+	actual, err := p.RealPath(virtualPath)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: virtualPath, Message: fmt.Sprintf("could not get the real path of %q: %s", virtualPath, err)}, msgAndArgs...)
+	}
+
+	if filepath.Clean(actual) != filepath.Clean(expectedReal) {
+		return c.fail(t, FailureInfo{
+			Path:     virtualPath,
+			Expected: expectedReal,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q is mapped to %q, expected %q", virtualPath, actual, expectedReal),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
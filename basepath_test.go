@@ -0,0 +1,54 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestRealPathEqual(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.FromSlash("/srv/data")
+	fs := afero.NewBasePathFs(afero.NewMemMapFs(), base)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.RealPathEqual(mockT, fs, "config.yaml", filepath.Join(base, "config.yaml")), mockT.message())
+
+	assert.False(t, aferoassert.RealPathEqual(mockT, fs, "config.yaml", filepath.Join(base, "other.yaml")))
+	assert.Contains(t, mockT.message(), `"config.yaml" is mapped to`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.RealPathEqual(mockT, fs, "../config.yaml", "config.yaml"))
+	assert.Contains(t, mockT.message(), `could not get the real path of "../config.yaml"`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.RealPathEqual(mockT, afero.NewMemMapFs(), "config.yaml", "config.yaml"))
+	assert.Contains(t, mockT.message(), "MemMapFS does not map the paths to real paths")
+}
+
+func TestBasePathFs_RealPathInFailures(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.FromSlash("/srv/data")
+	fs := afero.NewBasePathFs(afero.NewMemMapFs(), base)
+	mockT := &testingT{}
+
+	require.NoError(t, fs.MkdirAll("root", 0o755))
+
+	assert.False(t, aferoassert.FileExists(mockT, fs, "config.yaml"))
+	assert.Contains(t, mockT.message(), fmt.Sprintf(`unable to find file "config.yaml" (real path %q)`, filepath.Join(base, "config.yaml")))
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, "- file", "root"))
+	assert.Contains(t, mockT.message(), fmt.Sprintf(`expected these files in "root" (real path %q) but not found`, filepath.Join(base, "root")))
+}
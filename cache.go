@@ -0,0 +1,85 @@
+package aferoassert
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// CachedAfterRead reads a file through a cache file system, such as an afero.CacheOnReadFs, and checks whether the
+// cache layer then contains the file with the content that was read.
+func CachedAfterRead(t TestingT, union, cache afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "CachedAfterRead", func(t TestingT) bool {
+		return assertCachedAfterRead(t, c, union, cache, path, msgAndArgs...)
+	})
+}
+
+func assertCachedAfterRead(t TestingT, c *config, union, cache afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	read, err := readFile(c, union, path)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	if !assertInLayer(t, c, cache, path, msgAndArgs...) {
+		return false
+	}
+
+	cached, err := readFile(c, cache, path)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	if !bytes.Equal(read, cached) {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: string(read),
+			Actual:   string(cached),
+			Message:  fmt.Sprintf("%q in the cache is not the same as the content that was read", path),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// CacheExpired checks whether the next read of a file through an afero.CacheOnReadFs, created with the cache time,
+// is not served from the cache layer, because the file is not cached or because the cached copy is older than the
+// cache time and than the file in the base layer.
+func CacheExpired(t TestingT, base, cache afero.Fs, path string, cacheTime time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "CacheExpired", func(t TestingT) bool {
+		return assertCacheExpired(t, c, base, cache, path, cacheTime, msgAndArgs...)
+	})
+}
+
+func assertCacheExpired(t TestingT, c *config, base, cache afero.Fs, path string, cacheTime time.Duration, msgAndArgs ...interface{}) bool {
+	cached, err := stat(c, cache, path)
+	if err != nil {
+		return true
+	}
+
+	// Same rules as afero.CacheOnReadFs.
+	if cacheTime > 0 && cached.ModTime().Add(cacheTime).Before(time.Now()) {
+		if info, err := stat(c, base, path); err == nil && info.ModTime().After(cached.ModTime()) {
+			return true
+		}
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    path,
+		Actual:  cached.ModTime(),
+		Message: fmt.Sprintf("%q is still cached since %s", path, cached.ModTime().Format(time.RFC3339Nano)),
+	}, msgAndArgs...)
+}
@@ -0,0 +1,65 @@
+package aferoassert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestCachedAfterRead(t *testing.T) {
+	t.Parallel()
+
+	base := afero.NewMemMapFs()
+	cache := afero.NewMemMapFs()
+	union := afero.NewCacheOnReadFs(base, cache, time.Hour)
+
+	require.NoError(t, afero.WriteFile(base, "file.txt", []byte("hello"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.CachedAfterRead(mockT, union, cache, "file.txt"), mockT.message())
+
+	// Not cached.
+	assert.False(t, aferoassert.CachedAfterRead(mockT, base, afero.NewMemMapFs(), "file.txt"))
+	assert.False(t, aferoassert.CachedAfterRead(mockT, union, cache, "unknown.txt"))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `"file.txt" is not in the layer MemMapFS`)
+	assert.Contains(t, mockT.errors[1], `could not read "unknown.txt"`)
+}
+
+func TestCacheExpired(t *testing.T) {
+	t.Parallel()
+
+	base := afero.NewMemMapFs()
+	cache := afero.NewMemMapFs()
+	union := afero.NewCacheOnReadFs(base, cache, time.Minute)
+
+	require.NoError(t, afero.WriteFile(base, "file.txt", []byte("hello"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.CacheExpired(mockT, base, cache, "file.txt", time.Minute), mockT.message())
+
+	_, err := afero.ReadFile(union, "file.txt")
+	require.NoError(t, err)
+
+	assert.False(t, aferoassert.CacheExpired(mockT, base, cache, "file.txt", time.Minute))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"file.txt" is still cached since `)
+
+	// The cached copy is older than the cache time and the base file.
+	now := time.Now()
+
+	require.NoError(t, cache.Chtimes("file.txt", now.Add(-time.Hour), now.Add(-time.Hour)))
+	require.NoError(t, base.Chtimes("file.txt", now, now))
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.CacheExpired(mockT, base, cache, "file.txt", time.Minute), mockT.message())
+	assert.False(t, aferoassert.CacheExpired(mockT, base, cache, "file.txt", 0))
+}
@@ -0,0 +1,79 @@
+package aferoassert
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+// DirChecksum computes a stable digest of a directory over the relative paths, the file types, the content and the
+// permissions of all its entries, with the setuid, setgid and sticky bits. The modification times are not included.
+// The permissions are excluded with IgnorePerm, and the IgnorePaths and FollowLinks options are supported.
+func DirChecksum(fs afero.Fs, root string, opts ...Option) (string, error) {
+	return dirChecksum(configFromOptions(opts), fs, root)
+}
+
+func dirChecksum(c *config, fs afero.Fs, root string) (string, error) {
+	snapshot, err := fingerprint(c, fs, root)
+	if err != nil {
+		return "", err
+	}
+
+	paths := make([]string, 0, len(snapshot))
+
+	for p := range snapshot {
+		paths = append(paths, filepath.ToSlash(p))
+	}
+
+	sort.Strings(paths)
+
+	h := sha256.New()
+
+	for _, p := range paths {
+		s := snapshot[filepath.FromSlash(p)]
+
+		perm := uint32(0)
+		if !c.ignorePerm {
+			perm = unixPerm(s.Mode)
+		}
+
+		_, _ = fmt.Fprintf(h, "%q %s %04o %s\n", p, FormatFileMode(s.Mode.Type()), perm, s.Hash)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DirChecksumEqual checks whether the checksum of a directory is the expected one, see DirChecksum.
+func DirChecksumEqual(t TestingT, fs afero.Fs, root string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "DirChecksumEqual", func(t TestingT) bool {
+		return assertDirChecksumEqual(t, c, fs, root, expected, msgAndArgs...)
+	})
+}
+
+func assertDirChecksumEqual(t TestingT, c *config, fs afero.Fs, root string, expected string, msgAndArgs ...interface{}) bool {
+	actual, err := dirChecksum(c, fs, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not compute checksum of %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if actual != expected {
+		return c.fail(t, FailureInfo{
+			Path:     root,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q checksum is %s, expected %s", root, actual, expected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
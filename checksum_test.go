@@ -0,0 +1,81 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestDirChecksum(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+
+	sum, err := aferoassert.DirChecksum(fs, "root")
+	require.NoError(t, err)
+	assert.Len(t, sum, 64)
+
+	// Stable.
+	again, err := aferoassert.DirChecksum(newSourceFs(t), "root")
+	require.NoError(t, err)
+	assert.Equal(t, sum, again)
+
+	// Perm.
+	require.NoError(t, fs.Chmod("root/file 1", 0o600))
+
+	changed, err := aferoassert.DirChecksum(fs, "root")
+	require.NoError(t, err)
+	assert.NotEqual(t, sum, changed)
+
+	withoutPerm, err := aferoassert.DirChecksum(fs, "root", aferoassert.IgnorePerm())
+	require.NoError(t, err)
+
+	expected, err := aferoassert.DirChecksum(newSourceFs(t), "root", aferoassert.IgnorePerm())
+	require.NoError(t, err)
+	assert.Equal(t, expected, withoutPerm)
+
+	// Special bits.
+	require.NoError(t, fs.Chmod("root/file 1", os.ModeSetuid|0o600))
+
+	withSetuid, err := aferoassert.DirChecksum(fs, "root")
+	require.NoError(t, err)
+	assert.NotEqual(t, changed, withSetuid)
+
+	withoutPerm, err = aferoassert.DirChecksum(fs, "root", aferoassert.IgnorePerm())
+	require.NoError(t, err)
+	assert.Equal(t, expected, withoutPerm)
+
+	// Rename.
+	require.NoError(t, fs.Rename("root/file 1", "root/file 3"))
+
+	changed, err = aferoassert.DirChecksum(fs, "root", aferoassert.IgnorePerm())
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, changed)
+}
+
+func TestDirChecksumEqual(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+
+	sum, err := aferoassert.DirChecksum(fs, "root")
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.DirChecksumEqual(mockT, fs, "root", sum), mockT.message())
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("changed"), 0o644))
+
+	assert.False(t, aferoassert.DirChecksumEqual(mockT, fs, "root", sum))
+	assert.False(t, aferoassert.DirChecksumEqual(mockT, fs, "unknown", sum))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `"root" checksum is `)
+	assert.Contains(t, mockT.errors[0], ", expected "+sum)
+	assert.Contains(t, mockT.errors[1], `could not compute checksum of "unknown"`)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func check(args []string, stdout, stderr io.Writer) int {
+	var ignore stringsFlag
+
+	flags := newFlagSet("check", stderr)
+	treeFile := flags.String("tree", "", "the tree YAML file")
+	contains := flags.Bool("contains", false, "allow the files that are not in the tree")
+	ignorePerm := flags.Bool("ignore-perm", false, "skip the permission checks")
+	followLinks := flags.Bool("follow-links", false, "follow the symlinks")
+	tap := flags.Bool("tap", false, "print the result in the Test Anything Protocol")
+
+	flags.Var(&ignore, "ignore", "ignore the paths matching the pattern, can be repeated")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *treeFile == "" || flags.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: aferoassert check --tree <file> [flags] <dir>")
+
+		return 2
+	}
+
+	raw, err := os.ReadFile(*treeFile)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "could not read %q: %s\n", *treeFile, err)
+
+		return 2
+	}
+
+	var tree aferoassert.FileTree
+
+	if err := yaml.Unmarshal(raw, &tree); err != nil {
+		_, _ = fmt.Fprintf(stderr, "could not unmarshal %q: %s\n", *treeFile, err)
+
+		return 2
+	}
+
+	opts := []aferoassert.Option{aferoassert.IgnorePaths(ignore...)}
+
+	if *ignorePerm {
+		opts = append(opts, aferoassert.IgnorePerm())
+	}
+
+	if *followLinks {
+		opts = append(opts, aferoassert.FollowLinks())
+	}
+
+	validate := aferoassert.Validate
+	if *contains {
+		validate = aferoassert.ValidateContains
+	}
+
+	dir := flags.Arg(0)
+
+	err = validate(afero.NewOsFs(), tree, dir, opts...)
+
+	var verr *aferoassert.ValidationError
+
+	if *tap {
+		var failures []aferoassert.FailureInfo
+
+		if errors.As(err, &verr) {
+			failures = verr.Failures
+		}
+
+		if err := aferoassert.WriteTAP(stdout, tree, dir, failures); err != nil {
+			_, _ = fmt.Fprintf(stderr, "could not write the result: %s\n", err)
+
+			return 2
+		}
+
+		if len(failures) > 0 {
+			return 1
+		}
+
+		return 0
+	}
+
+	if errors.As(err, &verr) {
+		for _, f := range verr.Failures {
+			_, _ = fmt.Fprintln(stderr, f.Message)
+		}
+
+		return 1
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s matches %s\n", dir, *treeFile)
+
+	return 0
+}
@@ -0,0 +1,69 @@
+// Command aferoassert checks a directory against a tree YAML, so the expectations can be used outside the Go tests.
+//
+//	aferoassert check --tree layout.yaml ./dist
+//	aferoassert snapshot ./dist > layout.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const usage = `Usage: aferoassert <command> [flags] <dir>
+
+Commands:
+  check       validate a directory against a tree YAML
+  snapshot    print the tree YAML of a directory
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+
+		return 2
+	}
+
+	switch args[0] {
+	case "check":
+		return check(args[1:], stdout, stderr)
+
+	case "snapshot":
+		return snapshot(args[1:], stdout, stderr)
+
+	case "-h", "--help", "help":
+		_, _ = fmt.Fprint(stdout, usage)
+
+		return 0
+	}
+
+	_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+
+	return 2
+}
+
+// stringsFlag is a flag that can be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+
+	return nil
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	return fs
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "dist", "assets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dist", "assets", "app.js"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dist", "index.html"), nil, 0o644))
+
+	return dir
+}
+
+func writeTree(t *testing.T, dir, tree string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "layout.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(tree), 0o644))
+
+	return path
+}
+
+func TestRun_Usage(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	assert.Equal(t, 2, run(nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: aferoassert")
+
+	stderr.Reset()
+
+	assert.Equal(t, 2, run([]string{"unknown"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown command "unknown"`)
+
+	stderr.Reset()
+
+	assert.Equal(t, 2, run([]string{"check", "dist"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "usage: aferoassert check")
+}
+
+func TestRun_Check(t *testing.T) {
+	t.Parallel()
+
+	dir := newDir(t)
+	dist := filepath.Join(dir, "dist")
+
+	testCases := []struct {
+		scenario       string
+		tree           string
+		args           []string
+		expectedCode   int
+		expectedOutput string
+	}{
+		{
+			scenario: "equal",
+			tree: `
+- assets:
+    - app.js 'perm:"0644"'
+- index.html
+`,
+			expectedCode:   0,
+			expectedOutput: "matches",
+		},
+		{
+			scenario:       "not equal",
+			tree:           `- index.html`,
+			expectedCode:   1,
+			expectedOutput: `unexpected file "` + filepath.Join(dist, "assets") + `"`,
+		},
+		{
+			scenario:     "contains",
+			tree:         `- index.html`,
+			args:         []string{"--contains"},
+			expectedCode: 0,
+		},
+		{
+			scenario:     "ignore",
+			tree:         `- index.html`,
+			args:         []string{"--ignore", "assets"},
+			expectedCode: 0,
+		},
+		{
+			scenario:       "wrong perm",
+			tree:           `- index.html 'perm:"0600"'`,
+			args:           []string{"--contains"},
+			expectedCode:   1,
+			expectedOutput: "perm is 0644, expected 0600",
+		},
+		{
+			scenario:     "ignore perm",
+			tree:         `- index.html 'perm:"0600"'`,
+			args:         []string{"--contains", "--ignore-perm"},
+			expectedCode: 0,
+		},
+		{
+			scenario: "tap",
+			tree: `
+- assets:
+    - app.js
+- index.html
+`,
+			args:           []string{"--tap"},
+			expectedCode:   0,
+			expectedOutput: "TAP version 13\n1..3\nok 1 - " + filepath.Join(dist, "assets") + "\n",
+		},
+		{
+			scenario:       "tap not ok",
+			tree:           `- index.html 'perm:"0600"'`,
+			args:           []string{"--tap", "--contains"},
+			expectedCode:   1,
+			expectedOutput: "not ok 1 - " + filepath.Join(dist, "index.html") + "\n# " + `"` + filepath.Join(dist, "index.html") + `" perm is 0644, expected 0600`,
+		},
+		{
+			scenario:       "invalid tree",
+			tree:           `- index.html: [`,
+			expectedCode:   2,
+			expectedOutput: "could not unmarshal",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			treeFile := writeTree(t, t.TempDir(), tc.tree)
+			args := append([]string{"check", "--tree", treeFile}, tc.args...)
+			args = append(args, dist)
+
+			assert.Equal(t, tc.expectedCode, run(args, &stdout, &stderr), stderr.String())
+			assert.Contains(t, stdout.String()+stderr.String(), tc.expectedOutput)
+		})
+	}
+}
+
+func TestRun_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	dir := newDir(t)
+	dist := filepath.Join(dir, "dist")
+
+	var stdout, stderr bytes.Buffer
+
+	require.Equal(t, 0, run([]string{"snapshot", "--ignore", "*.js", dist}, &stdout, &stderr), stderr.String())
+
+	expected := `- assets 'perm:"0755"': {}
+- index.html 'perm:"0644"'
+`
+
+	assert.Equal(t, expected, stdout.String())
+
+	// The snapshot is a valid expectation.
+	treeFile := writeTree(t, t.TempDir(), stdout.String())
+
+	stdout.Reset()
+
+	assert.Equal(t, 0, run([]string{"check", "--tree", treeFile, "--ignore", "*.js", dist}, &stdout, &stderr), stderr.String())
+
+	stderr.Reset()
+
+	assert.Equal(t, 1, run([]string{"snapshot", filepath.Join(dir, "unknown")}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "could not snapshot")
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+
+	"go.nhat.io/aferoassert"
+)
+
+func snapshot(args []string, stdout, stderr io.Writer) int {
+	var ignore stringsFlag
+
+	flags := newFlagSet("snapshot", stderr)
+	followLinks := flags.Bool("follow-links", false, "follow the symlinks")
+
+	flags.Var(&ignore, "ignore", "ignore the paths matching the pattern, can be repeated")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: aferoassert snapshot [flags] <dir>")
+
+		return 2
+	}
+
+	opts := []aferoassert.Option{aferoassert.IgnorePaths(ignore...)}
+
+	if *followLinks {
+		opts = append(opts, aferoassert.FollowLinks())
+	}
+
+	out, err := aferoassert.MarshalTree(afero.NewOsFs(), flags.Arg(0), opts...)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "could not snapshot %q: %s\n", flags.Arg(0), err)
+
+		return 1
+	}
+
+	_, _ = stdout.Write(out)
+
+	return 0
+}
@@ -0,0 +1,101 @@
+// Package cmp provides the options for github.com/google/go-cmp to compare the file systems and the file trees with
+// readable diffs, for example:
+//
+//	import aferocmp "go.nhat.io/aferoassert/cmp"
+//
+//	diff := cmp.Diff(expectedFs, actualFs, aferocmp.TransformFs("."))
+//	diff := cmp.Diff(expectedTree, actualTree, aferocmp.TransformTree())
+package cmp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"go.nhat.io/aferoassert"
+)
+
+// File is the snapshot of a file or a directory, see TransformFs.
+type File struct {
+	Mode    string
+	Content string
+}
+
+// TransformFs transforms an afero.Fs to a map of the paths relative to the root and their File snapshots, so the
+// modes and the contents are compared and the differences are reported per path. The modification times are ignored.
+func TransformFs(root string) gocmp.Option {
+	return gocmp.Transformer("aferoassert.Fs", func(fs afero.Fs) map[string]File {
+		return snapshot(fs, filepath.Clean(root))
+	})
+}
+
+// TransformTree transforms an aferoassert.FileTree to a map of the paths and their tags, for example
+// `perm:"0644"`. The directories have a trailing path separator.
+func TransformTree() gocmp.Option {
+	return gocmp.Transformer("aferoassert.FileTree", func(tree aferoassert.FileTree) map[string]string {
+		result := make(map[string]string)
+
+		for p, n := range tree.Flatten("") {
+			if n.IsDir {
+				p += string(os.PathSeparator)
+			}
+
+			result[p] = tags(n)
+		}
+
+		return result
+	})
+}
+
+func tags(n aferoassert.FileNode) string {
+	result := make([]string, 0, 2)
+
+	if len(n.Tags) > 0 {
+		result = append(result, n.Tags.String())
+	}
+
+	if len(n.Attrs) > 0 {
+		result = append(result, n.Attrs.String())
+	}
+
+	return strings.Join(result, " ")
+}
+
+func snapshot(fs afero.Fs, root string) map[string]File {
+	result := make(map[string]File)
+
+	err := afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == root {
+			return nil
+		}
+
+		f := File{Mode: info.Mode().String()}
+
+		if info.Mode().IsRegular() {
+			content, err := afero.ReadFile(fs, path)
+			if err != nil {
+				return err
+			}
+
+			f.Content = string(content)
+		}
+
+		result[strings.TrimPrefix(path, root+string(os.PathSeparator))] = f
+
+		return nil
+	})
+	if err != nil {
+		// The transformer can not return an error, it is reported as a difference instead.
+		result[root] = File{Content: fmt.Sprintf("could not walk through %q: %s", root, err)}
+	}
+
+	return result
+}
@@ -0,0 +1,84 @@
+package cmp_test
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+	aferocmp "go.nhat.io/aferoassert/cmp"
+)
+
+func TestTransformFs(t *testing.T) {
+	t.Parallel()
+
+	expected := afero.NewMemMapFs()
+	actual := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(expected, "root/dir/file.txt", []byte("hello"), 0o644))
+	require.NoError(t, afero.WriteFile(actual, "root/dir/file.txt", []byte("hello"), 0o644))
+
+	assert.Empty(t, gocmp.Diff(expected, actual, aferocmp.TransformFs("root")))
+
+	require.NoError(t, afero.WriteFile(actual, "root/dir/file.txt", []byte("world"), 0o644))
+	require.NoError(t, actual.Chmod("root/dir/file.txt", 0o600))
+	require.NoError(t, afero.WriteFile(actual, "root/other.txt", nil, 0o644))
+
+	diff := gocmp.Diff(expected, actual, aferocmp.TransformFs("root"))
+
+	assert.Contains(t, diff, `"dir/file.txt": {`)
+	assert.Contains(t, diff, `Mode:    "-rw-r--r--",`)
+	assert.Contains(t, diff, `Mode:    "-rw-------",`)
+	assert.Contains(t, diff, `Content: "hello",`)
+	assert.Contains(t, diff, `Content: "world",`)
+	assert.Contains(t, diff, `"other.txt": {Mode: "-rw-r--r--"},`)
+}
+
+func TestTransformFs_NotFound(t *testing.T) {
+	t.Parallel()
+
+	diff := gocmp.Diff(afero.NewMemMapFs(), afero.NewMemMapFs(), aferocmp.TransformFs("root"))
+
+	assert.Empty(t, diff)
+
+	expected := afero.NewMemMapFs()
+
+	require.NoError(t, expected.Mkdir("root", 0o755))
+
+	diff = gocmp.Diff(expected, afero.NewMemMapFs(), aferocmp.TransformFs("root"))
+
+	assert.Contains(t, diff, `could not walk through "root"`)
+}
+
+func TestTransformTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/dir", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/dir/file.txt", nil, 0o644))
+
+	actual, err := aferoassert.TreeFromFs(fs, "root")
+	require.NoError(t, err)
+
+	var expected aferoassert.FileTree
+
+	require.NoError(t, yamlUnmarshal(`
+- dir 'perm:"0755"':
+    - file.txt 'perm:"0600"'
+`, &expected))
+
+	diff := gocmp.Diff(expected, actual, aferocmp.TransformTree())
+
+	assert.Contains(t, diff, "\"dir/file.txt\": `perm:\"0600\"`,")
+	assert.Contains(t, diff, "\"dir/file.txt\": `perm:\"0644\"`,")
+}
+
+func yamlUnmarshal(s string, tree *aferoassert.FileTree) error {
+	return yaml.Unmarshal([]byte(s), tree)
+}
@@ -0,0 +1,138 @@
+package aferoassert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+var _ TestingT = (*Collector)(nil)
+
+// Collector is a TestingT that gathers the failures of many assertions and reports them as a single grouped message,
+// keeping the output manageable when asserting dozens of paths, for example:
+//
+//	c := aferoassert.NewCollector(t)
+//
+//	for _, path := range paths {
+//		aferoassert.FileExists(c, fs, path)
+//	}
+//
+//	c.Report()
+type Collector struct {
+	t TestingT
+
+	mu       sync.Mutex
+	failures []string
+	infos    []FailureInfo
+}
+
+// NewCollector creates a Collector that reports to t.
+func NewCollector(t TestingT) *Collector {
+	return &Collector{t: t}
+}
+
+// Errorf collects a failure.
+func (c *Collector) Errorf(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures = append(c.failures, strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+func (c *Collector) recordFailure(info FailureInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.infos = append(c.infos, info)
+}
+
+// Helper marks the caller as a test helper, if it is supported by the wrapped TestingT.
+func (c *Collector) Helper() {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+}
+
+// Failed tells whether a failure has been collected.
+func (c *Collector) Failed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.failures) > 0
+}
+
+// Failures returns the collected failures.
+func (c *Collector) Failures() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.failures...)
+}
+
+// Report reports the collected failures to the wrapped TestingT as a single message and forgets them. It returns true
+// if there is no failure.
+func (c *Collector) Report() bool {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c.mu.Lock()
+	failures := c.failures
+	c.failures = nil
+	c.infos = nil
+	c.mu.Unlock()
+
+	if len(failures) == 0 {
+		return true
+	}
+
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%d assertion(s) failed:\n", len(failures))
+
+	for i, f := range failures {
+		_, _ = fmt.Fprintf(&sb, "\n%d) %s\n", i+1, indent(f))
+	}
+
+	c.t.Errorf("%s", sb.String())
+
+	return false
+}
+
+// Collect runs the assertions of fn with a Collector and reports their failures as a single message at the end. It
+// returns true if there is no failure.
+func Collect(t TestingT, fn func(t TestingT)) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c := NewCollector(t)
+
+	fn(c)
+
+	return c.Report()
+}
+
+// WriteTAP writes the result of the collected tree assertions of a directory in the Test Anything Protocol, see
+// WriteTAP. It has to be called before Report, which forgets the failures.
+func (c *Collector) WriteTAP(w io.Writer, tree FileTree, root string) error {
+	c.mu.Lock()
+	infos := append([]FailureInfo(nil), c.infos...)
+	c.mu.Unlock()
+
+	return WriteTAP(w, tree, root, infos)
+}
+
+// ReportNow reports the collected failures like Report and stops the test, if the wrapped TestingT supports FailNow.
+func (c *Collector) ReportNow() {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if c.Report() {
+		return
+	}
+
+	failNow(c.t)
+}
@@ -0,0 +1,84 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+	c := aferoassert.NewCollector(mockT)
+
+	assert.True(t, c.Report())
+	assert.Empty(t, mockT.errors)
+
+	aferoassert.FileExists(c, fs, "root/file 1")
+	aferoassert.FileExists(c, fs, "root/unknown 1")
+	aferoassert.DirExists(c, fs, "root/file 1")
+
+	assert.True(t, c.Failed())
+	assert.Len(t, c.Failures(), 2)
+	assert.Empty(t, mockT.errors)
+
+	assert.False(t, c.Report())
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "2 assertion(s) failed:\n\n1) Error Trace:")
+	assert.Contains(t, mockT.errors[0], `unable to find file "root/unknown 1"`)
+	assert.Contains(t, mockT.errors[0], "\n2) Error Trace:")
+	assert.Contains(t, mockT.errors[0], `"root/file 1" is a file`)
+
+	assert.False(t, c.Failed())
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Collect(mockT, func(t aferoassert.TestingT) {
+		aferoassert.FileExists(t, fs, "root/file 1")
+	}))
+
+	assert.False(t, aferoassert.Collect(mockT, func(t aferoassert.TestingT) {
+		aferoassert.FileExists(t, fs, "root/unknown 1")
+		aferoassert.FileExists(t, fs, "root/unknown 2")
+	}))
+
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "2 assertion(s) failed:")
+}
+
+type failNowT struct {
+	testingT
+
+	failedNow bool
+}
+
+func (t *failNowT) FailNow() {
+	t.failedNow = true
+}
+
+func TestCollector_ReportNow(t *testing.T) {
+	t.Parallel()
+
+	mockT := &failNowT{}
+	c := aferoassert.NewCollector(mockT)
+
+	c.ReportNow()
+
+	assert.False(t, mockT.failedNow)
+
+	c.Errorf("failure")
+	c.ReportNow()
+
+	assert.True(t, mockT.failedNow)
+	assert.Equal(t, []string{"1 assertion(s) failed:\n\n1) failure\n"}, mockT.errors)
+}
@@ -0,0 +1,109 @@
+package aferoassert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// Comparer compares the expected and the actual content of a file. It returns an error if the content could not be
+// compared, for example, when it is not in the expected format.
+type Comparer func(expected, actual []byte) (bool, error)
+
+var comparers = struct {
+	mu  sync.RWMutex
+	ext map[string]Comparer
+}{
+	ext: map[string]Comparer{
+		".json": JSONComparer,
+		".yaml": YAMLComparer,
+		".yml":  YAMLComparer,
+		".csv":  CSVComparer,
+	},
+}
+
+// RegisterComparer registers a comparer for the files with the given extension, for example ".json". The comparer is
+// used by FileSemanticEqual and the `content` tag of the tree assertions. Registering a nil comparer removes the
+// existing one and the files are compared byte by byte.
+func RegisterComparer(ext string, c Comparer) {
+	ext = strings.ToLower(ext)
+
+	comparers.mu.Lock()
+	defer comparers.mu.Unlock()
+
+	if c == nil {
+		delete(comparers.ext, ext)
+
+		return
+	}
+
+	comparers.ext[ext] = c
+}
+
+func comparerFor(path string) Comparer {
+	comparers.mu.RLock()
+	defer comparers.mu.RUnlock()
+
+	if c, ok := comparers.ext[strings.ToLower(filepath.Ext(path))]; ok {
+		return c
+	}
+
+	return BytesComparer
+}
+
+// BytesComparer compares the content byte by byte.
+func BytesComparer(expected, actual []byte) (bool, error) {
+	return bytes.Equal(expected, actual), nil
+}
+
+// JSONComparer compares the content as JSON documents.
+func JSONComparer(expected, actual []byte) (bool, error) {
+	var e, a interface{}
+
+	if err := json.Unmarshal(expected, &e); err != nil {
+		return false, fmt.Errorf("could not decode expected json: %w", err)
+	}
+
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return false, fmt.Errorf("could not decode actual json: %w", err)
+	}
+
+	return assert.ObjectsAreEqual(e, a), nil
+}
+
+// YAMLComparer compares the content as YAML documents.
+func YAMLComparer(expected, actual []byte) (bool, error) {
+	var e, a interface{}
+
+	if err := yaml.Unmarshal(expected, &e); err != nil {
+		return false, fmt.Errorf("could not decode expected yaml: %w", err)
+	}
+
+	if err := yaml.Unmarshal(actual, &a); err != nil {
+		return false, fmt.Errorf("could not decode actual yaml: %w", err)
+	}
+
+	return assert.ObjectsAreEqual(e, a), nil
+}
+
+// CSVComparer compares the content as CSV records, the quoting and the line endings are ignored.
+func CSVComparer(expected, actual []byte) (bool, error) {
+	e, err := csv.NewReader(bytes.NewReader(expected)).ReadAll()
+	if err != nil {
+		return false, fmt.Errorf("could not decode expected csv: %w", err)
+	}
+
+	a, err := csv.NewReader(bytes.NewReader(actual)).ReadAll()
+	if err != nil {
+		return false, fmt.Errorf("could not decode actual csv: %w", err)
+	}
+
+	return assert.ObjectsAreEqual(e, a), nil
+}
@@ -0,0 +1,145 @@
+package aferoassert_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestComparers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		comparer       aferoassert.Comparer
+		expected       string
+		actual         string
+		expectedResult bool
+		expectedError  string
+	}{
+		{
+			scenario:       "bytes are equal",
+			comparer:       aferoassert.BytesComparer,
+			expected:       "hello",
+			actual:         "hello",
+			expectedResult: true,
+		},
+		{
+			scenario: "bytes are not equal",
+			comparer: aferoassert.BytesComparer,
+			expected: "hello",
+			actual:   "hello\n",
+		},
+		{
+			scenario:       "json is equal",
+			comparer:       aferoassert.JSONComparer,
+			expected:       `{"a": 1, "b": [true, null]}`,
+			actual:         "{\n  \"b\": [true, null],\n  \"a\": 1\n}\n",
+			expectedResult: true,
+		},
+		{
+			scenario: "json is not equal",
+			comparer: aferoassert.JSONComparer,
+			expected: `{"a": 1}`,
+			actual:   `{"a": 2}`,
+		},
+		{
+			scenario:      "expected json is invalid",
+			comparer:      aferoassert.JSONComparer,
+			expected:      `{`,
+			actual:        `{}`,
+			expectedError: "could not decode expected json: unexpected end of JSON input",
+		},
+		{
+			scenario:      "actual json is invalid",
+			comparer:      aferoassert.JSONComparer,
+			expected:      `{}`,
+			actual:        `{`,
+			expectedError: "could not decode actual json: unexpected end of JSON input",
+		},
+		{
+			scenario:       "yaml is equal",
+			comparer:       aferoassert.YAMLComparer,
+			expected:       "a: 1\nb: [x, y]\n",
+			actual:         "b:\n  - x\n  - y\na: 1\n",
+			expectedResult: true,
+		},
+		{
+			scenario: "yaml is not equal",
+			comparer: aferoassert.YAMLComparer,
+			expected: "a: 1\n",
+			actual:   "a: 2\n",
+		},
+		{
+			scenario:      "actual yaml is invalid",
+			comparer:      aferoassert.YAMLComparer,
+			expected:      "a: 1\n",
+			actual:        "a: [\n",
+			expectedError: "could not decode actual yaml: yaml: line 1: did not find expected node content",
+		},
+		{
+			scenario:       "csv is equal",
+			comparer:       aferoassert.CSVComparer,
+			expected:       "a,b\n1,2\n",
+			actual:         "\"a\",b\r\n1,\"2\"",
+			expectedResult: true,
+		},
+		{
+			scenario: "csv is not equal",
+			comparer: aferoassert.CSVComparer,
+			expected: "a,b\n1,2\n",
+			actual:   "a,b\n2,1\n",
+		},
+		{
+			scenario:      "actual csv is invalid",
+			comparer:      aferoassert.CSVComparer,
+			expected:      "a,b\n",
+			actual:        "a,b\n1\n",
+			expectedError: "could not decode actual csv: record on line 2: wrong number of fields",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.comparer([]byte(tc.expected), []byte(tc.actual))
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestRegisterComparer(t *testing.T) {
+	t.Parallel()
+
+	aferoassert.RegisterComparer(".Custom", func(expected, actual []byte) (bool, error) {
+		return bytes.EqualFold(expected, actual), nil
+	})
+
+	t.Cleanup(func() {
+		aferoassert.RegisterComparer(".custom", nil)
+	})
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.custom", []byte("HELLO"), 0o644))
+
+	mockT := new(testing.T)
+	assert.True(t, aferoassert.FileSemanticEqual(mockT, fs, "file.custom", "hello"))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileSemanticEqual(mockT, fs, "file.custom", "world"))
+}
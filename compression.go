@@ -0,0 +1,111 @@
+package aferoassert
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Compression is a set of compression formats, see FileCompressedAs. The formats can be combined to accept any of
+// them, for example Gzip|Zstd.
+type Compression uint
+
+const (
+	// Gzip is the gzip format, RFC 1952.
+	Gzip Compression = 1 << iota
+	// Zstd is the Zstandard format, RFC 8878.
+	Zstd
+	// Bzip2 is the bzip2 format.
+	Bzip2
+	// Xz is the xz format.
+	Xz
+)
+
+// compressionMagics are the magic bytes that the compressed files start with.
+var compressionMagics = []struct {
+	compression Compression
+	name        string
+	magic       []byte
+}{
+	{Gzip, "gzip", []byte{0x1f, 0x8b}},
+	{Zstd, "zstd", []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{Bzip2, "bzip2", []byte("BZh")},
+	{Xz, "xz", []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+}
+
+// compressionMagicSize is the number of bytes needed to detect all the formats.
+const compressionMagicSize = 6
+
+// String returns the names of the formats, for example "gzip|zstd".
+func (c Compression) String() string {
+	names := make([]string, 0, len(compressionMagics))
+
+	for _, m := range compressionMagics {
+		if c&m.compression != 0 {
+			names = append(names, m.name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "uncompressed"
+	}
+
+	return strings.Join(names, "|")
+}
+
+// detectCompression detects the format of the content from its first bytes, it returns 0 if the format is unknown.
+func detectCompression(header []byte) Compression {
+	for _, m := range compressionMagics {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression
+		}
+	}
+
+	return 0
+}
+
+// FileCompressedAs checks whether a file is compressed with one of the expected formats by checking its magic bytes,
+// so the tests can verify that an output was actually compressed with the configured algorithm, for example:
+//
+//	aferoassert.FileCompressedAs(t, fs, "backup.tar.zst", aferoassert.Zstd)
+func FileCompressedAs(t TestingT, fs afero.Fs, path string, expected Compression, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileCompressedAs", func(t TestingT) bool {
+		return assertFileCompressedAs(t, c, fs, path, expected, msgAndArgs...)
+	})
+}
+
+func assertFileCompressedAs(t TestingT, c *config, fs afero.Fs, path string, expected Compression, msgAndArgs ...interface{}) bool {
+	header, ok := readFileHeader(t, c, fs, path, 0, compressionMagicSize, msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	actual := detectCompression(header)
+
+	if actual == 0 || actual&expected == 0 {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q is %s, expected %s", path, compressedAs(actual), expected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+func compressedAs(c Compression) string {
+	if c == 0 {
+		return "not compressed"
+	}
+
+	return fmt.Sprintf("compressed as %s", c)
+}
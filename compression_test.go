@@ -0,0 +1,76 @@
+package aferoassert_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileCompressedAs(t *testing.T) {
+	t.Parallel()
+
+	var gz bytes.Buffer
+
+	w := gzip.NewWriter(&gz)
+
+	_, err := w.Write([]byte("content"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "out.gz", gz.Bytes(), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "out.zst", []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "out.bz2", []byte("BZh91AY&SY"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "out.xz", []byte{0xfd, '7', 'z', 'X', 'Z', 0x00, 0x00}, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "raw.gz", []byte("content"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "empty.gz", nil, 0o644))
+
+	testCases := []struct {
+		scenario string
+		path     string
+		expected aferoassert.Compression
+		message  string
+	}{
+		{scenario: "gzip", path: "out.gz", expected: aferoassert.Gzip},
+		{scenario: "zstd", path: "out.zst", expected: aferoassert.Zstd},
+		{scenario: "bzip2", path: "out.bz2", expected: aferoassert.Bzip2},
+		{scenario: "xz", path: "out.xz", expected: aferoassert.Xz},
+		{scenario: "any of", path: "out.zst", expected: aferoassert.Gzip | aferoassert.Zstd},
+		{scenario: "other format", path: "out.gz", expected: aferoassert.Zstd | aferoassert.Bzip2, message: `"out.gz" is compressed as gzip, expected zstd|bzip2`},
+		{scenario: "raw", path: "raw.gz", expected: aferoassert.Gzip, message: `"raw.gz" is not compressed, expected gzip`},
+		{scenario: "empty", path: "empty.gz", expected: aferoassert.Gzip, message: `"empty.gz" is not compressed, expected gzip`},
+		{scenario: "not found", path: "unknown.gz", expected: aferoassert.Gzip, message: `unable to find file "unknown.gz"`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.Equal(t, tc.message == "", aferoassert.FileCompressedAs(mockT, fs, tc.path, tc.expected))
+
+			if tc.message == "" {
+				assert.Empty(t, mockT.message())
+			} else {
+				assert.Contains(t, mockT.message(), tc.message)
+			}
+		})
+	}
+}
+
+func TestCompression_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "uncompressed", aferoassert.Compression(0).String())
+	assert.Equal(t, "gzip|xz", (aferoassert.Gzip | aferoassert.Xz).String())
+}
@@ -0,0 +1,89 @@
+package aferoassert
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// Check is an assertion run by AssertConcurrently.
+type Check func(t TestingT, fs afero.Fs) bool
+
+// recordingT records the failures of a check run by AssertConcurrently, so they are reported in order.
+type recordingT struct {
+	errors []string
+	infos  []FailureInfo
+}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) recordFailure(info FailureInfo) {
+	t.infos = append(t.infos, info)
+}
+
+// AssertConcurrently runs independent checks in a pool of GOMAXPROCS workers, for example, to validate hundreds of paths
+// in parallel. Every check has its own TestingT, so they don't race on t, and their failures are reported to t in the
+// order of the checks once they are all done, with their details if t keeps them, such as a Collector, for example:
+//
+//	aferoassert.AssertConcurrently(t, fs,
+//		func(t aferoassert.TestingT, fs afero.Fs) bool { return aferoassert.FileExists(t, fs, "a.txt") },
+//		func(t aferoassert.TestingT, fs afero.Fs) bool { return aferoassert.FileExists(t, fs, "b.txt") },
+//	)
+func AssertConcurrently(t TestingT, fs afero.Fs, checks ...Check) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	results := make([]*recordingT, len(checks))
+	passed := make([]bool, len(checks))
+	jobs := make(chan int)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(checks) {
+		workers = len(checks)
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i] = &recordingT{}
+				passed[i] = checks[i](results[i], fs)
+			}
+		}()
+	}
+
+	for i := range checks {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	result := true
+
+	for i, r := range results {
+		if recorder, ok := t.(failureRecorder); ok {
+			for _, info := range r.infos {
+				recorder.recordFailure(info)
+			}
+		}
+
+		for _, err := range r.errors {
+			t.Errorf("%s", err)
+		}
+
+		result = result && passed[i] && len(r.errors) == 0
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestAssertConcurrently(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	checks := make([]aferoassert.Check, 0, 200)
+
+	for i := 0; i < 200; i++ {
+		path := fmt.Sprintf("root/file %03d", i)
+
+		if i%50 != 0 {
+			require.NoError(t, afero.WriteFile(fs, path, nil, 0o644))
+		}
+
+		checks = append(checks, func(t aferoassert.TestingT, fs afero.Fs) bool {
+			return aferoassert.FileExists(t, fs, path)
+		})
+	}
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertConcurrently(mockT, fs, checks[1:50]...), mockT.message())
+
+	assert.False(t, aferoassert.AssertConcurrently(mockT, fs, checks...))
+	require.Len(t, mockT.errors, 4)
+
+	for i, err := range mockT.errors {
+		assert.Contains(t, err, fmt.Sprintf(`unable to find file "root/file %03d"`, i*50))
+	}
+
+	assert.True(t, aferoassert.AssertConcurrently(mockT, fs))
+}
+
+func TestAssertConcurrently_FailureInfos(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/b.txt", nil, 0o644))
+
+	failures := aferoassert.CheckE(fs, func(t aferoassert.TestingT, fs afero.Fs) bool {
+		return aferoassert.AssertConcurrently(t, fs,
+			func(t aferoassert.TestingT, fs afero.Fs) bool { return aferoassert.FileExists(t, fs, "root/a.txt") },
+			func(t aferoassert.TestingT, fs afero.Fs) bool { return aferoassert.FileExists(t, fs, "root/b.txt") },
+			func(t aferoassert.TestingT, fs afero.Fs) bool {
+				return aferoassert.FileContent(t, fs, "root/b.txt", "b")
+			},
+		)
+	})
+
+	require.Len(t, failures, 2)
+	assert.Equal(t, "FileExists", failures[0].Assertion)
+	assert.Equal(t, aferoassert.FailureNotFound, failures[0].Kind)
+	assert.Equal(t, "root/a.txt", failures[0].Path)
+	assert.Equal(t, "FileContent", failures[1].Assertion)
+	assert.Equal(t, aferoassert.FailureContent, failures[1].Kind)
+}
@@ -0,0 +1,91 @@
+package aferoassert
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// ErrInvalidCondition indicates that the condition of an `if` tag or of a skip modifier is invalid.
+var ErrInvalidCondition = errors.New("invalid condition")
+
+// skipModifierPrefix is the prefix of the modifiers of the mode tags, for example `perm:"0644,skip-windows"`.
+const skipModifierPrefix = "skip-"
+
+// knownOS are the values of GOOS that can be used in the conditions.
+var knownOS = map[string]bool{
+	"aix":       true,
+	"android":   true,
+	"darwin":    true,
+	"dragonfly": true,
+	"freebsd":   true,
+	"illumos":   true,
+	"ios":       true,
+	"js":        true,
+	"linux":     true,
+	"netbsd":    true,
+	"openbsd":   true,
+	"plan9":     true,
+	"solaris":   true,
+	"wasip1":    true,
+	"windows":   true,
+}
+
+// evalCondition evaluates the condition of an `if` tag, a list of terms separated by commas that must all be true. A
+// term is either a GOOS, such as "windows", or "root" when the test runs as root, and is negated with "!", for example
+// `if:"!windows,!root"`.
+func evalCondition(cond string) (bool, error) {
+	result := true
+
+	for _, term := range strings.Split(cond, ",") {
+		term = strings.TrimSpace(term)
+		name := strings.TrimPrefix(term, "!")
+
+		ok, err := matchCondition(name)
+		if err != nil {
+			return false, err
+		}
+
+		if name != term {
+			ok = !ok
+		}
+
+		result = result && ok
+	}
+
+	return result, nil
+}
+
+// skipped tells whether one of the modifiers of a mode tag, such as "skip-windows" or "skip-root", matches the
+// platform.
+func skipped(modifiers []string) (bool, error) {
+	result := false
+
+	for _, m := range modifiers {
+		if !strings.HasPrefix(m, skipModifierPrefix) {
+			return false, ErrInvalidCondition
+		}
+
+		ok, err := matchCondition(strings.TrimPrefix(m, skipModifierPrefix))
+		if err != nil {
+			return false, err
+		}
+
+		result = result || ok
+	}
+
+	return result, nil
+}
+
+func matchCondition(name string) (bool, error) {
+	switch {
+	case name == "root":
+		return os.Geteuid() == 0, nil
+
+	case knownOS[name]:
+		return runtime.GOOS == name, nil
+	}
+
+	return false, ErrInvalidCondition
+}
@@ -0,0 +1,213 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func otherOS() string {
+	if runtime.GOOS == "plan9" {
+		return "windows"
+	}
+
+	return "plan9"
+}
+
+func TestFileTree_Flatten_IfTag(t *testing.T) {
+	t.Parallel()
+
+	isRoot := os.Geteuid() == 0
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(`
+- always
+- current 'if:"%[1]s"'
+- not-current 'if:"!%[1]s"'
+- other 'if:"%[2]s"'
+- not-other 'if:"!%[2]s"'
+- root 'if:"root"'
+- not-root 'if:"!root"'
+- both 'if:"%[1]s,!%[2]s"'
+- dir 'if:"%[2]s"':
+    - file
+`, runtime.GOOS, otherOS())), &tree))
+
+	// The conditional nodes are kept in the tree.
+	assert.Len(t, tree, 9)
+
+	cond, ok := tree["other"].Attrs.If()
+
+	assert.True(t, ok)
+	assert.Equal(t, otherOS(), cond)
+
+	names := make([]string, 0, len(tree))
+
+	for name := range tree.Flatten("") {
+		names = append(names, name)
+	}
+
+	expected := []string{"always", "current", "not-other", "both"}
+
+	if isRoot {
+		expected = append(expected, "root")
+	} else {
+		expected = append(expected, "not-root")
+	}
+
+	assert.ElementsMatch(t, expected, names)
+	assert.Empty(t, tree["dir"].Flatten(""))
+	assert.Len(t, tree["current"].Flatten(""), 1)
+}
+
+func TestFileTree_Flatten_SkipModifiers(t *testing.T) {
+	t.Parallel()
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(`
+- skipped 'perm:"0644,skip-%[1]s"'
+- kept 'perm:"0644,skip-%[2]s" mode:"Dir,skip-%[1]s"'
+- many 'perm:"0600,skip-%[2]s,skip-%[1]s"'
+`, runtime.GOOS, otherOS())), &tree))
+
+	// The tags and their modifiers are kept in the tree.
+	assert.Equal(t, os.FileMode(0o644), *tree["skipped"].Tags.Perm())
+	assert.Equal(t, []string{"skip-" + runtime.GOOS}, tree["skipped"].Modifiers["perm"])
+
+	flat := tree.Flatten("")
+
+	assert.Nil(t, flat["skipped"].Tags.Perm())
+	assert.Equal(t, os.FileMode(0o644), *flat["kept"].Tags.Perm())
+	assert.Nil(t, flat["kept"].Tags.Mode())
+	assert.Nil(t, flat["many"].Tags.Perm())
+
+	require.NoError(t, yaml.Unmarshal([]byte(`- file 'perm:"0644,skip-root"'`), &tree))
+
+	assert.Equal(t, os.Geteuid() == 0, tree.Flatten("")["file"].Tags.Perm() == nil)
+}
+
+func TestFileTree_MarshalYAML_Conditions(t *testing.T) {
+	t.Parallel()
+
+	text := fmt.Sprintf(`- app 'perm:"0755,skip-%[1]s"'
+- app.exe 'if:"%[1]s"'
+- dir 'if:"!%[1]s,!root"':
+    - file
+`, otherOS())
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(text), &tree))
+
+	out, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+
+	assert.Equal(t, text, string(out))
+}
+
+func TestFileTree_UnmarshalYAML_InvalidCondition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		text          string
+		expectedError string
+	}{
+		{
+			scenario:      "unknown os in if tag",
+			text:          `- file 'if:"!windwos"'`,
+			expectedError: `invalid condition "!windwos" in "if" tag at line 1`,
+		},
+		{
+			scenario:      "empty if tag",
+			text:          `- file 'if:""'`,
+			expectedError: `invalid condition "" in "if" tag at line 1`,
+		},
+		{
+			scenario:      "unknown modifier",
+			text:          `- file 'perm:"0644,omitempty"'`,
+			expectedError: `invalid condition "omitempty" in "perm" tag at line 1`,
+		},
+		{
+			scenario:      "unknown skip modifier",
+			text:          `- file 'perm:"0644,skip-admin"'`,
+			expectedError: `invalid condition "skip-admin" in "perm" tag at line 1`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			var tree aferoassert.FileTree
+
+			err := yaml.Unmarshal([]byte(tc.text), &tree)
+
+			assert.ErrorIs(t, err, aferoassert.ErrInvalidCondition)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestYAMLTreeEqual_Conditions(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/app", nil, 0o644))
+
+	tree := fmt.Sprintf(`
+- app 'perm:"0755,skip-%[1]s"'
+- app.exe 'if:"%[2]s"'
+`, runtime.GOOS, otherOS())
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist"), mockT.message())
+
+	tree = fmt.Sprintf(`
+- app 'perm:"0755,skip-%[1]s"'
+- app.exe 'if:"!%[1]s"'
+`, otherOS())
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist"))
+	assert.Contains(t, mockT.message(), `perm is 0644, expected 0755`)
+	assert.Contains(t, mockT.message(), "- app.exe")
+}
+
+func TestTreeEqual_Conditions(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/app", nil, 0o644))
+
+	tree := aferoassert.FileTree{
+		"app": {
+			Name:      "app",
+			Tags:      aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(0o755)},
+			Modifiers: aferoassert.FileModeModifiers{"perm": {"skip-" + runtime.GOOS}},
+		},
+		"app.exe": {Name: "app.exe", Attrs: aferoassert.FileAttrs{"if": otherOS()}},
+	}
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.TreeEqual(mockT, fs, tree, "dist"), mockT.message())
+
+	tree["app.exe"] = aferoassert.FileNode{Name: "app.exe", Attrs: aferoassert.FileAttrs{"if": runtime.GOOS}}
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.TreeEqual(mockT, fs, tree, "dist"))
+	assert.Contains(t, mockT.message(), "- app.exe")
+}
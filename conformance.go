@@ -0,0 +1,431 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// conformanceCheck verifies one behavior of a file system in a scratch directory.
+type conformanceCheck struct {
+	name  string
+	check func(fs afero.Fs, dir string) error
+}
+
+var conformanceChecks = []conformanceCheck{
+	{name: "create", check: checkCreate},
+	{name: "read", check: checkRead},
+	{name: "seek", check: checkSeek},
+	{name: "stat not exist", check: checkStatNotExist},
+	{name: "mkdir", check: checkMkdir},
+	{name: "readdir", check: checkReaddir},
+	{name: "rename", check: checkRename},
+	{name: "remove", check: checkRemove},
+	{name: "remove all", check: checkRemoveAll},
+	{name: "open exclusive", check: checkOpenExclusive},
+	{name: "open append", check: checkOpenAppend},
+	{name: "open truncate", check: checkOpenTruncate},
+	{name: "chmod", check: checkChmod},
+}
+
+// FsConformance runs a battery of checks against a writable file system to verify that it behaves like the standard
+// ones, for example, creating, reading, seeking, renaming and removing files, reading directories, and returning the
+// errors that satisfy errors.Is(err, os.ErrNotExist) and errors.Is(err, os.ErrExist). It helps the authors of the
+// custom afero backends. The checks run in a temporary directory that is removed afterwards.
+func FsConformance(t TestingT, fs afero.Fs, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FsConformance", func(t TestingT) bool {
+		return assertFsConformance(t, c, fs, msgAndArgs...)
+	})
+}
+
+func assertFsConformance(t TestingT, c *config, fs afero.Fs, msgAndArgs ...interface{}) bool {
+	root, err := conformanceDir(fs)
+	if err != nil {
+		return c.fail(t, FailureInfo{Message: fmt.Sprintf("could not create a temporary directory: %s", err)}, msgAndArgs...)
+	}
+
+	defer fs.RemoveAll(root) // nolint: errcheck
+
+	result := true
+
+	for i, cc := range conformanceChecks {
+		dir := filepath.Join(root, fmt.Sprintf("%02d", i))
+
+		if err := fs.Mkdir(dir, 0o755); err != nil {
+			result = c.fail(t, FailureInfo{Path: dir, Message: fmt.Sprintf("could not create directory %q: %s", dir, err)}, msgAndArgs...)
+
+			continue
+		}
+
+		if err := cc.check(fs, dir); err != nil {
+			result = c.fail(t, FailureInfo{Path: dir, Message: fmt.Sprintf("%s: %s", cc.name, err)}, msgAndArgs...)
+		}
+	}
+
+	return result
+}
+
+// conformanceDir creates the scratch directory in the temporary directory of the OS, or in the root of the file system
+// if there is no such directory, for example, when the file system is an afero.BasePathFs.
+func conformanceDir(fs afero.Fs) (string, error) {
+	dir, err := afero.TempDir(fs, "", "aferoassert-conformance")
+	if err == nil {
+		return dir, nil
+	}
+
+	if dir, err := afero.TempDir(fs, string(os.PathSeparator), "aferoassert-conformance"); err == nil {
+		return dir, nil
+	}
+
+	return "", err
+}
+
+func writeFile(fs afero.Fs, path, content string) error {
+	return afero.WriteFile(fs, path, []byte(content), 0o644)
+}
+
+func expectContent(fs afero.Fs, path, expected string) error {
+	actual, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return fmt.Errorf("could not read %q: %w", path, err)
+	}
+
+	if string(actual) != expected {
+		return fmt.Errorf("%q content is %q, expected %q", path, actual, expected) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func expectNotExist(fs afero.Fs, path string) error {
+	if _, err := fs.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("stat(%q) returned %v, expected an error satisfying os.ErrNotExist", path, err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkCreate(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	f, err := fs.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %q: %w", path, err)
+	}
+
+	if _, err := f.WriteString("hello"); err != nil {
+		_ = f.Close() // nolint: errcheck
+
+		return fmt.Errorf("could not write %q: %w", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close %q: %w", path, err)
+	}
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not stat %q: %w", path, err)
+	}
+
+	switch {
+	case info.IsDir():
+		return fmt.Errorf("%q is a directory", path) // nolint: goerr113
+
+	case info.Size() != 5: // nolint: mnd
+		return fmt.Errorf("%q size is %d, expected 5", path, info.Size()) // nolint: goerr113
+
+	case info.Name() != "file":
+		return fmt.Errorf("%q name is %q, expected %q", path, info.Name(), "file") // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkRead(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello"); err != nil {
+		return err
+	}
+
+	return expectContent(fs, path, "hello")
+}
+
+func checkSeek(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello"); err != nil {
+		return err
+	}
+
+	f, err := fs.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	if pos, err := f.Seek(1, io.SeekStart); err != nil || pos != 1 {
+		return fmt.Errorf("seek(1, io.SeekStart) returned (%d, %v), expected (1, nil)", pos, err) // nolint: goerr113
+	}
+
+	buf := make([]byte, 2)
+
+	if _, err := io.ReadFull(f, buf); err != nil || string(buf) != "el" {
+		return fmt.Errorf("read %q after seeking, expected %q: %v", buf, "el", err) // nolint: goerr113
+	}
+
+	if pos, err := f.Seek(-1, io.SeekEnd); err != nil || pos != 4 {
+		return fmt.Errorf("seek(-1, io.SeekEnd) returned (%d, %v), expected (4, nil)", pos, err) // nolint: goerr113
+	}
+
+	if pos, err := f.Seek(-1, io.SeekCurrent); err != nil || pos != 3 {
+		return fmt.Errorf("seek(-1, io.SeekCurrent) returned (%d, %v), expected (3, nil)", pos, err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkStatNotExist(fs afero.Fs, dir string) error {
+	if err := expectNotExist(fs, filepath.Join(dir, "unknown")); err != nil {
+		return err
+	}
+
+	if _, err := fs.Open(filepath.Join(dir, "unknown")); !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("open returned %v, expected an error satisfying os.ErrNotExist", err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkMkdir(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "dir")
+
+	if err := fs.Mkdir(path, 0o755); err != nil {
+		return fmt.Errorf("could not create directory %q: %w", path, err)
+	}
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not stat %q: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", path) // nolint: goerr113
+	}
+
+	if err := fs.Mkdir(path, 0o755); !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("mkdir of an existing directory returned %v, expected an error satisfying os.ErrExist", err) // nolint: goerr113
+	}
+
+	nested := filepath.Join(dir, "a", "b", "c")
+
+	if err := fs.MkdirAll(nested, 0o755); err != nil {
+		return fmt.Errorf("could not create directory %q: %w", nested, err)
+	}
+
+	if info, err := fs.Stat(nested); err != nil || !info.IsDir() {
+		return fmt.Errorf("%q is not a directory after MkdirAll: %v", nested, err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkReaddir(fs afero.Fs, dir string) error {
+	expected := []string{"a", "b", "c"}
+
+	for _, name := range expected {
+		if err := writeFile(fs, filepath.Join(dir, name), name); err != nil {
+			return err
+		}
+	}
+
+	names, err := readDirNames(fs, dir)
+	if err != nil {
+		return fmt.Errorf("could not read directory %q: %w", dir, err)
+	}
+
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		return fmt.Errorf("directory %q contains %q, expected %q", dir, names, expected) // nolint: goerr113
+	}
+
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return fmt.Errorf("could not read directory %q: %w", dir, err)
+	}
+
+	names = make([]string, 0, len(infos))
+
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+
+	sort.Strings(names)
+
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		return fmt.Errorf("directory %q contains %q, expected %q", dir, names, expected) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkRename(fs afero.Fs, dir string) error {
+	oldPath := filepath.Join(dir, "old")
+	newPath := filepath.Join(dir, "new")
+
+	if err := writeFile(fs, oldPath, "hello"); err != nil {
+		return err
+	}
+
+	if err := fs.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("could not rename %q to %q: %w", oldPath, newPath, err)
+	}
+
+	if err := expectNotExist(fs, oldPath); err != nil {
+		return err
+	}
+
+	return expectContent(fs, newPath, "hello")
+}
+
+func checkRemove(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello"); err != nil {
+		return err
+	}
+
+	if err := fs.Remove(path); err != nil {
+		return fmt.Errorf("could not remove %q: %w", path, err)
+	}
+
+	if err := expectNotExist(fs, path); err != nil {
+		return err
+	}
+
+	if err := fs.Remove(path); !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove of a missing file returned %v, expected an error satisfying os.ErrNotExist", err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkRemoveAll(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "dir")
+
+	if err := fs.MkdirAll(filepath.Join(path, "nested"), 0o755); err != nil {
+		return fmt.Errorf("could not create directory %q: %w", path, err)
+	}
+
+	if err := writeFile(fs, filepath.Join(path, "nested", "file"), "hello"); err != nil {
+		return err
+	}
+
+	if err := fs.RemoveAll(path); err != nil {
+		return fmt.Errorf("could not remove %q: %w", path, err)
+	}
+
+	if err := expectNotExist(fs, filepath.Join(path, "nested", "file")); err != nil {
+		return err
+	}
+
+	if err := fs.RemoveAll(path); err != nil {
+		return fmt.Errorf("remove all of a missing directory returned %w, expected nil", err)
+	}
+
+	return expectNotExist(fs, path)
+}
+
+func checkOpenExclusive(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello"); err != nil {
+		return err
+	}
+
+	f, err := fs.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	if err == nil {
+		_ = f.Close() // nolint: errcheck
+	}
+
+	if !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("exclusive open of an existing file returned %v, expected an error satisfying os.ErrExist", err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func checkOpenAppend(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello"); err != nil {
+		return err
+	}
+
+	f, err := fs.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not open %q: %w", path, err)
+	}
+
+	if _, err := f.WriteString(" world"); err != nil {
+		_ = f.Close() // nolint: errcheck
+
+		return fmt.Errorf("could not write %q: %w", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close %q: %w", path, err)
+	}
+
+	return expectContent(fs, path, "hello world")
+}
+
+func checkOpenTruncate(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello world"); err != nil {
+		return err
+	}
+
+	if err := writeFile(fs, path, "bye"); err != nil {
+		return err
+	}
+
+	return expectContent(fs, path, "bye")
+}
+
+func checkChmod(fs afero.Fs, dir string) error {
+	path := filepath.Join(dir, "file")
+
+	if err := writeFile(fs, path, "hello"); err != nil {
+		return err
+	}
+
+	if err := fs.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("could not chmod %q: %w", path, err)
+	}
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not stat %q: %w", path, err)
+	}
+
+	if perm := info.Mode() & os.ModePerm; perm != 0o600 {
+		return fmt.Errorf("%q perm is 0%o after chmod, expected 0600", path, perm) // nolint: goerr113
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// brokenFs returns the errors that do not satisfy os.ErrNotExist.
+type brokenFs struct {
+	afero.Fs
+}
+
+func (fs brokenFs) Stat(name string) (os.FileInfo, error) {
+	info, err := fs.Fs.Stat(name)
+	if err != nil {
+		return nil, assert.AnError
+	}
+
+	return info, nil
+}
+
+func TestFsConformance_Success(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		fs       afero.Fs
+	}{
+		{
+			scenario: "memmap",
+			fs:       afero.NewMemMapFs(),
+		},
+		{
+			scenario: "os",
+			fs:       afero.NewBasePathFs(afero.NewOsFs(), t.TempDir()),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.True(t, aferoassert.FsConformance(mockT, tc.fs), mockT.message())
+		})
+	}
+}
+
+func TestFsConformance_Failure(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.FsConformance(mockT, brokenFs{Fs: afero.NewMemMapFs()}))
+	require.NotEmpty(t, mockT.errors)
+	assert.Contains(t, mockT.message(), "stat not exist: stat(")
+	assert.Contains(t, mockT.message(), "expected an error satisfying os.ErrNotExist")
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FsConformance(mockT, afero.NewReadOnlyFs(afero.NewMemMapFs())))
+	assert.Contains(t, mockT.message(), "could not create a temporary directory")
+}
@@ -0,0 +1,108 @@
+package aferoassert
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// FsContentSetEqual checks whether the regular files of a directory have the same set of contents in both file
+// systems, regardless of their paths, for example, to test that a sync reorganized the same data. The duplicated
+// contents are counted once.
+func FsContentSetEqual(t TestingT, expected, actual afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FsContentSetEqual", func(t TestingT) bool {
+		return assertFsContentSetEqual(t, c, expected, actual, root, msgAndArgs...)
+	})
+}
+
+func assertFsContentSetEqual(t TestingT, c *config, expected, actual afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+
+	e, err := contentSet(c, expected, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not fingerprint %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	a, err := contentSet(c, actual, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not fingerprint %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	missing := contentSetDifference(e, a)
+	unexpected := contentSetDifference(a, e)
+
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return true
+	}
+
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%q does not have the same contents as expected:", root)
+
+	for _, p := range missing {
+		_, _ = fmt.Fprintf(&sb, "\n- missing content of %q", p)
+	}
+
+	for _, p := range unexpected {
+		_, _ = fmt.Fprintf(&sb, "\n+ unexpected content of %q", p)
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:     root,
+		Expected: missing,
+		Actual:   unexpected,
+		Message:  sb.String(),
+	}, msgAndArgs...)
+}
+
+// contentSet returns the hashes of the contents of the regular files, and the first path having each of them.
+func contentSet(c *config, fs afero.Fs, root string) (map[string]string, error) {
+	snapshot, err := fingerprint(c, fs, root)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(snapshot))
+
+	for p, s := range snapshot {
+		if s.Mode.IsRegular() {
+			paths = append(paths, p)
+		}
+	}
+
+	sort.Strings(paths)
+
+	result := make(map[string]string, len(paths))
+
+	for _, p := range paths {
+		if _, ok := result[snapshot[p].Hash]; !ok {
+			result[snapshot[p].Hash] = filepath.Join(root, p)
+		}
+	}
+
+	return result, nil
+}
+
+// contentSetDifference returns the sorted paths of the contents of a that are not in b.
+func contentSetDifference(a, b map[string]string) []string {
+	result := make([]string, 0)
+
+	for hash, p := range a {
+		if _, ok := b[hash]; !ok {
+			result = append(result, p)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
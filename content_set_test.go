@@ -0,0 +1,35 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFsContentSetEqual(t *testing.T) {
+	t.Parallel()
+
+	expected := newSourceFs(t)
+	actual := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(actual, "root/a/b/renamed", []byte("line 1\nline 2\n"), 0o600))
+	require.NoError(t, afero.WriteFile(actual, "root/file 2", []byte("file 2"), 0o644))
+	require.NoError(t, afero.WriteFile(actual, "root/copy of file 2", []byte("file 2"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FsContentSetEqual(mockT, expected, actual, "root"), mockT.message())
+
+	require.NoError(t, afero.WriteFile(actual, "root/file 2", []byte("changed"), 0o644))
+	require.NoError(t, actual.Remove("root/copy of file 2"))
+
+	assert.False(t, aferoassert.FsContentSetEqual(mockT, expected, actual, "root"))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"root" does not have the same contents as expected:`)
+	assert.Contains(t, mockT.errors[0], `- missing content of "root/folder 2/file 2"`)
+	assert.Contains(t, mockT.errors[0], `+ unexpected content of "root/file 2"`)
+}
@@ -0,0 +1,73 @@
+package aferoassert
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// copyDir copies the directories and the regular files from a file system to another one, with their permissions.
+// The other file types are skipped.
+func copyDir(c *config, src afero.Fs, srcRoot string, dst afero.Fs, dstRoot string) error {
+	srcRoot = filepath.Clean(srcRoot)
+
+	return walk(c, src, srcRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(srcRoot, path)
+
+		if rel != "." && c.ignored(rel) {
+			return skip(info)
+		}
+
+		target := filepath.Join(dstRoot, rel)
+
+		switch {
+		case info.IsDir():
+			if err := dst.MkdirAll(target, info.Mode().Perm()); err != nil {
+				return err
+			}
+
+			return dst.Chmod(target, info.Mode().Perm())
+
+		case info.Mode().IsRegular():
+			data, err := afero.ReadFile(src, path)
+			if err != nil {
+				return err
+			}
+
+			if err := afero.WriteFile(dst, target, data, info.Mode().Perm()); err != nil {
+				return err
+			}
+
+			return dst.Chmod(target, info.Mode().Perm())
+		}
+
+		return nil
+	})
+}
+
+// CopyToMemFs copies a directory to a new in-memory file system, at the same path, so the tests can mutate the copy
+// freely and then compare it with the original, for example, with FsEqual. The directories and the regular files are
+// copied with their permissions, the other file types are skipped. The IgnorePaths and FollowLinks options are
+// supported.
+func CopyToMemFs(src afero.Fs, root string, opts ...Option) (afero.Fs, error) {
+	dst := afero.NewMemMapFs()
+
+	if err := copyDir(configFromOptions(opts), src, root, dst, root); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// FromOsDir copies a directory on disk, such as "testdata/fixture", to a new in-memory file system at the same path,
+// see CopyToMemFs.
+//
+//	fs, err := aferoassert.FromOsDir("testdata/fixture")
+func FromOsDir(path string, opts ...Option) (afero.Fs, error) {
+	return CopyToMemFs(afero.NewOsFs(), path, opts...)
+}
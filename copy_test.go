@@ -0,0 +1,59 @@
+package aferoassert_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFromOsDir(t *testing.T) {
+	t.Parallel()
+
+	fs, err := aferoassert.FromOsDir("testdata/templates")
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FsEqual(mockT, afero.NewOsFs(), fs, "testdata/templates"), mockT.message())
+
+	// The copy is isolated from the original.
+	require.NoError(t, afero.WriteFile(fs, "testdata/templates/config.yaml", []byte("changed"), 0o644))
+	require.NoError(t, fs.Remove("testdata/templates/nested/readme.md"))
+
+	assert.False(t, aferoassert.FsEqual(mockT, afero.NewOsFs(), fs, "testdata/templates"))
+	assert.FileExists(t, filepath.FromSlash("testdata/templates/nested/readme.md"))
+}
+
+func TestCopyToMemFs(t *testing.T) {
+	t.Parallel()
+
+	src := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(src, "fixture/bin/app", []byte("#!/bin/sh"), 0o755))
+	require.NoError(t, afero.WriteFile(src, "fixture/cache/data", nil, 0o644))
+	require.NoError(t, src.Chmod("fixture/bin", 0o700))
+
+	fs, err := aferoassert.CopyToMemFs(src, "fixture", aferoassert.IgnorePaths("cache"))
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, `
+- bin 'perm:"0700"':
+    - app 'perm:"0755" content:"#!/bin/sh"'
+`, "fixture"), mockT.message())
+}
+
+func TestCopyToMemFs_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := aferoassert.CopyToMemFs(afero.NewMemMapFs(), "fixture")
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
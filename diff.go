@@ -0,0 +1,42 @@
+package aferoassert
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diff returns the unified diff of the expected and the actual content, truncated to the limit of WithMaxContentSize.
+func (c *config) diff(expected, actual string) string {
+	return c.truncate(unifiedDiff(expected, actual, c.diffContext))
+}
+
+// truncate cuts the content embedded in a failure message to the limit of WithMaxContentSize, without splitting a
+// rune.
+func (c *config) truncate(s string) string {
+	if c.maxContentSize <= 0 || len(s) <= c.maxContentSize {
+		return s
+	}
+
+	n := c.maxContentSize
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return fmt.Sprintf("%s\n... (%d bytes truncated)", s[:n], len(s)-n)
+}
+
+// unifiedDiff returns a line-based unified diff between the expected and the actual content.
+func unifiedDiff(expected, actual string, context int) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{ //nolint: errcheck
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  context,
+	})
+
+	return diff
+}
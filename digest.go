@@ -0,0 +1,64 @@
+package aferoassert
+
+import (
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/spf13/afero"
+)
+
+// FileCRC32 checks whether the CRC-32 (IEEE) of the content of a file is the expected one. It is a cheap alternative
+// to comparing the content when the tests check thousands of files.
+func FileCRC32(t TestingT, fs afero.Fs, path string, expected uint32, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileCRC32", func(t TestingT) bool {
+		return assertFileDigest(t, c, fs, path, "CRC32", crc32.NewIEEE(), fmt.Sprintf("%08x", expected), msgAndArgs...)
+	})
+}
+
+// FileXXHash checks whether the 64-bit xxHash of the content of a file is the expected one. It is a cheap alternative
+// to comparing the content when the tests check thousands of files.
+func FileXXHash(t TestingT, fs afero.Fs, path string, expected uint64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileXXHash", func(t TestingT) bool {
+		return assertFileDigest(t, c, fs, path, "xxHash", xxhash.New(), fmt.Sprintf("%016x", expected), msgAndArgs...)
+	})
+}
+
+// assertFileDigest streams the content of a file through the hash and compares the hex-encoded sum.
+func assertFileDigest(t TestingT, c *config, fs afero.Fs, path, name string, h hash.Hash, expected string, msgAndArgs ...interface{}) bool {
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	if _, err := io.Copy(h, f); err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	if actual := fmt.Sprintf("%x", h.Sum(nil)); actual != expected {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q %s is %s, expected %s", path, name, actual, expected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
@@ -0,0 +1,48 @@
+package aferoassert_test
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileCRC32(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	expected := crc32.ChecksumIEEE([]byte("line 1\nline 2\n"))
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileCRC32(mockT, fs, "root/file 1", expected), mockT.message())
+
+	assert.False(t, aferoassert.FileCRC32(mockT, fs, "root/folder 2/file 2", expected))
+	assert.Contains(t, mockT.message(), `"root/folder 2/file 2" CRC32 is`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileCRC32(mockT, fs, "root/folder 2", expected))
+	assert.Contains(t, mockT.message(), `"root/folder 2" is a directory`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileCRC32(mockT, fs, "root/unknown", expected))
+	assert.Contains(t, mockT.message(), `unable to find file "root/unknown"`)
+}
+
+func TestFileXXHash(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	expected := xxhash.Sum64String("line 1\nline 2\n")
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileXXHash(mockT, fs, "root/file 1", expected), mockT.message())
+
+	assert.False(t, aferoassert.FileXXHash(mockT, fs, "root/file 1", 0x1))
+	assert.Contains(t, mockT.message(), `"root/file 1" xxHash is`)
+	assert.Contains(t, mockT.message(), "expected 0000000000000001")
+}
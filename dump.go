@@ -0,0 +1,141 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/afero"
+)
+
+// tCleanup is a TestingT that can run a function when the test ends, such as *testing.T.
+type tCleanup interface {
+	Cleanup(fn func())
+	Failed() bool
+}
+
+type tNamer interface {
+	Name() string
+}
+
+// unsafeFileNameChars are replaced when the name of the test is used as a file name.
+var unsafeFileNameChars = regexp.MustCompile(`[^\w.-]+`)
+
+// DumpOnFailure registers a cleanup that, if the test failed, renders the final state of a directory, the modes, the
+// sizes and the paths, into the test log, so the state can be investigated after the fact. The WithDumpContent option
+// adds the content of the small files, the WithDumpDir option writes the dump into a directory on disk instead of the
+// log, for example, for the CI to upload it as an artifact. The IgnorePaths and FollowLinks options are supported.
+// It does nothing if t does not support Cleanup and Failed.
+//
+//	aferoassert.DumpOnFailure(t, fs, "dist", aferoassert.WithDumpContent(1024))
+func DumpOnFailure(t TestingT, fs afero.Fs, root string, opts ...Option) {
+	ct, ok := t.(tCleanup)
+	if !ok {
+		return
+	}
+
+	c := configFromOptions(opts)
+
+	ct.Cleanup(func() {
+		if ct.Failed() {
+			dumpTree(t, c, fs, root)
+		}
+	})
+}
+
+func dumpTree(t TestingT, c *config, fs afero.Fs, root string) {
+	out, err := renderTree(c, fs, root)
+	if err != nil {
+		logf(t, "could not dump %q: %s", root, err)
+
+		return
+	}
+
+	if c.dumpDir == "" {
+		logf(t, "dump of %q:\n%s", root, out)
+
+		return
+	}
+
+	name := "dump"
+	if n, ok := t.(tNamer); ok {
+		name = unsafeFileNameChars.ReplaceAllString(n.Name(), "_")
+	}
+
+	path := filepath.Join(c.dumpDir, name+".txt")
+
+	if err := os.MkdirAll(c.dumpDir, 0o755); err != nil {
+		logf(t, "could not dump %q: %s", root, err)
+
+		return
+	}
+
+	if err := os.WriteFile(path, []byte(out), 0o644); err != nil {
+		logf(t, "could not dump %q: %s", root, err)
+
+		return
+	}
+
+	logf(t, "dump of %q written to %q", root, path)
+}
+
+// renderTree lists the paths under the root, one per line with the mode and the size, and the content of the regular
+// files that are not larger than the limit of WithDumpContent.
+func renderTree(c *config, fs afero.Fs, root string) (string, error) {
+	root = filepath.Clean(root)
+
+	var sb strings.Builder
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(root, path)
+		if rel != "." && c.ignored(rel) {
+			return skip(info)
+		}
+
+		if info.IsDir() {
+			_, _ = fmt.Fprintf(&sb, "%s %10s %s%c\n", info.Mode(), "-", rel, os.PathSeparator)
+
+			return nil
+		}
+
+		_, _ = fmt.Fprintf(&sb, "%s %10d %s\n", info.Mode(), info.Size(), rel)
+
+		if !info.Mode().IsRegular() || info.Size() == 0 || info.Size() > c.dumpContentLimit {
+			return nil
+		}
+
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+
+		content := string(data)
+		if !utf8.Valid(data) {
+			content = fmt.Sprintf("%q", data)
+		}
+
+		for _, l := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+			_, _ = fmt.Fprintf(&sb, "    | %s\n", l)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+func logf(t TestingT, format string, args ...interface{}) {
+	if l, ok := t.(tLogger); ok {
+		l.Log(fmt.Sprintf(format, args...))
+	}
+}
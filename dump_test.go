@@ -0,0 +1,176 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// cleanupT is a testingT that supports Cleanup, Failed, Log and Name, the cleanups run when finish is called.
+type cleanupT struct {
+	testingT
+
+	name     string
+	logs     []string
+	cleanups []func()
+}
+
+func (t *cleanupT) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *cleanupT) Failed() bool {
+	return len(t.errors) > 0
+}
+
+func (t *cleanupT) Log(args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+func (t *cleanupT) Name() string {
+	return t.name
+}
+
+func (t *cleanupT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func newDumpFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("dist/assets", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "dist/assets/app.js", []byte("console.log(1)\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", []byte("<html>\n</html>\n"), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "dist/logo.png", []byte{0x89, 'P', 'N', 'G'}, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/debug.log", nil, 0o644))
+
+	return fs
+}
+
+func TestDumpOnFailure(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario     string
+		failed       bool
+		options      []aferoassert.Option
+		expectedLogs []string
+	}{
+		{
+			scenario: "passed",
+		},
+		{
+			scenario: "failed",
+			failed:   true,
+			expectedLogs: []string{`dump of "dist":
+drwxr-xr-x          - ./
+drwxr-xr-x          - assets/
+-rw-r--r--         15 assets/app.js
+-rw-r--r--          0 debug.log
+-rw-------         15 index.html
+-rw-r--r--          4 logo.png
+`},
+		},
+		{
+			scenario: "with content",
+			failed:   true,
+			options:  []aferoassert.Option{aferoassert.WithDumpContent(4), aferoassert.IgnorePaths("assets")},
+			expectedLogs: []string{`dump of "dist":
+drwxr-xr-x          - ./
+-rw-r--r--          0 debug.log
+-rw-------         15 index.html
+-rw-r--r--          4 logo.png
+    | "\x89PNG"
+`},
+		},
+		{
+			scenario: "with all content",
+			failed:   true,
+			options:  []aferoassert.Option{aferoassert.WithDumpContent(1024), aferoassert.IgnorePaths("*.png", "*.log")},
+			expectedLogs: []string{`dump of "dist":
+drwxr-xr-x          - ./
+drwxr-xr-x          - assets/
+-rw-r--r--         15 assets/app.js
+    | console.log(1)
+-rw-------         15 index.html
+    | <html>
+    | </html>
+`},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &cleanupT{}
+
+			aferoassert.DumpOnFailure(mockT, newDumpFs(t), "dist", tc.options...)
+
+			if tc.failed {
+				mockT.Errorf("failed")
+			}
+
+			mockT.finish()
+
+			assert.Equal(t, tc.expectedLogs, mockT.logs)
+		})
+	}
+}
+
+func TestDumpOnFailure_DumpDir(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	mockT := &cleanupT{name: "TestBuild/release build"}
+
+	aferoassert.DumpOnFailure(mockT, newDumpFs(t), "dist/assets", aferoassert.WithDumpDir(dir))
+
+	mockT.Errorf("failed")
+	mockT.finish()
+
+	path := filepath.Join(dir, "TestBuild_release_build.txt")
+
+	assert.Equal(t, []string{fmt.Sprintf(`dump of "dist/assets" written to %q`, path)}, mockT.logs)
+
+	actual, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+
+	expected := `drwxr-xr-x          - ./
+-rw-r--r--         15 app.js
+`
+
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestDumpOnFailure_Error(t *testing.T) {
+	t.Parallel()
+
+	mockT := &cleanupT{}
+
+	aferoassert.DumpOnFailure(mockT, afero.NewMemMapFs(), "dist")
+
+	mockT.Errorf("failed")
+	mockT.finish()
+
+	require.Len(t, mockT.logs, 1)
+	assert.Contains(t, mockT.logs[0], `could not dump "dist": open dist: file does not exist`)
+
+	// It does nothing without Cleanup.
+	assert.NotPanics(t, func() {
+		aferoassert.DumpOnFailure(&testingT{}, afero.NewMemMapFs(), "dist")
+	})
+}
@@ -0,0 +1,64 @@
+package aferoassert
+
+import (
+	"fmt"
+	iofs "io/fs"
+
+	"github.com/spf13/afero"
+)
+
+// EmbeddedTreeEqual checks whether a directory has exactly the files of an embedded file system, such as embed.FS,
+// with the same content, byte by byte. It is useful to test the code that extracts the embedded assets. The
+// permissions are not compared because the embedded files are always read-only.
+func EmbeddedTreeEqual(t TestingT, embedded iofs.FS, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "EmbeddedTreeEqual", func(t TestingT) bool {
+		a := &fsComparison{
+			t:            t,
+			c:            c,
+			msgAndArgs:   msgAndArgs,
+			expected:     FromIOFS(embedded),
+			expectedRoot: ".",
+			actual:       fs,
+			actualRoot:   root,
+			exhaustive:   true,
+		}
+
+		return a.run()
+	})
+}
+
+// EmbeddedFileEqual checks whether a file has the same content as a file of an embedded file system, byte by byte.
+func EmbeddedFileEqual(t TestingT, embedded iofs.FS, name string, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "EmbeddedFileEqual", func(t TestingT) bool {
+		return assertEmbeddedFileEqual(t, c, embedded, name, fs, path, msgAndArgs...)
+	})
+}
+
+func assertEmbeddedFileEqual(t TestingT, c *config, embedded iofs.FS, name string, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	expected, err := iofs.ReadFile(embedded, name)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: name, Message: fmt.Sprintf("could not read embedded %q: %s", name, err)}, msgAndArgs...)
+	}
+
+	if !assertFileExists(t, c, fs, path, msgAndArgs...) {
+		return false
+	}
+
+	if msg, ok := compareBytes(c, fs, path, expected); !ok {
+		return c.fail(t, FailureInfo{Kind: FailureContent, Path: path, Expected: string(expected), Message: msg}, msgAndArgs...)
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package aferoassert_test
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+//go:embed testdata/templates
+var templates embed.FS
+
+func extractTemplates(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "out/testdata/templates/config.yaml", []byte("debug: false\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "out/testdata/templates/nested/readme.md", []byte("# Readme\n"), 0o644))
+
+	return fs
+}
+
+func TestEmbeddedTreeEqual_Success(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.EmbeddedTreeEqual(mockT, templates, extractTemplates(t), "out"), mockT.message())
+}
+
+func TestEmbeddedTreeEqual_Failure(t *testing.T) {
+	t.Parallel()
+
+	fs := extractTemplates(t)
+
+	require.NoError(t, afero.WriteFile(fs, "out/testdata/templates/config.yaml", []byte("debug: true\n"), 0o644))
+	require.NoError(t, fs.Remove("out/testdata/templates/nested/readme.md"))
+	require.NoError(t, afero.WriteFile(fs, "out/testdata/templates/nested/README.md", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "out/unknown.txt", nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.EmbeddedTreeEqual(mockT, templates, fs, "out"))
+	require.Len(t, mockT.errors, 4)
+
+	assert.Contains(t, mockT.errors[0], "\"out/testdata/templates/config.yaml\" content is not as expected:\n")
+	assert.Contains(t, mockT.errors[0], "-debug: false\n")
+	assert.Contains(t, mockT.errors[0], "+debug: true\n")
+	assert.Contains(t, mockT.errors[1], `unexpected file "out/testdata/templates/nested/README.md"`)
+	assert.Contains(t, mockT.errors[2], `unexpected file "out/unknown.txt"`)
+	assert.Contains(t, mockT.errors[3], `expected these files in "out" but not found:`)
+	assert.Contains(t, mockT.errors[3], `- readme.md, did you mean "out/testdata/templates/nested/README.md"?`)
+}
+
+func TestEmbeddedFileEqual(t *testing.T) {
+	t.Parallel()
+
+	fs := extractTemplates(t)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.EmbeddedFileEqual(mockT, templates, "testdata/templates/config.yaml", fs, "out/testdata/templates/config.yaml"))
+	assert.Empty(t, mockT.errors)
+
+	assert.False(t, aferoassert.EmbeddedFileEqual(mockT, templates, "testdata/templates/nested/readme.md", fs, "out/testdata/templates/config.yaml"))
+	assert.False(t, aferoassert.EmbeddedFileEqual(mockT, templates, "testdata/templates/unknown", fs, "out/testdata/templates/config.yaml"))
+	require.Len(t, mockT.errors, 2)
+
+	assert.Contains(t, mockT.errors[0], `"out/testdata/templates/config.yaml" content is not as expected`)
+	assert.Contains(t, mockT.errors[1], `could not read embedded "testdata/templates/unknown"`)
+}
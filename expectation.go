@@ -0,0 +1,111 @@
+package aferoassert
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	_ TestingT        = (*expectationT)(nil)
+	_ failureRecorder = (*expectationT)(nil)
+)
+
+// FailedExpectation is a failure returned by the E variants of the assertions, such as TreeEqualE.
+type FailedExpectation = FailureInfo
+
+// CheckE runs a check without a test and returns its failures, instead of reporting them, so a custom harness can
+// render or aggregate them. The assertions that have no E variant can be checked with it, for example:
+//
+//	failures := aferoassert.CheckE(fs, func(t aferoassert.TestingT, fs afero.Fs) bool {
+//		return aferoassert.FileSize(t, fs, "app.bin", 1024)
+//	})
+//
+// A check that fails without an aferoassert failure, for example, by calling testify directly, returns a single
+// "check failed" failure.
+func CheckE(fs afero.Fs, check Check) []FailedExpectation {
+	t := &expectationT{}
+
+	if !check(t, fs) && len(t.failures) == 0 {
+		// The check failed without recording a failure, for example, it called testify directly.
+		t.failures = append(t.failures, FailedExpectation{Message: "check failed"})
+	}
+
+	return t.failures
+}
+
+// TreeEqualE checks whether a directory is the same as the expectation, like TreeEqual, and returns the failures.
+func TreeEqualE(fs afero.Fs, tree FileTree, root string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return TreeEqual(t, fs, tree, root, optionArgs(opts)...)
+	})
+}
+
+// YAMLTreeEqualE checks whether a directory is the same as the YAML expectation, like YAMLTreeEqual, and returns the
+// failures.
+func YAMLTreeEqualE(fs afero.Fs, expected, root string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return YAMLTreeEqual(t, fs, expected, root, optionArgs(opts)...)
+	})
+}
+
+// TreeContainsE checks whether a directory contains the expectation, like TreeContains, and returns the failures.
+func TreeContainsE(fs afero.Fs, tree FileTree, root string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return TreeContains(t, fs, tree, root, optionArgs(opts)...)
+	})
+}
+
+// YAMLTreeContainsE checks whether a directory contains the YAML expectation, like YAMLTreeContains, and returns the
+// failures.
+func YAMLTreeContainsE(fs afero.Fs, expected, root string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return YAMLTreeContains(t, fs, expected, root, optionArgs(opts)...)
+	})
+}
+
+// ExistsE checks whether a file or a directory exists, like Exists, and returns the failures.
+func ExistsE(fs afero.Fs, path string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return Exists(t, fs, path, optionArgs(opts)...)
+	})
+}
+
+// FileExistsE checks whether a file exists, like FileExists, and returns the failures.
+func FileExistsE(fs afero.Fs, path string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return FileExists(t, fs, path, optionArgs(opts)...)
+	})
+}
+
+// DirExistsE checks whether a directory exists, like DirExists, and returns the failures.
+func DirExistsE(fs afero.Fs, path string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return DirExists(t, fs, path, optionArgs(opts)...)
+	})
+}
+
+// PermE checks whether a path has the expected permission, like Perm, and returns the failures.
+func PermE(fs afero.Fs, path string, expected os.FileMode, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return Perm(t, fs, path, expected, optionArgs(opts)...)
+	})
+}
+
+// FileContentE checks whether a file has the expected content, like FileContent, and returns the failures.
+func FileContentE(fs afero.Fs, path string, expected string, opts ...Option) []FailedExpectation {
+	return CheckE(fs, func(t TestingT, fs afero.Fs) bool {
+		return FileContent(t, fs, path, expected, optionArgs(opts)...)
+	})
+}
+
+// expectationT keeps the details of the failures instead of reporting them, see CheckE.
+type expectationT struct {
+	failures []FailedExpectation
+}
+
+func (t *expectationT) Errorf(string, ...interface{}) {}
+
+func (t *expectationT) recordFailure(info FailureInfo) {
+	t.failures = append(t.failures, info)
+}
@@ -0,0 +1,104 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestTreeEqualE(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/app", []byte("#!/bin/sh"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/extra", nil, 0o644))
+
+	tree := `
+- app 'perm:"0755"'
+- missing
+`
+
+	failures := aferoassert.YAMLTreeEqualE(fs, tree, "dist")
+
+	require.Len(t, failures, 3)
+
+	assert.Equal(t, "YAMLTreeEqual", failures[0].Assertion)
+	assert.Equal(t, "dist/app", failures[0].Path)
+	assert.Equal(t, os.FileMode(0o755), failures[0].Expected)
+	assert.Equal(t, os.FileMode(0o644), failures[0].Actual)
+	assert.Contains(t, failures[1].Message, `"dist/extra"`)
+	assert.Contains(t, failures[2].Message, "- missing")
+
+	assert.Empty(t, aferoassert.YAMLTreeEqualE(fs, tree, "dist", aferoassert.IgnorePerm(), aferoassert.IgnorePaths("extra", "missing")))
+	assert.Len(t, aferoassert.YAMLTreeContainsE(fs, tree, "dist"), 2)
+	assert.Empty(t, aferoassert.YAMLTreeContainsE(fs, "- app\n", "dist"))
+	assert.Len(t, aferoassert.YAMLTreeEqualE(fs, "invalid", "dist"), 1)
+}
+
+func TestTreeEqualE_FileTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/app", nil, 0o644))
+
+	tree := aferoassert.FileTree{"app": {Name: "app"}}
+
+	assert.Empty(t, aferoassert.TreeEqualE(fs, tree, "dist"))
+	assert.Empty(t, aferoassert.TreeContainsE(fs, tree, "dist"))
+
+	require.NoError(t, afero.WriteFile(fs, "dist/extra", nil, 0o644))
+
+	assert.Len(t, aferoassert.TreeEqualE(fs, tree, "dist"), 1)
+	assert.Empty(t, aferoassert.TreeContainsE(fs, tree, "dist"))
+}
+
+func TestCheckE(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "app.bin", []byte("hello"), 0o600))
+
+	assert.Empty(t, aferoassert.ExistsE(fs, "app.bin"))
+	assert.Empty(t, aferoassert.FileExistsE(fs, "app.bin"))
+	assert.Empty(t, aferoassert.PermE(fs, "app.bin", 0o600))
+	assert.Empty(t, aferoassert.FileContentE(fs, "app.bin", "hello"))
+
+	failures := aferoassert.DirExistsE(fs, "app.bin")
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, "DirExists", failures[0].Assertion)
+
+	failures = aferoassert.FileContentE(fs, "app.bin", "world")
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, "world", failures[0].Expected)
+	assert.Equal(t, "hello", failures[0].Actual)
+
+	assert.Len(t, aferoassert.ExistsE(fs, "missing"), 1)
+	assert.Len(t, aferoassert.FileExistsE(fs, "missing"), 1)
+	assert.Len(t, aferoassert.PermE(fs, "app.bin", 0o644), 1)
+
+	failures = aferoassert.CheckE(fs, func(t aferoassert.TestingT, fs afero.Fs) bool {
+		return aferoassert.FileSize(t, fs, "app.bin", 1024)
+	})
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, "FileSize", failures[0].Assertion)
+	assert.Equal(t, "app.bin", failures[0].Path)
+
+	// A check that fails without recording a failure.
+	failures = aferoassert.CheckE(fs, func(t aferoassert.TestingT, fs afero.Fs) bool {
+		return assert.Fail(t, "custom failure")
+	})
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, "check failed", failures[0].Message)
+}
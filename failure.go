@@ -0,0 +1,147 @@
+package aferoassert
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FailureKind is the kind of a failure, so a Formatter can render the failures of the same kind the same way, whatever
+// the assertion.
+type FailureKind string
+
+const (
+	// FailureNotFound is the kind of the failures of the paths that do not exist.
+	FailureNotFound FailureKind = "not found"
+	// FailureUnexpected is the kind of the failures of the paths that exist but are not expected.
+	FailureUnexpected FailureKind = "unexpected"
+	// FailureType is the kind of the failures of the paths that are not of the expected type, such as a file instead of
+	// a directory.
+	FailureType FailureKind = "type"
+	// FailureMode is the kind of the failures of the `mode` tags.
+	FailureMode FailureKind = "mode"
+	// FailurePerm is the kind of the failures of the permissions.
+	FailurePerm FailureKind = "perm"
+	// FailureSize is the kind of the failures of the sizes.
+	FailureSize FailureKind = "size"
+	// FailureContent is the kind of the failures of the contents.
+	FailureContent FailureKind = "content"
+	// FailureLink is the kind of the failures of the `link` tags, when a symlink is not of the expected flavor.
+	FailureLink FailureKind = "link"
+	// FailureTarget is the kind of the failures of the `target` tags.
+	FailureTarget FailureKind = "target"
+	// FailureValid is the kind of the failures of the `valid` tags.
+	FailureValid FailureKind = "valid"
+	// FailureXattr is the kind of the failures of the extended attributes.
+	FailureXattr FailureKind = "xattr"
+)
+
+// FailureInfo contains the details of a failed assertion, see WithOnFailure.
+type FailureInfo struct {
+	// Assertion is the name of the assertion, for example "FileContent".
+	Assertion string
+	// Kind is the kind of the failure, it is empty for the failures that have no kind, such as an error while reading a
+	// file.
+	Kind FailureKind
+	// Path is the path being checked.
+	Path string
+	// Expected is the expected value, if there is any.
+	Expected interface{}
+	// Actual is the actual value, if there is any.
+	Actual interface{}
+	// Message is the failure message.
+	Message string
+}
+
+// Formatter renders the messages of the failures, see WithFormatter.
+type Formatter interface {
+	// Format returns the message of the failure, the Message of the info is the default one.
+	Format(info FailureInfo) string
+}
+
+// FormatterFunc is a function that renders the messages of the failures.
+type FormatterFunc func(info FailureInfo) string
+
+// Format satisfies Formatter.
+func (f FormatterFunc) Format(info FailureInfo) string {
+	return f(info)
+}
+
+// tFailNower is a TestingT that can stop the test, such as *testing.T.
+type tFailNower interface {
+	FailNow()
+}
+
+// failNow stops the test if the TestingT supports it.
+func failNow(t TestingT) {
+	if t, ok := t.(tFailNower); ok {
+		t.FailNow()
+	}
+}
+
+// failureRecorder is a TestingT that keeps the details of the failures, such as Collector.
+type failureRecorder interface {
+	recordFailure(info FailureInfo)
+}
+
+// fail calls the OnFailure hooks and reports the failure.
+func (c *config) fail(t TestingT, info FailureInfo, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if info.Assertion == "" {
+		info.Assertion = c.assertion
+	}
+
+	if c.formatter != nil {
+		info.Message = c.formatter.Format(info)
+	}
+
+	if _, retrying := t.(*silentT); !retrying {
+		for _, fn := range c.onFailure {
+			fn(info)
+		}
+
+		if r, ok := t.(failureRecorder); ok {
+			r.recordFailure(info)
+		}
+	}
+
+	return assert.Fail(t, info.Message, msgAndArgs...)
+}
+
+// splitNotFound splits the failure of the files that are not found, which are reported together on their root, into
+// one failure per file, so the reporters can have one test case per path.
+func splitNotFound(f FailureInfo) []FailureInfo {
+	missing, ok := f.Expected.([]string)
+	if !ok || f.Kind != FailureNotFound {
+		return []FailureInfo{f}
+	}
+
+	result := make([]FailureInfo, 0, len(missing))
+
+	for _, p := range missing {
+		p = filepath.Join(f.Path, p)
+
+		result = append(result, FailureInfo{
+			Assertion: f.Assertion,
+			Kind:      FailureNotFound,
+			Path:      p,
+			Message:   fmt.Sprintf("%q not found", p),
+		})
+	}
+
+	return result
+}
+
+// toRegexp converts the expectation to a regular expression the same way assert.Regexp does.
+func toRegexp(expected interface{}) *regexp.Regexp {
+	if r, ok := expected.(*regexp.Regexp); ok {
+		return r
+	}
+
+	return regexp.MustCompile(fmt.Sprint(expected))
+}
@@ -0,0 +1,187 @@
+package aferoassert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFailureInfo_Kind(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario     string
+		assert       func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool
+		expectedKind aferoassert.FailureKind
+	}{
+		{
+			scenario: "FileExists",
+			assert: func(_ *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				return aferoassert.FileExists(mockT, afero.NewMemMapFs(), "missing", opt)
+			},
+			expectedKind: aferoassert.FailureNotFound,
+		},
+		{
+			scenario: "FileContent",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := afero.NewMemMapFs()
+
+				require.NoError(t, afero.WriteFile(fs, "file", []byte("actual"), 0o644))
+
+				return aferoassert.FileContent(mockT, fs, "file", "expected", opt)
+			},
+			expectedKind: aferoassert.FailureContent,
+		},
+		{
+			scenario: "PermWithUmask",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := afero.NewMemMapFs()
+
+				require.NoError(t, afero.WriteFile(fs, "file", nil, 0o600))
+
+				return aferoassert.PermWithUmask(mockT, fs, "file", 0o666, 0o022, opt)
+			},
+			expectedKind: aferoassert.FailurePerm,
+		},
+		{
+			scenario: "EmbeddedTreeEqual",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := extractTemplates(t)
+
+				require.NoError(t, afero.WriteFile(fs, "out/extra", nil, 0o644))
+
+				return aferoassert.EmbeddedTreeEqual(mockT, templates, fs, "out", opt)
+			},
+			expectedKind: aferoassert.FailureUnexpected,
+		},
+		{
+			scenario: "Mirrored type",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := newMirroredFs(t)
+
+				require.NoError(t, fs.Remove("dst/file"))
+				require.NoError(t, fs.Mkdir("dst/file", 0o755))
+
+				return aferoassert.Mirrored(mockT, fs, "src", "dst", opt)
+			},
+			expectedKind: aferoassert.FailureType,
+		},
+		{
+			scenario: "Mirrored content",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := newMirroredFs(t)
+
+				require.NoError(t, afero.WriteFile(fs, "dst/file", []byte("changed\n"), 0o600))
+
+				return aferoassert.Mirrored(mockT, fs, "src", "dst", opt)
+			},
+			expectedKind: aferoassert.FailureContent,
+		},
+		{
+			scenario: "Mirrored unexpected file",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := newMirroredFs(t)
+
+				require.NoError(t, afero.WriteFile(fs, "dst/extra", nil, 0o644))
+
+				return aferoassert.Mirrored(mockT, fs, "src", "dst", opt)
+			},
+			expectedKind: aferoassert.FailureUnexpected,
+		},
+		{
+			scenario: "EmbeddedFileEqual",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := extractTemplates(t)
+				path := "out/testdata/templates/config.yaml"
+
+				require.NoError(t, afero.WriteFile(fs, path, []byte("debug: true\n"), 0o644))
+
+				return aferoassert.EmbeddedFileEqual(mockT, templates, "testdata/templates/config.yaml", fs, path, opt)
+			},
+			expectedKind: aferoassert.FailureContent,
+		},
+		{
+			scenario: "WaitForPath",
+			assert: func(_ *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				return aferoassert.WaitForPath(mockT, afero.NewMemMapFs(), "missing", 10*time.Millisecond, opt)
+			},
+			expectedKind: aferoassert.FailureNotFound,
+		},
+		{
+			scenario: "link tag",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs, root := newSymlinkFs(t)
+
+				return aferoassert.YAMLTreeContains(mockT, fs, `- relative 'link:"absolute"'`, root, opt)
+			},
+			expectedKind: aferoassert.FailureLink,
+		},
+		{
+			scenario: "target tag",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs, root := newSymlinkFs(t)
+
+				return aferoassert.YAMLTreeContains(mockT, fs, `- relative 'target:"bin/other"'`, root, opt)
+			},
+			expectedKind: aferoassert.FailureTarget,
+		},
+		{
+			scenario: "valid tag",
+			assert: func(t *testing.T, mockT aferoassert.TestingT, opt aferoassert.Option) bool {
+				t.Helper()
+
+				fs := afero.NewMemMapFs()
+
+				require.NoError(t, afero.WriteFile(fs, "root/config.json", []byte(`{"key": }`), 0o644))
+
+				return aferoassert.YAMLTreeContains(mockT, fs, `- config.json 'valid:"true"'`, "root", opt)
+			},
+			expectedKind: aferoassert.FailureValid,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			var failures []aferoassert.FailureInfo
+
+			mockT := &testingT{}
+			onFailure := aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+				failures = append(failures, info)
+			})
+
+			assert.False(t, tc.assert(t, mockT, onFailure))
+			require.NotEmpty(t, failures)
+
+			for _, f := range failures {
+				assert.Equal(t, tc.expectedKind, f.Kind, f.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package aferoassert
+
+import (
+	"os"
+	"strings"
+)
+
+// FlattenOption configures FileTree.FlattenWith.
+type FlattenOption interface {
+	applyFlattenOption(c *flattenConfig)
+}
+
+type flattenOptionFunc func(c *flattenConfig)
+
+func (f flattenOptionFunc) applyFlattenOption(c *flattenConfig) {
+	f(c)
+}
+
+type flattenConfig struct {
+	separator   string
+	excludeDirs bool
+	trimPrefix  string
+}
+
+// WithSeparator makes FlattenWith join the paths with the separator instead of the one of the OS, for example "/" for
+// the keys to be the same on all the platforms.
+func WithSeparator(sep string) FlattenOption {
+	return flattenOptionFunc(func(c *flattenConfig) {
+		c.separator = sep
+	})
+}
+
+// ExcludeDirs makes FlattenWith skip the directory nodes, only the files are kept.
+func ExcludeDirs() FlattenOption {
+	return flattenOptionFunc(func(c *flattenConfig) {
+		c.excludeDirs = true
+	})
+}
+
+// TrimPrefix makes FlattenWith remove the prefix, and the separator that follows it, from the keys. The prefix uses the
+// separator of WithSeparator, if it is set. The keys that do not start with the prefix are kept as is.
+func TrimPrefix(prefix string) FlattenOption {
+	return flattenOptionFunc(func(c *flattenConfig) {
+		c.trimPrefix = prefix
+	})
+}
+
+// FlattenWith converts the file tree to a flat map like Flatten, with control over the format of the keys, for example:
+//
+//	tree.FlattenWith("dist", aferoassert.WithSeparator("/"), aferoassert.ExcludeDirs(), aferoassert.TrimPrefix("dist"))
+func (t FileTree) FlattenWith(root string, opts ...FlattenOption) map[string]FileNode {
+	c := &flattenConfig{separator: string(os.PathSeparator)}
+
+	for _, o := range opts {
+		o.applyFlattenOption(c)
+	}
+
+	flat := t.Flatten(root)
+	result := make(map[string]FileNode, len(flat))
+
+	for p, n := range flat {
+		if c.excludeDirs && n.IsDir {
+			continue
+		}
+
+		if c.separator != string(os.PathSeparator) {
+			p = strings.ReplaceAll(p, string(os.PathSeparator), c.separator)
+		}
+
+		if c.trimPrefix != "" && strings.HasPrefix(p, c.trimPrefix+c.separator) {
+			p = strings.TrimPrefix(p, c.trimPrefix+c.separator)
+		}
+
+		result[p] = n
+	}
+
+	return result
+}
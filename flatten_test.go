@@ -0,0 +1,82 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileTree_FlattenWith(t *testing.T) {
+	t.Parallel()
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- README.md
+- bin:
+    - app
+- docs:
+    - guides:
+        - intro.md
+`), &tree))
+
+	testCases := []struct {
+		scenario     string
+		root         string
+		options      []aferoassert.FlattenOption
+		expectedKeys []string
+	}{
+		{
+			scenario:     "no options",
+			root:         "dist",
+			expectedKeys: []string{"dist/README.md", "dist/bin", "dist/bin/app", "dist/docs", "dist/docs/guides", "dist/docs/guides/intro.md"},
+		},
+		{
+			scenario:     "separator",
+			root:         "dist",
+			options:      []aferoassert.FlattenOption{aferoassert.WithSeparator("::")},
+			expectedKeys: []string{"dist::README.md", "dist::bin", "dist::bin::app", "dist::docs", "dist::docs::guides", "dist::docs::guides::intro.md"},
+		},
+		{
+			scenario:     "exclude dirs",
+			options:      []aferoassert.FlattenOption{aferoassert.ExcludeDirs()},
+			expectedKeys: []string{"README.md", "bin/app", "docs/guides/intro.md"},
+		},
+		{
+			scenario:     "trim prefix",
+			root:         "dist",
+			options:      []aferoassert.FlattenOption{aferoassert.TrimPrefix("dist/docs")},
+			expectedKeys: []string{"dist/README.md", "dist/bin", "dist/bin/app", "dist/docs", "guides", "guides/intro.md"},
+		},
+		{
+			scenario: "all options",
+			root:     "dist",
+			options: []aferoassert.FlattenOption{
+				aferoassert.WithSeparator("/"),
+				aferoassert.ExcludeDirs(),
+				aferoassert.TrimPrefix("dist"),
+			},
+			expectedKeys: []string{"README.md", "bin/app", "docs/guides/intro.md"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			keys := make([]string, 0)
+
+			for k := range tree.FlattenWith(tc.root, tc.options...) {
+				keys = append(keys, k)
+			}
+
+			assert.ElementsMatch(t, tc.expectedKeys, keys)
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package aferoassert
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/afero"
+)
+
+// FsEqual checks whether a directory has the same files, permissions and content in both file systems, for example,
+// to test that a copy or a sync produced an identical tree. All the differences are reported in one failure. The
+// permissions are not compared with IgnorePerm.
+func FsEqual(t TestingT, expected, actual afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FsEqual", func(t TestingT) bool {
+		return assertFsEqual(t, c, expected, actual, root, msgAndArgs...)
+	})
+}
+
+func assertFsEqual(t TestingT, c *config, expected, actual afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+
+	d, err := diffFs(c, expected, actual, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not compare %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if d.Empty() {
+		return true
+	}
+
+	differences := make([]string, 0, len(d.Modified)+len(d.Added)+1)
+
+	for _, m := range d.Modified {
+		differences = append(differences, describeChange(c, expected, actual, filepath.Join(root, m.Path), m)...)
+	}
+
+	for _, p := range topLevelPaths(d.Added) {
+		differences = append(differences, fmt.Sprintf("unexpected file %q", filepath.Join(root, p)))
+	}
+
+	if removed := topLevelPaths(d.Removed); len(removed) > 0 {
+		differences = append(differences, fmt.Sprintf("expected these files in %q but not found:\n%s", root, formatMissingFiles(removed, func(p string) string {
+			return didYouMean(actual, filepath.Join(root, p))
+		})))
+	}
+
+	if len(differences) == 0 {
+		return true
+	}
+
+	for i, diff := range differences {
+		differences[i] = "- " + indent(strings.TrimRight(diff, "\n"))
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  d,
+		Message: fmt.Sprintf("%q is not the same as expected:\n\n%s", root, strings.Join(differences, "\n")),
+	}, msgAndArgs...)
+}
+
+// topLevelPaths removes the paths whose parent is in the list, the list must be sorted.
+func topLevelPaths(paths []string) []string {
+	result := make([]string, 0, len(paths))
+
+	for _, p := range paths {
+		if n := len(result); n > 0 && strings.HasPrefix(p, result[n-1]+string(os.PathSeparator)) {
+			continue
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// describeChange renders the failure messages of a modified path.
+func describeChange(c *config, expected, actual afero.Fs, path string, m FileChange) []string {
+	if m.ModeChanged() {
+		switch {
+		case m.Before.Mode.IsDir():
+			return []string{fmt.Sprintf("%q is not a directory", path)}
+
+		case m.After.Mode.IsDir():
+			return []string{fmt.Sprintf("%q is a directory", path)}
+		}
+
+		return []string{fmt.Sprintf("%q mode is %s, expected %s", path, FormatFileMode(m.After.Mode.Type()), FormatFileMode(m.Before.Mode.Type()))}
+	}
+
+	var result []string
+
+	if m.PermChanged() && !c.ignorePerm && !permMatches(c, actual, m.Before.Mode&permBits, m.After.Mode&permBits) {
+		result = append(result, fmt.Sprintf("%q perm is %s, expected %s", path, formatPerm(m.After.Mode), formatPerm(m.Before.Mode)))
+	}
+
+	if m.ContentChanged() || m.SizeChanged() {
+		if e, err := readFile(c, expected, path); err != nil {
+			result = append(result, fmt.Sprintf("could not read %q: %s", path, err))
+		} else if msg, ok := compareBytes(c, actual, path, e); !ok {
+			result = append(result, msg)
+		}
+	}
+
+	return result
+}
+
+// fsComparison compares a directory of a file system with a directory of another file system.
+type fsComparison struct {
+	t          TestingT
+	c          *config
+	msgAndArgs []interface{}
+
+	expected     afero.Fs
+	expectedRoot string
+	actual       afero.Fs
+	actualRoot   string
+	exhaustive   bool
+	// comparePerm tells whether the permission of an expected path is compared, nil means never.
+	comparePerm func(expectedPath string) bool
+
+	result bool
+}
+
+func (a *fsComparison) run() bool {
+	a.result = true
+	a.expectedRoot = filepath.Clean(a.expectedRoot)
+	a.actualRoot = filepath.Clean(a.actualRoot)
+
+	seen := make(map[string]bool)
+	missing := make([]string, 0)
+
+	err := walk(a.c, a.expected, a.expectedRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(a.expectedRoot, path)
+		if rel == "." {
+			return nil
+		}
+
+		if a.c.ignored(rel) {
+			return skip(info)
+		}
+
+		seen[rel] = true
+		actualPath := filepath.Join(a.actualRoot, rel)
+
+		actualInfo, err := stat(a.c, a.actual, actualPath)
+		if err != nil {
+			missing = append(missing, rel)
+
+			return skip(info)
+		}
+
+		a.compare(path, info, actualPath, actualInfo)
+
+		return nil
+	})
+	if err != nil {
+		return a.fail(FailureInfo{Path: a.expectedRoot, Message: fmt.Sprintf("could not walk through %q: %s", a.expectedRoot, err)})
+	}
+
+	if a.exhaustive {
+		a.reportUnexpected(seen)
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+
+		a.fail(FailureInfo{
+			Kind:     FailureNotFound,
+			Path:     a.actualRoot,
+			Expected: missing,
+			Message: fmt.Sprintf("expected these files in %q but not found:\n%s", a.actualRoot, formatMissingFiles(missing, func(p string) string {
+				return didYouMean(a.actual, filepath.Join(a.actualRoot, p))
+			})),
+		})
+	}
+
+	return a.result
+}
+
+func (a *fsComparison) fail(info FailureInfo) bool {
+	a.result = false
+
+	return a.c.fail(a.t, info, a.msgAndArgs...)
+}
+
+func (a *fsComparison) reportUnexpected(seen map[string]bool) {
+	err := walk(a.c, a.actual, a.actualRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(a.actualRoot, path)
+		if rel == "." || seen[rel] {
+			return nil
+		}
+
+		if a.c.ignored(rel) {
+			return skip(info)
+		}
+
+		a.fail(FailureInfo{Kind: FailureUnexpected, Path: path, Message: fmt.Sprintf("unexpected file %q", path)})
+
+		return skip(info)
+	})
+	if err != nil {
+		a.fail(FailureInfo{Path: a.actualRoot, Message: fmt.Sprintf("could not walk through %q: %s", a.actualRoot, err)})
+	}
+}
+
+func (a *fsComparison) compare(expectedPath string, expected os.FileInfo, actualPath string, actual os.FileInfo) {
+	if expected.IsDir() != actual.IsDir() {
+		if expected.IsDir() {
+			a.fail(FailureInfo{Kind: FailureType, Path: actualPath, Message: fmt.Sprintf("%q is not a directory", actualPath)})
+		} else {
+			a.fail(FailureInfo{Kind: FailureType, Path: actualPath, Message: fmt.Sprintf("%q is a directory", actualPath)})
+		}
+
+		return
+	}
+
+	if expectedType, actualType := expected.Mode().Type(), actual.Mode().Type(); expectedType != actualType {
+		a.fail(FailureInfo{
+			Kind:     FailureType,
+			Path:     actualPath,
+			Expected: expectedType,
+			Actual:   actualType,
+			Message:  fmt.Sprintf("%q mode is %s, expected %s", actualPath, FormatFileMode(actualType), FormatFileMode(expectedType)),
+		})
+
+		return
+	}
+
+	if a.comparePerm != nil && a.comparePerm(expectedPath) && !a.c.ignorePerm {
+		expectedPerm := expected.Mode() & permBits
+		actualPerm := actual.Mode() & permBits
+
+		if !permMatches(a.c, a.actual, expectedPerm, actualPerm) {
+			a.fail(FailureInfo{
+				Kind:     FailurePerm,
+				Path:     actualPath,
+				Expected: expectedPerm,
+				Actual:   actualPerm,
+				Message:  fmt.Sprintf("%q perm is %s, expected %s", actualPath, formatPerm(actualPerm), formatPerm(expectedPerm)),
+			})
+		}
+	}
+
+	if !expected.Mode().IsRegular() {
+		return
+	}
+
+	e, err := readFile(a.c, a.expected, expectedPath)
+	if err != nil {
+		a.fail(FailureInfo{Path: expectedPath, Message: fmt.Sprintf("could not read %q: %s", expectedPath, err)})
+
+		return
+	}
+
+	if msg, ok := compareBytes(a.c, a.actual, actualPath, e); !ok {
+		a.fail(FailureInfo{Kind: FailureContent, Path: actualPath, Expected: string(e), Message: msg})
+	}
+}
+
+// compareBytes compares the file content with the expected bytes, byte by byte, and returns the failure message if
+// they are not equal. The message contains a diff if both are text.
+func compareBytes(c *config, fs afero.Fs, path string, expected []byte) (string, bool) {
+	actual, err := readFile(c, fs, path)
+	if err != nil {
+		return fmt.Sprintf("could not read %q: %s", path, err), false
+	}
+
+	if bytes.Equal(expected, actual) {
+		return "", true
+	}
+
+	if !utf8.Valid(expected) || !utf8.Valid(actual) {
+		return fmt.Sprintf("%q content is not as expected, %d bytes expected, %d bytes found", path, len(expected), len(actual)), false
+	}
+
+	return fmt.Sprintf("%q content is not as expected:\n\n%s", path, c.diff(string(expected), string(actual))), false
+}
+
+// indent indents the lines, except the first one, by two spaces.
+func indent(s string) string {
+	lines := strings.Split(s, "\n")
+
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = "  " + lines[i]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// relPath returns the path relative to the root, or "." if it is the root.
+func relPath(root, path string) string {
+	if path == root {
+		return "."
+	}
+
+	if root == "." {
+		return path
+	}
+
+	return strings.TrimPrefix(path, root+string(os.PathSeparator))
+}
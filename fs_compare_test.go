@@ -0,0 +1,91 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func newSourceFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/folder 2", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("line 1\nline 2\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/folder 2/file 2", []byte("file 2"), 0o600))
+
+	return fs
+}
+
+func TestFsEqual_Success(t *testing.T) {
+	t.Parallel()
+
+	expected := newSourceFs(t)
+	actual := afero.NewMemMapFs()
+
+	require.NoError(t, afero.Walk(expected, "root", func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+
+		if info.IsDir() {
+			return actual.MkdirAll(path, info.Mode().Perm())
+		}
+
+		data, err := afero.ReadFile(expected, path)
+		require.NoError(t, err)
+
+		return afero.WriteFile(actual, path, data, info.Mode().Perm())
+	}))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FsEqual(mockT, expected, actual, "root"), mockT.message())
+}
+
+func TestFsEqual_Failure(t *testing.T) {
+	t.Parallel()
+
+	expected := newSourceFs(t)
+	actual := afero.NewMemMapFs()
+
+	require.NoError(t, actual.MkdirAll("root/folder 2", 0o755))
+	require.NoError(t, afero.WriteFile(actual, "root/file 1", []byte("line 1\nline 3\n"), 0o644))
+	require.NoError(t, afero.WriteFile(actual, "root/file 3", nil, 0o644))
+	require.NoError(t, afero.WriteFile(actual, "root/folder 2/file 2", []byte("file 2"), 0o644))
+
+	var failures []aferoassert.FailureInfo
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.FsEqual(mockT, expected, actual, "root", aferoassert.WithDiffContext(0), aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+		failures = append(failures, info)
+	})))
+	require.Len(t, mockT.errors, 1)
+	require.Len(t, failures, 1)
+
+	expectedMessage := `"root" is not the same as expected:
+
+- "root/file 1" content is not as expected:
+
+  --- Expected
+  +++ Actual
+  @@ -2 +2 @@
+  -line 2
+  +line 3
+- "root/folder 2/file 2" perm is 0644, expected 0600
+- unexpected file "root/file 3"`
+
+	assert.Equal(t, expectedMessage, failures[0].Message)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FsEqual(mockT, expected, actual, "root", aferoassert.IgnorePerm(), aferoassert.IgnorePaths("file 1")))
+	require.Len(t, mockT.errors, 1)
+	assert.NotContains(t, mockT.errors[0], "perm is")
+	assert.Contains(t, mockT.errors[0], `- unexpected file "root/file 3"`)
+}
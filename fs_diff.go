@@ -0,0 +1,337 @@
+package aferoassert
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// FileState is the state of a file or a directory that is compared by DiffFs.
+type FileState struct {
+	Mode os.FileMode
+	Size int64
+	// Hash is the hex-encoded SHA-256 of the content of a regular file, or of the target of a symlink.
+	Hash string
+}
+
+// FileChange is a path that exists in both file systems but with different states.
+type FileChange struct {
+	Path   string
+	Before FileState
+	After  FileState
+}
+
+// ModeChanged tells whether the type of the file changed, for example, a file became a symlink.
+func (c FileChange) ModeChanged() bool {
+	return c.Before.Mode.Type() != c.After.Mode.Type()
+}
+
+// PermChanged tells whether the permission changed, including the setuid, setgid and sticky bits.
+func (c FileChange) PermChanged() bool {
+	return c.Before.Mode&permBits != c.After.Mode&permBits
+}
+
+// SizeChanged tells whether the size changed.
+func (c FileChange) SizeChanged() bool {
+	return c.Before.Size != c.After.Size
+}
+
+// ContentChanged tells whether the content changed.
+func (c FileChange) ContentChanged() bool {
+	return c.Before.Hash != c.After.Hash
+}
+
+// String describes what changed, for example "size 5 -> 6, content".
+func (c FileChange) String() string {
+	changes := make([]string, 0, 4)
+
+	if c.ModeChanged() {
+		changes = append(changes, fmt.Sprintf("mode %s -> %s", c.Before.Mode.Type(), c.After.Mode.Type()))
+	}
+
+	if c.PermChanged() {
+		changes = append(changes, fmt.Sprintf("perm %s -> %s", formatPerm(c.Before.Mode), formatPerm(c.After.Mode)))
+	}
+
+	if c.SizeChanged() {
+		changes = append(changes, fmt.Sprintf("size %d -> %d", c.Before.Size, c.After.Size))
+	}
+
+	if c.ContentChanged() {
+		changes = append(changes, "content")
+	}
+
+	return strings.Join(changes, ", ")
+}
+
+// FsDiff is the difference between two directories, the paths are relative to the root and sorted.
+type FsDiff struct {
+	// Added contains the paths that exist only in the second file system.
+	Added []string
+	// Removed contains the paths that exist only in the first file system.
+	Removed []string
+	// Modified contains the paths that exist in both file systems with different states.
+	Modified []FileChange
+}
+
+// Empty tells whether there is no difference.
+func (d FsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// String renders the difference, one path per line, prefixed with "+" when added, "-" when removed and "~" when
+// modified.
+func (d FsDiff) String() string {
+	var sb strings.Builder
+
+	for _, p := range d.Added {
+		_, _ = fmt.Fprintf(&sb, "+ %s\n", p)
+	}
+
+	for _, p := range d.Removed {
+		_, _ = fmt.Fprintf(&sb, "- %s\n", p)
+	}
+
+	for _, c := range d.Modified {
+		_, _ = fmt.Fprintf(&sb, "~ %s (%s)\n", c.Path, c)
+	}
+
+	return sb.String()
+}
+
+// DiffFs compares a directory in two file systems and returns what was added, removed and modified in the second one.
+// The modification times are not compared. The IgnorePaths, IgnorePerm and FollowLinks options are supported.
+func DiffFs(fsA, fsB afero.Fs, root string, opts ...Option) (FsDiff, error) {
+	return diffFs(configFromOptions(opts), fsA, fsB, root)
+}
+
+func diffFs(c *config, fsA, fsB afero.Fs, root string) (FsDiff, error) {
+	before, err := fingerprint(c, fsA, root)
+	if err != nil {
+		return FsDiff{}, err
+	}
+
+	after, err := fingerprint(c, fsB, root)
+	if err != nil {
+		return FsDiff{}, err
+	}
+
+	return diffStates(c, before, after), nil
+}
+
+func diffStates(c *config, before, after Snapshot) FsDiff {
+	var d FsDiff
+
+	for p, b := range before {
+		a, ok := after[p]
+		if !ok {
+			d.Removed = append(d.Removed, p)
+
+			continue
+		}
+
+		change := FileChange{Path: p, Before: b, After: a}
+
+		if change.ModeChanged() || change.SizeChanged() || change.ContentChanged() || (change.PermChanged() && !c.ignorePerm) {
+			d.Modified = append(d.Modified, change)
+		}
+	}
+
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			d.Added = append(d.Added, p)
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Slice(d.Modified, func(i, j int) bool {
+		return d.Modified[i].Path < d.Modified[j].Path
+	})
+
+	return d
+}
+
+// Snapshot contains the states of all the paths under a directory, the keys are relative to the directory.
+type Snapshot map[string]FileState
+
+// Fingerprint captures the paths, the sizes, the modes and the content hashes of a directory, so AssertUnchanged can
+// prove later that the directory was not modified. The IgnorePaths and FollowLinks options are supported.
+func Fingerprint(fs afero.Fs, root string, opts ...Option) (Snapshot, error) {
+	return fingerprint(configFromOptions(opts), fs, root)
+}
+
+// AssertUnchanged checks whether a directory is the same as when the snapshot was taken, see Fingerprint.
+func AssertUnchanged(t TestingT, fs afero.Fs, root string, snapshot Snapshot, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertUnchanged", func(t TestingT) bool {
+		return assertUnchanged(t, c, fs, root, snapshot, msgAndArgs...)
+	})
+}
+
+func assertUnchanged(t TestingT, c *config, fs afero.Fs, root string, snapshot Snapshot, msgAndArgs ...interface{}) bool {
+	current, err := fingerprint(c, fs, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not fingerprint %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if d := diffStates(c, snapshot, current); !d.Empty() {
+		return c.fail(t, FailureInfo{
+			Path:    root,
+			Actual:  d,
+			Message: fmt.Sprintf("%q has been modified:\n\n%s", root, d),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// AssertOnlyChanged checks whether only the allowed paths of a directory were created, removed or modified since the
+// snapshot was taken, see Fingerprint. The allowed paths are relative to the root and follow filepath.Match, an allowed
+// directory allows all of its children. The options have to be the same as the ones of the snapshot, so the current
+// fingerprint is taken the same way, for example:
+//
+//	snapshot, _ := aferoassert.Fingerprint(fs, "root", aferoassert.IgnorePaths("tmp"))
+//
+//	aferoassert.AssertOnlyChanged(t, fs, "root", snapshot, []string{"cache"}, aferoassert.IgnorePaths("tmp"))
+func AssertOnlyChanged(t TestingT, fs afero.Fs, root string, snapshot Snapshot, allowed []string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertOnlyChanged", func(t TestingT) bool {
+		return assertOnlyChanged(t, c, fs, root, snapshot, allowed, msgAndArgs...)
+	})
+}
+
+func assertOnlyChanged(t TestingT, c *config, fs afero.Fs, root string, snapshot Snapshot, allowed []string, msgAndArgs ...interface{}) bool {
+	current, err := fingerprint(c, fs, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not fingerprint %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	// The allowed paths are matched the same way as the ignored ones.
+	allow := &config{ignorePatterns: allowed}
+	d := diffStates(c, snapshot, current)
+
+	var unexpected FsDiff
+
+	for _, p := range d.Added {
+		if !allow.ignored(p) {
+			unexpected.Added = append(unexpected.Added, p)
+		}
+	}
+
+	for _, p := range d.Removed {
+		if !allow.ignored(p) {
+			unexpected.Removed = append(unexpected.Removed, p)
+		}
+	}
+
+	for _, m := range d.Modified {
+		if !allow.ignored(m.Path) {
+			unexpected.Modified = append(unexpected.Modified, m)
+		}
+	}
+
+	if !unexpected.Empty() {
+		return c.fail(t, FailureInfo{
+			Path:     root,
+			Expected: allowed,
+			Actual:   unexpected,
+			Message:  fmt.Sprintf("%q has unexpected changes:\n\n%s", root, unexpected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// fingerprint captures the states of all the paths under the root.
+func fingerprint(c *config, fs afero.Fs, root string) (Snapshot, error) {
+	root = filepath.Clean(root)
+	result := make(Snapshot)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(root, path)
+		if rel == "." {
+			return nil
+		}
+
+		if c.ignored(rel) {
+			return skip(info)
+		}
+
+		state := FileState{Mode: info.Mode()}
+
+		if !info.IsDir() {
+			state.Size = info.Size()
+
+			if state.Hash, err = hashFile(fs, path, info); err != nil {
+				return err
+			}
+		}
+
+		result[rel] = state
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func hashFile(fs afero.Fs, path string, info os.FileInfo) (string, error) {
+	h := sha256.New()
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		r, ok := fs.(afero.LinkReader)
+		if !ok {
+			return "", nil
+		}
+
+		target, err := r.ReadlinkIfPossible(path)
+		if err != nil {
+			return "", err
+		}
+
+		_, _ = io.WriteString(h, target)
+
+	case info.Mode().IsRegular():
+		f, err := fs.Open(path)
+		if err != nil {
+			return "", err
+		}
+
+		defer f.Close() // nolint: errcheck
+
+		if _, err := io.Copy(h, f); err != nil {
+			return "", err
+		}
+
+	default:
+		return "", nil
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,197 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestDiffFs(t *testing.T) {
+	t.Parallel()
+
+	fsA := newSourceFs(t)
+	fsB := afero.NewMemMapFs()
+
+	require.NoError(t, fsB.MkdirAll("root/folder 3", 0o755))
+	require.NoError(t, afero.WriteFile(fsB, "root/file 1", []byte("line 1\nline 2\n"), 0o600))
+	require.NoError(t, afero.WriteFile(fsB, "root/folder 3/file 3", []byte("file 3"), 0o644))
+
+	d, err := aferoassert.DiffFs(fsA, fsB, "root")
+	require.NoError(t, err)
+
+	assert.False(t, d.Empty())
+	assert.Equal(t, []string{"folder 3", "folder 3/file 3"}, d.Added)
+	assert.Equal(t, []string{"folder 2", "folder 2/file 2"}, d.Removed)
+	require.Len(t, d.Modified, 1)
+
+	m := d.Modified[0]
+
+	assert.Equal(t, "file 1", m.Path)
+	assert.True(t, m.PermChanged())
+	assert.False(t, m.SizeChanged())
+	assert.False(t, m.ContentChanged())
+	assert.False(t, m.ModeChanged())
+
+	expected := `+ folder 3
++ folder 3/file 3
+- folder 2
+- folder 2/file 2
+~ file 1 (perm 0644 -> 0600)
+`
+
+	assert.Equal(t, expected, d.String())
+
+	d, err = aferoassert.DiffFs(fsA, fsB, "root", aferoassert.IgnorePerm(), aferoassert.IgnorePaths("folder *"))
+	require.NoError(t, err)
+
+	assert.True(t, d.Empty())
+
+	require.NoError(t, afero.WriteFile(fsB, "root/file 1", []byte("line 1\n"), 0o644))
+	require.NoError(t, fsB.Chmod("root/file 1", 0o644))
+
+	d, err = aferoassert.DiffFs(fsA, fsB, "root", aferoassert.IgnorePaths("folder *"))
+	require.NoError(t, err)
+
+	require.Len(t, d.Modified, 1)
+	assert.Equal(t, "size 14 -> 7, content", d.Modified[0].String())
+}
+
+func TestDiffFs_SpecialBits(t *testing.T) {
+	t.Parallel()
+
+	fsA := afero.NewMemMapFs()
+	fsB := afero.NewMemMapFs()
+
+	for _, fs := range []afero.Fs{fsA, fsB} {
+		require.NoError(t, fs.MkdirAll("root/shared", 0o755))
+		require.NoError(t, fs.Chmod("root/shared", 0o755))
+	}
+
+	require.NoError(t, fsB.Chmod("root/shared", os.ModeSetgid|0o755))
+
+	d, err := aferoassert.DiffFs(fsA, fsB, "root")
+	require.NoError(t, err)
+
+	require.Len(t, d.Modified, 1)
+	assert.True(t, d.Modified[0].PermChanged())
+	assert.False(t, d.Modified[0].ModeChanged())
+	assert.Equal(t, "~ shared (perm 0755 -> 02755)\n", d.String())
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.FsEqual(mockT, fsA, fsB, "root"))
+	assert.Contains(t, mockT.message(), `"root/shared" perm is 02755, expected 0755`)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.FsEqual(mockT, fsA, fsB, "root", aferoassert.IgnorePerm()), mockT.message())
+}
+
+func TestDiffFs_Error(t *testing.T) {
+	t.Parallel()
+
+	_, err := aferoassert.DiffFs(afero.NewMemMapFs(), newSourceFs(t), "root")
+
+	assert.Error(t, err)
+}
+
+func TestFingerprint_AssertUnchanged(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+
+	snapshot, err := aferoassert.Fingerprint(fs, "root")
+	require.NoError(t, err)
+
+	assert.Len(t, snapshot, 3)
+	assert.Equal(t, int64(6), snapshot["folder 2/file 2"].Size)
+	assert.Equal(t, os.FileMode(0o600), snapshot["folder 2/file 2"].Mode)
+	assert.NotEmpty(t, snapshot["folder 2/file 2"].Hash)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertUnchanged(mockT, fs, "root", snapshot), mockT.message())
+
+	// Same size, different content.
+	require.NoError(t, afero.WriteFile(fs, "root/folder 2/file 2", []byte("file 3"), 0o600))
+	require.NoError(t, afero.WriteFile(fs, "root/file 3", nil, 0o644))
+
+	failures := make([]aferoassert.FailureInfo, 0)
+
+	assert.False(t, aferoassert.AssertUnchanged(mockT, fs, "root", snapshot, aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+		failures = append(failures, info)
+	})))
+	require.Len(t, failures, 1)
+
+	expected := `"root" has been modified:
+
++ file 3
+~ folder 2/file 2 (content)
+`
+
+	assert.Equal(t, expected, failures[0].Message)
+}
+
+func TestAssertUnchanged_Error(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.AssertUnchanged(mockT, afero.NewMemMapFs(), "root", nil))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `could not fingerprint "root"`)
+}
+
+func TestAssertOnlyChanged(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+
+	snapshot, err := aferoassert.Fingerprint(fs, "root")
+	require.NoError(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("changed"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/cache/1.tmp", nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertOnlyChanged(mockT, fs, "root", snapshot, []string{"file 1", "cache"}), mockT.message())
+	assert.True(t, aferoassert.AssertOnlyChanged(mockT, fs, "root", snapshot, []string{"file *", "*.tmp", "cache"}), mockT.message())
+
+	require.NoError(t, fs.Remove("root/folder 2/file 2"))
+
+	assert.False(t, aferoassert.AssertOnlyChanged(mockT, fs, "root", snapshot, []string{"cache"}, "after %s", "build"))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"root" has unexpected changes:`)
+	assert.Contains(t, mockT.errors[0], "after build")
+	assert.Contains(t, mockT.errors[0], "- folder 2/file 2\n")
+	assert.Contains(t, mockT.errors[0], "~ file 1 (size 14 -> 7, content)\n")
+	assert.NotContains(t, mockT.errors[0], "cache")
+}
+
+func TestAssertOnlyChanged_Options(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+
+	require.NoError(t, afero.WriteFile(fs, "root/tmp/1.tmp", nil, 0o644))
+
+	snapshot, err := aferoassert.Fingerprint(fs, "root", aferoassert.IgnorePaths("tmp"))
+	require.NoError(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, "root/cache/1.tmp", nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertOnlyChanged(mockT, fs, "root", snapshot, []string{"cache"}, aferoassert.IgnorePaths("tmp")), mockT.message())
+
+	// Without the options of the snapshot, the ignored paths are added.
+	assert.False(t, aferoassert.AssertOnlyChanged(mockT, fs, "root", snapshot, []string{"cache"}))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "+ tmp\n")
+}
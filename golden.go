@@ -0,0 +1,96 @@
+package aferoassert
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/afero"
+)
+
+// DirGolden checks whether a directory has exactly the files of a reference directory on disk, for example
+// "testdata/expected", with the same content. The permissions are not compared because they are usually not preserved
+// by the version control systems. When the update mode is on, the reference directory is replaced by the actual one
+// instead. The mode is set with WithGoldenUpdate, or else it follows the -update flag, if the test binary defines it:
+//
+//	var update = flag.Bool("update", false, "update the golden files")
+//
+//	go test ./... -update
+func DirGolden(t TestingT, fs afero.Fs, actualRoot, goldenDir string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "DirGolden", func(t TestingT) bool {
+		if !c.goldenUpdate() {
+			return assertDirGolden(t, c, fs, actualRoot, goldenDir, msgAndArgs...)
+		}
+
+		if err := updateGoldenDir(c, fs, actualRoot, goldenDir); err != nil {
+			return c.fail(t, FailureInfo{
+				Path:    goldenDir,
+				Message: fmt.Sprintf("could not update golden directory %q: %s", goldenDir, err),
+			}, msgAndArgs...)
+		}
+
+		return true
+	})
+}
+
+// goldenUpdate tells whether DirGolden updates the golden directories, see WithGoldenUpdate. The -update flag is
+// looked up lazily because a library must not register flags, the test binaries usually define their own.
+func (c *config) goldenUpdate() bool {
+	if c.updateGolden != nil {
+		return *c.updateGolden
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	update, err := strconv.ParseBool(f.Value.String())
+
+	return err == nil && update
+}
+
+func assertDirGolden(t TestingT, c *config, fs afero.Fs, actualRoot, goldenDir string, msgAndArgs ...interface{}) bool {
+	golden := afero.NewOsFs()
+
+	if _, err := golden.Stat(goldenDir); err != nil {
+		return c.fail(t, FailureInfo{
+			Path:    goldenDir,
+			Message: fmt.Sprintf("could not find golden directory %q, run the test with -update to create it: %s", goldenDir, err),
+		}, msgAndArgs...)
+	}
+
+	a := &fsComparison{
+		t:            t,
+		c:            c,
+		msgAndArgs:   msgAndArgs,
+		expected:     golden,
+		expectedRoot: goldenDir,
+		actual:       fs,
+		actualRoot:   actualRoot,
+		exhaustive:   true,
+	}
+
+	return a.run()
+}
+
+func updateGoldenDir(c *config, fs afero.Fs, actualRoot, goldenDir string) error {
+	golden := afero.NewOsFs()
+
+	if err := golden.RemoveAll(goldenDir); err != nil {
+		return err
+	}
+
+	if err := golden.MkdirAll(filepath.Dir(filepath.Clean(goldenDir)), 0o755); err != nil {
+		return err
+	}
+
+	return copyDir(c, fs, actualRoot, golden, goldenDir)
+}
@@ -0,0 +1,91 @@
+package aferoassert_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// update is the flag of the golden tests, it is defined by the test binary, not by aferoassert.
+var update = flag.Bool("update", false, "update the golden directories")
+
+func TestDirGolden(t *testing.T) { // nolint: paralleltest
+	goldenDir := filepath.Join(t.TempDir(), "testdata", "expected")
+	fs := newSourceFs(t)
+
+	// The golden directory does not exist.
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.DirGolden(mockT, fs, "root", goldenDir))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "run the test with -update to create it")
+
+	// Update.
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.DirGolden(mockT, fs, "root", goldenDir, aferoassert.WithGoldenUpdate(true)), mockT.message())
+
+	content, err := os.ReadFile(filepath.Join(goldenDir, "folder 2", "file 2"))
+	require.NoError(t, err)
+	assert.Equal(t, "file 2", string(content))
+
+	// Compare.
+	assert.True(t, aferoassert.DirGolden(mockT, fs, "root", goldenDir), mockT.message())
+
+	require.NoError(t, afero.WriteFile(fs, "root/folder 2/file 2", []byte("changed"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file 3", nil, 0o644))
+
+	assert.False(t, aferoassert.DirGolden(mockT, fs, "root", goldenDir))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `"root/folder 2/file 2" content is not as expected`)
+	assert.Contains(t, mockT.errors[1], `unexpected file "root/file 3"`)
+}
+
+func TestDirGolden_UpdateFlag(t *testing.T) { // nolint: paralleltest
+	goldenDir := filepath.Join(t.TempDir(), "expected")
+	fs := newSourceFs(t)
+
+	require.NoError(t, flag.Set("update", "true"))
+
+	t.Cleanup(func() {
+		*update = false
+	})
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.DirGolden(mockT, fs, "root", goldenDir), mockT.message())
+
+	content, err := os.ReadFile(filepath.Join(goldenDir, "folder 2", "file 2"))
+	require.NoError(t, err)
+	assert.Equal(t, "file 2", string(content))
+
+	// The option overrides the flag.
+	require.NoError(t, afero.WriteFile(fs, "root/file 3", nil, 0o644))
+
+	assert.False(t, aferoassert.DirGolden(mockT, fs, "root", goldenDir, aferoassert.WithGoldenUpdate(false)))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `unexpected file "root/file 3"`)
+}
+
+func TestDirGolden_UpdateFailNow(t *testing.T) {
+	t.Parallel()
+
+	// The golden directory cannot be created under a file.
+	goldenDir := filepath.Join(t.TempDir(), "file", "expected")
+
+	require.NoError(t, os.WriteFile(filepath.Dir(goldenDir), nil, 0o644))
+
+	mockT := &failNowT{}
+
+	assert.False(t, aferoassert.DirGolden(mockT, newSourceFs(t), "root", goldenDir, aferoassert.WithGoldenUpdate(true), aferoassert.WithFailNow()))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "could not update golden directory")
+	assert.True(t, mockT.failedNow)
+}
@@ -0,0 +1,63 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// NoHardlinks checks whether none of the regular files of a directory has more than one link, so the directory can be
+// archived by the tools that don't preserve the hard links. The number of links is read from Sys(), the files of the
+// file systems that don't expose it, such as afero.MemMapFs, are not checked.
+func NoHardlinks(t TestingT, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NoHardlinks", func(t TestingT) bool {
+		return assertNoHardlinks(t, c, fs, root, msgAndArgs...)
+	})
+}
+
+func assertNoHardlinks(t TestingT, c *config, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+	linked := make([]string, 0)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if rel := relPath(root, path); rel != "." && c.ignored(rel) {
+			return skip(info)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		if n, ok := fileLinks(info); ok && n > 1 {
+			linked = append(linked, fmt.Sprintf("%q has %d links", path, n))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not walk through %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if len(linked) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  linked,
+		Message: fmt.Sprintf("%q contains hard links:\n- %s", root, strings.Join(linked, "\n- ")),
+	}, msgAndArgs...)
+}
@@ -0,0 +1,13 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package aferoassert
+
+import (
+	"os"
+)
+
+// fileLinks returns the number of hard links of the file.
+func fileLinks(os.FileInfo) (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,20 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestNoHardlinks_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.NoHardlinks(mockT, newSourceFs(t), "root"), mockT.message())
+
+	assert.False(t, aferoassert.NoHardlinks(mockT, newSourceFs(t), "unknown"))
+	assert.Contains(t, mockT.message(), `could not walk through "unknown"`)
+}
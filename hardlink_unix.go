@@ -0,0 +1,19 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package aferoassert
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileLinks returns the number of hard links of the file.
+func fileLinks(info os.FileInfo) (uint64, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+
+	return uint64(st.Nlink), true // nolint: unconvert
+}
@@ -0,0 +1,41 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package aferoassert_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestNoHardlinks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(root, "dir"), 0o755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(root, "file"), nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.NoHardlinks(mockT, fs, root), mockT.message())
+
+	require.NoError(t, os.Link(filepath.Join(root, "file"), filepath.Join(root, "dir", "link")))
+
+	assert.False(t, aferoassert.NoHardlinks(mockT, fs, root))
+	assert.Contains(t, mockT.message(), "contains hard links:")
+	assert.Contains(t, mockT.message(), `/dir/link" has 2 links`)
+	assert.Contains(t, mockT.message(), `/file" has 2 links`)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.NoHardlinks(mockT, fs, root, aferoassert.IgnorePaths("dir", "file")), mockT.message())
+}
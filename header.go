@@ -0,0 +1,78 @@
+package aferoassert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+)
+
+// FileHeader checks whether a file starts with the expected bytes, for example, the magic bytes of a format:
+//
+//	aferoassert.FileHeader(t, fs, "logo.png", []byte{0x89, 'P', 'N', 'G'})
+func FileHeader(t TestingT, fs afero.Fs, path string, expected []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileHeader", func(t TestingT) bool {
+		return assertFileHeader(t, c, fs, path, 0, expected, msgAndArgs...)
+	})
+}
+
+// FileHeaderAt checks whether a file has the expected bytes at the offset, for example, the signature of a format that
+// is not at the beginning of the file.
+func FileHeaderAt(t TestingT, fs afero.Fs, path string, offset int64, expected []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileHeaderAt", func(t TestingT) bool {
+		return assertFileHeader(t, c, fs, path, offset, expected, msgAndArgs...)
+	})
+}
+
+func assertFileHeader(t TestingT, c *config, fs afero.Fs, path string, offset int64, expected []byte, msgAndArgs ...interface{}) bool {
+	actual, ok := readFileHeader(t, c, fs, path, offset, len(expected), msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	if !bytes.Equal(expected, actual) {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q has [% x] at offset %d, expected [% x]", path, actual, offset, expected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// readFileHeader reads at most n bytes of a file from the offset, it returns less bytes if the file is shorter.
+func readFileHeader(t TestingT, c *config, fs afero.Fs, path string, offset int64, n int, msgAndArgs ...interface{}) ([]byte, bool) {
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return nil, false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	header := make([]byte, n)
+
+	read, err := f.ReadAt(header, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	c.bytesRead += int64(read)
+
+	return header[:read], true
+}
@@ -0,0 +1,38 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileHeader(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "logo.png", []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}, 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileHeader(mockT, fs, "logo.png", []byte{0x89, 'P', 'N', 'G'}), mockT.message())
+	assert.True(t, aferoassert.FileHeader(mockT, fs, "logo.png", nil), mockT.message())
+	assert.True(t, aferoassert.FileHeaderAt(mockT, fs, "logo.png", 1, []byte("PNG")), mockT.message())
+
+	assert.False(t, aferoassert.FileHeader(mockT, fs, "logo.png", []byte("GIF8")))
+	assert.Contains(t, mockT.message(), `"logo.png" has [89 50 4e 47] at offset 0, expected [47 49 46 38]`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileHeaderAt(mockT, fs, "logo.png", 6, []byte{0x1a, '\n', 0x00}))
+	assert.Contains(t, mockT.message(), `"logo.png" has [1a 0a] at offset 6, expected [1a 0a 00]`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileHeader(mockT, fs, "unknown.png", []byte("PNG")))
+	assert.Contains(t, mockT.message(), `unable to find file "unknown.png"`)
+}
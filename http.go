@@ -0,0 +1,165 @@
+package aferoassert
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// FromHTTPFileSystem adapts an http.FileSystem, such as http.Dir or afero.HttpFs.Dir, to a read-only afero.Fs so the
+// assertions can check what a static file handler actually exposes, for example, that the dotfiles are hidden:
+//
+//	fs := aferoassert.FromHTTPFileSystem(handlerFs)
+//
+//	aferoassert.FileContent(t, fs, "index.html", "<html></html>")
+//	aferoassert.NoExists(t, fs, ".env")
+//
+// The paths are relative to the root of the http.FileSystem. All the mutations fail with syscall.EPERM.
+func FromHTTPFileSystem(hfs http.FileSystem) afero.Fs {
+	return httpFs{fs: hfs}
+}
+
+type httpFs struct {
+	fs http.FileSystem
+}
+
+// httpName converts a path to the slash-separated and rooted name required by http.FileSystem.
+func httpName(name string) string {
+	return path.Clean("/" + filepath.ToSlash(name))
+}
+
+func (f httpFs) Open(name string) (afero.File, error) {
+	file, err := f.fs.Open(httpName(name))
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &httpFile{File: file, name: name}, nil
+}
+
+func (f httpFs) OpenFile(name string, flag int, _ os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		return nil, &os.PathError{Op: "open", Path: name, Err: syscall.EPERM}
+	}
+
+	return f.Open(name)
+}
+
+func (f httpFs) Stat(name string) (os.FileInfo, error) {
+	file, err := f.Open(name)
+	if err != nil {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: unwrapPathError(err)}
+	}
+
+	defer file.Close() //nolint: errcheck
+
+	return file.Stat()
+}
+
+func (f httpFs) Name() string {
+	return "HTTPFileSystem"
+}
+
+func (f httpFs) Create(name string) (afero.File, error) {
+	return nil, &os.PathError{Op: "create", Path: name, Err: syscall.EPERM}
+}
+
+func (f httpFs) Mkdir(name string, _ os.FileMode) error {
+	return &os.PathError{Op: "mkdir", Path: name, Err: syscall.EPERM}
+}
+
+func (f httpFs) MkdirAll(path string, _ os.FileMode) error {
+	return &os.PathError{Op: "mkdir", Path: path, Err: syscall.EPERM}
+}
+
+func (f httpFs) Remove(name string) error {
+	return &os.PathError{Op: "remove", Path: name, Err: syscall.EPERM}
+}
+
+func (f httpFs) RemoveAll(path string) error {
+	return &os.PathError{Op: "remove", Path: path, Err: syscall.EPERM}
+}
+
+func (f httpFs) Rename(oldname, _ string) error {
+	return &os.PathError{Op: "rename", Path: oldname, Err: syscall.EPERM}
+}
+
+func (f httpFs) Chmod(name string, _ os.FileMode) error {
+	return &os.PathError{Op: "chmod", Path: name, Err: syscall.EPERM}
+}
+
+func (f httpFs) Chown(name string, _, _ int) error {
+	return &os.PathError{Op: "chown", Path: name, Err: syscall.EPERM}
+}
+
+func (f httpFs) Chtimes(name string, _, _ time.Time) error {
+	return &os.PathError{Op: "chtimes", Path: name, Err: syscall.EPERM}
+}
+
+func unwrapPathError(err error) error {
+	if pe, ok := err.(*os.PathError); ok { //nolint: errorlint
+		return pe.Err
+	}
+
+	return err
+}
+
+// httpFile adapts an http.File to a read-only afero.File.
+type httpFile struct {
+	http.File
+
+	name string
+}
+
+func (f *httpFile) Name() string {
+	return f.name
+}
+
+func (f *httpFile) ReadAt(p []byte, off int64) (int, error) {
+	if r, ok := f.File.(io.ReaderAt); ok {
+		return r.ReadAt(p, off)
+	}
+
+	if _, err := f.File.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return io.ReadFull(f.File, p)
+}
+
+func (f *httpFile) Readdirnames(n int) ([]string, error) {
+	infos, err := f.File.Readdir(n)
+	names := make([]string, len(infos))
+
+	for i, info := range infos {
+		names[i] = info.Name()
+	}
+
+	return names, err
+}
+
+func (f *httpFile) Sync() error {
+	return nil
+}
+
+func (f *httpFile) Truncate(int64) error {
+	return &os.PathError{Op: "truncate", Path: f.name, Err: syscall.EPERM}
+}
+
+func (f *httpFile) Write([]byte) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: f.name, Err: syscall.EPERM}
+}
+
+func (f *httpFile) WriteAt([]byte, int64) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: f.name, Err: syscall.EPERM}
+}
+
+func (f *httpFile) WriteString(string) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: f.name, Err: syscall.EPERM}
+}
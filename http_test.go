@@ -0,0 +1,87 @@
+package aferoassert_test
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// dotFileHidingFileSystem hides the dotfiles, like the example of http.FileServer.
+type dotFileHidingFileSystem struct {
+	http.FileSystem
+}
+
+func (fsys dotFileHidingFileSystem) Open(name string) (http.File, error) {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return nil, fs.ErrPermission
+		}
+	}
+
+	file, err := fsys.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return dotFileHidingFile{file}, nil
+}
+
+type dotFileHidingFile struct {
+	http.File
+}
+
+func (f dotFileHidingFile) Readdir(n int) ([]fs.FileInfo, error) {
+	files, err := f.File.Readdir(n)
+	result := make([]fs.FileInfo, 0, len(files))
+
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), ".") {
+			result = append(result, file)
+		}
+	}
+
+	return result, err
+}
+
+func TestFromHTTPFileSystem(t *testing.T) {
+	t.Parallel()
+
+	source := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(source, "public/index.html", []byte("<html></html>"), 0o644))
+	require.NoError(t, afero.WriteFile(source, "public/.env", []byte("SECRET=1"), 0o600))
+	require.NoError(t, afero.WriteFile(source, "public/assets/app.js", []byte("app()"), 0o644))
+
+	fs := aferoassert.FromHTTPFileSystem(dotFileHidingFileSystem{afero.NewHttpFs(source).Dir("public")})
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Exists(mockT, fs, "index.html"), mockT.message())
+	assert.True(t, aferoassert.FileContent(mockT, fs, "assets/app.js", "app()"), mockT.message())
+	assert.True(t, aferoassert.NoExists(mockT, fs, ".env"), mockT.message())
+	assert.True(t, aferoassert.YAMLTreeContains(mockT, fs, "- index.html\n- assets:\n    - app.js", "."), mockT.message())
+
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, "- .env", "."))
+	assert.NotEmpty(t, mockT.errors)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.AssertFsReadOnly(mockT, fs), mockT.message())
+}
+
+func TestFromHTTPFileSystem_Dir(t *testing.T) {
+	t.Parallel()
+
+	fs := aferoassert.FromHTTPFileSystem(http.Dir("testdata/templates"))
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileExists(mockT, fs, "config.yaml"), mockT.message())
+	assert.True(t, aferoassert.DirExists(mockT, fs, "nested"), mockT.message())
+	assert.True(t, aferoassert.NoExists(mockT, fs, "unknown"), mockT.message())
+}
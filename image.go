@@ -0,0 +1,51 @@
+package aferoassert
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // Register the GIF decoder for ImageDimensions.
+	_ "image/jpeg" // Register the JPEG decoder for ImageDimensions.
+	_ "image/png"  // Register the PNG decoder for ImageDimensions.
+
+	"github.com/spf13/afero"
+)
+
+// ImageDimensions checks whether an image has the expected width and height, only the header of the image is decoded.
+// The PNG, JPEG and GIF formats are supported, the other formats can be supported by registering their decoders with
+// image.RegisterFormat.
+func ImageDimensions(t TestingT, fs afero.Fs, path string, width, height int, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "ImageDimensions", func(t TestingT) bool {
+		return assertImageDimensions(t, c, fs, path, width, height, msgAndArgs...)
+	})
+}
+
+func assertImageDimensions(t TestingT, c *config, fs afero.Fs, path string, width, height int, msgAndArgs ...interface{}) bool {
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	cfg, format, err := image.DecodeConfig(f)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not decode image %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	if cfg.Width != width || cfg.Height != height {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: fmt.Sprintf("%dx%d", width, height),
+			Actual:   fmt.Sprintf("%dx%d", cfg.Width, cfg.Height),
+			Message:  fmt.Sprintf("%q is %dx%d (%s), expected %dx%d", path, cfg.Width, cfg.Height, format, width, height),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
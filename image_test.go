@@ -0,0 +1,57 @@
+package aferoassert_test
+
+import (
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestImageDimensions(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	img := image.NewRGBA(image.Rect(0, 0, 16, 9))
+
+	encoders := map[string]func(w io.Writer) error{
+		"thumb.png": func(w io.Writer) error { return png.Encode(w, img) },
+		"thumb.jpg": func(w io.Writer) error { return jpeg.Encode(w, img, nil) },
+		"thumb.gif": func(w io.Writer) error { return gif.Encode(w, img, nil) },
+	}
+
+	for name, encode := range encoders {
+		f, err := fs.Create(name)
+		require.NoError(t, err)
+		require.NoError(t, encode(f))
+		require.NoError(t, f.Close())
+
+		mockT := &testingT{}
+
+		assert.True(t, aferoassert.ImageDimensions(mockT, fs, name, 16, 9), mockT.message())
+	}
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.ImageDimensions(mockT, fs, "thumb.png", 9, 16))
+	assert.Contains(t, mockT.message(), `"thumb.png" is 16x9 (png), expected 9x16`)
+
+	mockT = &testingT{}
+
+	require.NoError(t, afero.WriteFile(fs, "thumb.txt", []byte("not an image"), 0o644))
+
+	assert.False(t, aferoassert.ImageDimensions(mockT, fs, "thumb.txt", 16, 9))
+	assert.Contains(t, mockT.message(), `could not decode image "thumb.txt": image: unknown format`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.ImageDimensions(mockT, fs, "unknown.png", 16, 9))
+	assert.Contains(t, mockT.message(), `unable to find file "unknown.png"`)
+}
@@ -0,0 +1,35 @@
+package aferoassert
+
+import (
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// FromIOFS adapts a standard io/fs.FS, such as embed.FS or fstest.MapFS, to a read-only afero.Fs so it can be used with
+// all the assertions, for example:
+//
+//	aferoassert.YAMLTreeEqual(t, aferoassert.FromIOFS(templates), tree, ".")
+//
+// The paths are converted to slash-separated paths, as required by io/fs.
+func FromIOFS(fsys iofs.FS) afero.Fs {
+	return ioFS{FromIOFS: afero.FromIOFS{FS: fsys}}
+}
+
+type ioFS struct {
+	afero.FromIOFS
+}
+
+func (f ioFS) Open(name string) (afero.File, error) {
+	return f.FromIOFS.Open(filepath.ToSlash(name))
+}
+
+func (f ioFS) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	return f.FromIOFS.OpenFile(filepath.ToSlash(name), flag, perm)
+}
+
+func (f ioFS) Stat(name string) (os.FileInfo, error) {
+	return f.FromIOFS.Stat(filepath.ToSlash(name))
+}
@@ -0,0 +1,36 @@
+package aferoassert_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFromIOFS(t *testing.T) {
+	t.Parallel()
+
+	fs := aferoassert.FromIOFS(fstest.MapFS{
+		"root/file 1":             {Data: []byte("hello"), Mode: 0o644},
+		"root/folder 2/data.json": {Data: []byte(`{"a": 1}`), Mode: 0o600},
+	})
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.DirExists(mockT, fs, "root"))
+	assert.True(t, aferoassert.FileExists(mockT, fs, "root/file 1"))
+	assert.True(t, aferoassert.FileContent(mockT, fs, "root/file 1", "hello"))
+	assert.True(t, aferoassert.FileSemanticEqual(mockT, fs, "root/folder 2/data.json", `{"a":1}`))
+	assert.True(t, aferoassert.Perm(mockT, fs, "root/folder 2/data.json", 0o600))
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, `
+- file 1 'perm:"0644"'
+- folder 2:
+    - data.json 'content:"{\"a\":1}"'
+`, "root"))
+	assert.Empty(t, mockT.errors, mockT.message())
+
+	assert.False(t, aferoassert.FileExists(mockT, fs, "root/file 3"))
+	assert.Len(t, mockT.errors, 1)
+}
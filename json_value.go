@@ -0,0 +1,196 @@
+package aferoassert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// ErrInvalidJSONPath indicates that a JSON path query could not be parsed.
+var ErrInvalidJSONPath = errors.New("invalid json path")
+
+// JSONFileValue checks whether the value at a JSON path of a JSON file is equal to the expectation, so a field of a
+// large document can be checked without comparing the whole document, for example:
+//
+//	aferoassert.JSONFileValue(t, fs, "deployment.json", "$.spec.replicas", 3)
+//	aferoassert.JSONFileValue(t, fs, "deployment.json", "spec.containers[0].name", "app")
+//
+// The query supports the keys, ".name" or ["name"], and the array indexes, [0]. The leading "$" is optional. The
+// expectation is compared as JSON, so 3 is equal to 3.0 and a struct is equal to an object with the same fields.
+func JSONFileValue(t TestingT, fs afero.Fs, path, query string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "JSONFileValue", func(t TestingT) bool {
+		return assertJSONFileValue(t, c, fs, path, query, expected, msgAndArgs...)
+	})
+}
+
+func assertJSONFileValue(t TestingT, c *config, fs afero.Fs, path, query string, expected interface{}, msgAndArgs ...interface{}) bool {
+	steps, err := parseJSONPath(query)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: err.Error()}, msgAndArgs...)
+	}
+
+	e, err := toJSONValue(expected)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not encode expected value: %s", err)}, msgAndArgs...)
+	}
+
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	var doc interface{}
+
+	if err := json.NewDecoder(f).Decode(&doc); err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not decode %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	actual, err := lookupJSONPath(doc, steps)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Expected: expected, Message: fmt.Sprintf("%q has no value at %s: %s", path, query, err)}, msgAndArgs...)
+	}
+
+	if !assert.ObjectsAreEqual(e, actual) {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q value at %s is %s, expected %s", path, query, jsonString(actual), jsonString(e)),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// parseJSONPath splits the query into the object keys, strings, and the array indexes, ints.
+func parseJSONPath(query string) ([]interface{}, error) {
+	q := strings.TrimPrefix(query, "$")
+
+	if q != query && q != "" && q[0] != '.' && q[0] != '[' {
+		return nil, fmt.Errorf("%w %q: unexpected %q after $", ErrInvalidJSONPath, query, q[0])
+	}
+
+	if q != "" && q[0] != '.' && q[0] != '[' {
+		q = "." + q
+	}
+
+	var steps []interface{}
+
+	for q != "" {
+		switch q[0] {
+		case '.':
+			end := strings.IndexAny(q[1:], ".[") + 1
+			if end == 0 {
+				end = len(q)
+			}
+
+			if end == 1 {
+				return nil, fmt.Errorf("%w %q: empty key", ErrInvalidJSONPath, query)
+			}
+
+			steps = append(steps, q[1:end])
+			q = q[end:]
+
+		case '[':
+			end := strings.IndexByte(q, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w %q: missing ]", ErrInvalidJSONPath, query)
+			}
+
+			step, err := parseJSONPathIndex(q[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("%w %q: %s", ErrInvalidJSONPath, query, err)
+			}
+
+			steps = append(steps, step)
+			q = q[end+1:]
+
+		default:
+			return nil, fmt.Errorf("%w %q: unexpected %q", ErrInvalidJSONPath, query, q[0])
+		}
+	}
+
+	return steps, nil
+}
+
+// parseJSONPathIndex parses the content of the brackets, either a quoted key or an array index.
+func parseJSONPathIndex(s string) (interface{}, error) {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], nil
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return nil, fmt.Errorf("invalid index %q", s) // nolint: goerr113
+	}
+
+	return i, nil
+}
+
+func lookupJSONPath(doc interface{}, steps []interface{}) (interface{}, error) {
+	for _, step := range steps {
+		switch s := step.(type) {
+		case string:
+			obj, ok := doc.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object", jsonString(doc)) // nolint: goerr113
+			}
+
+			if doc, ok = obj[s]; !ok {
+				return nil, fmt.Errorf("key %q not found", s) // nolint: goerr113
+			}
+
+		case int:
+			arr, ok := doc.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an array", jsonString(doc)) // nolint: goerr113
+			}
+
+			if s >= len(arr) {
+				return nil, fmt.Errorf("index %d out of range, the array has %d elements", s, len(arr)) // nolint: goerr113
+			}
+
+			doc = arr[s]
+		}
+	}
+
+	return doc, nil
+}
+
+// toJSONValue converts the value to the types decoded by encoding/json, so it can be compared with a decoded value.
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func jsonString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(data)
+}
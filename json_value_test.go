@@ -0,0 +1,152 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func newDeploymentFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "deployment.json", []byte(`{
+	"kind": "Deployment",
+	"spec": {
+		"replicas": 3,
+		"containers": [
+			{"name": "app", "ports": [8080]},
+			{"name": "sidecar", "env": {"LOG.LEVEL": "debug"}}
+		]
+	}
+}`), 0o644))
+
+	return fs
+}
+
+func TestJSONFileValue(t *testing.T) {
+	t.Parallel()
+
+	type container struct {
+		Name  string `json:"name"`
+		Ports []int  `json:"ports"`
+	}
+
+	testCases := []struct {
+		scenario string
+		query    string
+		expected interface{}
+	}{
+		{scenario: "root key", query: "$.kind", expected: "Deployment"},
+		{scenario: "number", query: "$.spec.replicas", expected: 3},
+		{scenario: "without dollar", query: "spec.replicas", expected: 3.0},
+		{scenario: "array index", query: "$.spec.containers[1].name", expected: "sidecar"},
+		{scenario: "quoted key", query: `$.spec.containers[1].env["LOG.LEVEL"]`, expected: "debug"},
+		{scenario: "struct", query: "$.spec.containers[0]", expected: container{Name: "app", Ports: []int{8080}}},
+		{scenario: "nested array", query: "$.spec.containers[0].ports[0]", expected: 8080},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.True(t, aferoassert.JSONFileValue(mockT, newDeploymentFs(t), "deployment.json", tc.query, tc.expected), mockT.message())
+		})
+	}
+}
+
+func TestJSONFileValue_Failure(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		path     string
+		query    string
+		expected interface{}
+		message  string
+	}{
+		{
+			scenario: "different value",
+			path:     "deployment.json",
+			query:    "$.spec.replicas",
+			expected: 2,
+			message:  `"deployment.json" value at $.spec.replicas is 3, expected 2`,
+		},
+		{
+			scenario: "missing key",
+			path:     "deployment.json",
+			query:    "$.spec.template",
+			expected: nil,
+			message:  `"deployment.json" has no value at $.spec.template: key "template" not found`,
+		},
+		{
+			scenario: "index out of range",
+			path:     "deployment.json",
+			query:    "$.spec.containers[2]",
+			expected: nil,
+			message:  "index 2 out of range, the array has 2 elements",
+		},
+		{
+			scenario: "not an array",
+			path:     "deployment.json",
+			query:    "$.kind[0]",
+			expected: nil,
+			message:  `"Deployment" is not an array`,
+		},
+		{
+			scenario: "invalid query",
+			path:     "deployment.json",
+			query:    "$.spec[x]",
+			expected: nil,
+			message:  `invalid json path "$.spec[x]": invalid index "x"`,
+		},
+		{
+			scenario: "empty key",
+			path:     "deployment.json",
+			query:    "$..spec",
+			expected: nil,
+			message:  `invalid json path "$..spec": empty key`,
+		},
+		{
+			scenario: "file not found",
+			path:     "unknown.json",
+			query:    "$",
+			expected: nil,
+			message:  `unable to find file "unknown.json"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.False(t, aferoassert.JSONFileValue(mockT, newDeploymentFs(t), tc.path, tc.query, tc.expected))
+			assert.Contains(t, mockT.message(), tc.message)
+		})
+	}
+}
+
+func TestJSONFileValue_InvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	mockT := &testingT{}
+
+	require.NoError(t, afero.WriteFile(fs, "invalid.json", []byte("{"), 0o644))
+
+	assert.False(t, aferoassert.JSONFileValue(mockT, fs, "invalid.json", "$", nil))
+	assert.Contains(t, mockT.message(), `could not decode "invalid.json"`)
+}
@@ -0,0 +1,133 @@
+package aferoassert
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"sync"
+)
+
+// JUnitReporter records the failures of the assertions and writes them as a JUnit XML report, with one failed test
+// case per path, so the CI dashboards show which files were wrong instead of a single failed test, for example:
+//
+//	r := aferoassert.NewJUnitReporter("layout")
+//
+//	aferoassert.YAMLTreeEqual(t, fs, expected, "dist", aferoassert.WithOnFailure(r.Record))
+//
+//	f, _ := os.Create("junit.xml")
+//	defer f.Close()
+//
+//	r.WriteXML(f)
+type JUnitReporter struct {
+	suite string
+
+	mu       sync.Mutex
+	paths    []string
+	failures map[string][]FailureInfo
+}
+
+// NewJUnitReporter creates a JUnitReporter that writes the test cases into a test suite with the given name.
+func NewJUnitReporter(suite string) *JUnitReporter {
+	return &JUnitReporter{
+		suite:    suite,
+		failures: make(map[string][]FailureInfo),
+	}
+}
+
+// Record records a failure, it is meant to be passed to WithOnFailure. The files that are not found, which the tree
+// assertions report together, are recorded as one failure per file.
+func (r *JUnitReporter) Record(info FailureInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, info := range splitNotFound(info) {
+		if _, ok := r.failures[info.Path]; !ok {
+			r.paths = append(r.paths, info.Path)
+		}
+
+		r.failures[info.Path] = append(r.failures[info.Path], info)
+	}
+}
+
+// Failures returns the recorded failures, grouped by path in the order they were first recorded.
+func (r *JUnitReporter) Failures() []FailureInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]FailureInfo, 0, len(r.paths))
+
+	for _, p := range r.paths {
+		result = append(result, r.failures[p]...)
+	}
+
+	return result
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string       `xml:"classname,attr"`
+	Name      string       `xml:"name,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// WriteXML writes the recorded failures as a JUnit XML test suite. The failures of the same path are merged into one
+// test case, the message of the test case is the first line of the first failure.
+func (r *JUnitReporter) WriteXML(w io.Writer) error {
+	r.mu.Lock()
+
+	suite := junitTestSuite{
+		Name:      r.suite,
+		Tests:     len(r.paths),
+		Failures:  len(r.paths),
+		TestCases: make([]junitTestCase, 0, len(r.paths)),
+	}
+
+	for _, p := range r.paths {
+		failures := r.failures[p]
+		messages := make([]string, 0, len(failures))
+
+		for _, f := range failures {
+			messages = append(messages, f.Message)
+		}
+
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: r.suite,
+			Name:      p,
+			Failure: junitFailure{
+				Message: strings.SplitN(failures[0].Message, "\n", 2)[0],
+				Type:    failures[0].Assertion,
+				Content: strings.Join(messages, "\n\n"),
+			},
+		})
+	}
+
+	r.mu.Unlock()
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
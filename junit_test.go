@@ -0,0 +1,125 @@
+package aferoassert_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", []byte("<html>"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/app.js", []byte("app"), 0o600))
+
+	r := aferoassert.NewJUnitReporter("layout")
+	onFailure := aferoassert.WithOnFailure(r.Record)
+
+	assert.False(t, aferoassert.YAMLTreeEqual(&testingT{}, fs, `
+- index.html 'content:"<html></html>"'
+- app.js 'perm:"0644" content:"bundle"'
+- style.css
+`, "dist", onFailure))
+	assert.True(t, aferoassert.FileExists(&testingT{}, fs, "dist/index.html", onFailure))
+
+	require.Len(t, r.Failures(), 4)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, r.WriteXML(&buf))
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="layout" tests="3" failures="3">
+  <testcase classname="layout" name="dist/app.js">
+    <failure message="&#34;dist/app.js&#34; perm is 0600, expected 0644" type="YAMLTreeEqual">&#34;dist/app.js&#34; perm is 0600, expected 0644&#xA;&#xA;&#34;dist/app.js&#34; content is not as expected`
+
+	assert.Contains(t, buf.String(), expected)
+	assert.Contains(t, buf.String(), `  <testcase classname="layout" name="dist/index.html">
+    <failure message="&#34;dist/index.html&#34; content is not as expected:" type="YAMLTreeEqual">`)
+	assert.Contains(t, buf.String(), `  <testcase classname="layout" name="dist/style.css">
+    <failure message="&#34;dist/style.css&#34; not found" type="YAMLTreeEqual">&#34;dist/style.css&#34; not found</failure>`)
+	assert.Contains(t, buf.String(), "</testsuite>\n")
+}
+
+func TestJUnitReporter_NotFound(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("dist", 0o755))
+
+	r := aferoassert.NewJUnitReporter("layout")
+
+	assert.False(t, aferoassert.YAMLTreeEqual(&testingT{}, fs, "- index.html\n- assets:\n    - app.js\n", "dist", aferoassert.WithOnFailure(r.Record)))
+
+	failures := r.Failures()
+	paths := make([]string, 0, len(failures))
+
+	for _, f := range failures {
+		assert.Equal(t, aferoassert.FailureNotFound, f.Kind)
+
+		paths = append(paths, f.Path)
+	}
+
+	assert.Equal(t, []string{"dist/assets", "dist/assets/app.js", "dist/index.html"}, paths)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, r.WriteXML(&buf))
+	assert.Contains(t, buf.String(), `<testsuite name="layout" tests="3" failures="3">`)
+}
+
+func TestJUnitReporter_WithActualTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", nil, 0o644))
+
+	r := aferoassert.NewJUnitReporter("layout")
+
+	assert.False(t, aferoassert.YAMLTreeEqual(&testingT{}, fs, "- style.css\n", "dist", aferoassert.WithOnFailure(r.Record), aferoassert.WithActualTree()))
+
+	var buf bytes.Buffer
+
+	require.NoError(t, r.WriteXML(&buf))
+
+	assert.Contains(t, buf.String(), `<testsuite name="layout" tests="2" failures="2">`)
+	assert.Contains(t, buf.String(), `name="dist/index.html"`)
+	assert.Contains(t, buf.String(), `name="dist/style.css"`)
+	assert.Contains(t, buf.String(), `actual tree of &#34;dist&#34;`)
+}
+
+func TestJUnitReporter_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, aferoassert.NewJUnitReporter("layout").WriteXML(&buf))
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="layout" tests="0" failures="0"></testsuite>
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestJUnitReporter_WriteError(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, aferoassert.NewJUnitReporter("layout").WriteXML(failingWriter{}), "write error")
+}
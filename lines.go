@@ -0,0 +1,77 @@
+package aferoassert
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// FileLinesContain checks whether a file contains all the lines, in any order, for example, the output of concurrent
+// loggers. Each line has to match a whole line of the file at least once, the line endings, LF or CRLF, are ignored.
+// The file is read line by line and the reading stops as soon as all the lines are found.
+func FileLinesContain(t TestingT, fs afero.Fs, path string, lines ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, _ := newConfig(nil)
+
+	return c.run(t, "FileLinesContain", func(t TestingT) bool {
+		return assertFileLinesContain(t, c, fs, path, lines)
+	})
+}
+
+func assertFileLinesContain(t TestingT, c *config, fs afero.Fs, path string, lines []string) bool {
+	f, ok := openRegularFile(t, c, fs, path)
+	if !ok {
+		return false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	pending := make(map[string]bool, len(lines))
+
+	for _, l := range lines {
+		pending[l] = true
+	}
+
+	r := bufio.NewReader(f)
+
+	for len(pending) > 0 {
+		line, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)})
+		}
+
+		if line != "" {
+			delete(pending, strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if len(pending) == 0 {
+		return true
+	}
+
+	missing := make([]string, 0, len(pending))
+
+	for _, l := range lines {
+		if pending[l] {
+			missing = append(missing, fmt.Sprintf("%q", l))
+			delete(pending, l)
+		}
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:     path,
+		Expected: lines,
+		Message:  fmt.Sprintf("%q does not contain these lines:\n- %s", path, strings.Join(missing, "\n- ")),
+	})
+}
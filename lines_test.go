@@ -0,0 +1,36 @@
+package aferoassert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileLinesContain(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "out.log", []byte("worker 2 done\r\nworker 1 done\nworker 3 done"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileLinesContain(mockT, fs, "out.log"), mockT.message())
+	assert.True(t, aferoassert.FileLinesContain(mockT, fs, "out.log", "worker 1 done", "worker 2 done", "worker 3 done", "worker 1 done"), mockT.message())
+
+	assert.False(t, aferoassert.FileLinesContain(mockT, fs, "out.log", "worker 4 done", "worker 1 done", "worker", "worker 4 done"))
+	assert.Contains(t, mockT.message(), "\"out.log\" does not contain these lines:\n")
+	assert.Contains(t, mockT.message(), `- "worker 4 done"`)
+	assert.Contains(t, mockT.message(), `- "worker"`)
+	assert.Equal(t, 1, strings.Count(mockT.message(), "worker 4 done"))
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileLinesContain(mockT, fs, "unknown.log", "line"))
+	assert.Contains(t, mockT.message(), `unable to find file "unknown.log"`)
+}
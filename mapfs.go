@@ -0,0 +1,156 @@
+package aferoassert
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"testing/fstest"
+
+	"github.com/spf13/afero"
+)
+
+// ToMapFS converts a directory to a fstest.MapFS, the keys are the slash-separated paths relative to the root.
+func ToMapFS(fs afero.Fs, root string) (fstest.MapFS, error) {
+	root = filepath.Clean(root)
+	result := make(fstest.MapFS)
+
+	err := afero.Walk(fs, root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(root, p)
+		if rel == "." {
+			return nil
+		}
+
+		f := &fstest.MapFile{
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+		}
+
+		if info.Mode().IsRegular() {
+			if f.Data, err = afero.ReadFile(fs, p); err != nil {
+				return err
+			}
+		}
+
+		result[filepath.ToSlash(rel)] = f
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FromMapFS creates an afero.MemMapFs with the files of a fstest.MapFS. The files without a permission are created
+// with 0644 and the directories with 0755.
+func FromMapFS(m fstest.MapFS) (afero.Fs, error) {
+	fs := afero.NewMemMapFs()
+	names := make([]string, 0, len(m))
+
+	for name := range m {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := m[name]
+		p := filepath.FromSlash(name)
+
+		if err := createMapFile(fs, p, f); err != nil {
+			return nil, err
+		}
+
+		if !f.ModTime.IsZero() {
+			if err := fs.Chtimes(p, f.ModTime, f.ModTime); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return fs, nil
+}
+
+func createMapFile(fs afero.Fs, p string, f *fstest.MapFile) error {
+	if f.Mode.IsDir() {
+		perm := f.Mode.Perm()
+		if perm == 0 {
+			perm = 0o755
+		}
+
+		if err := fs.MkdirAll(p, perm); err != nil {
+			return err
+		}
+
+		return fs.Chmod(p, perm)
+	}
+
+	perm := f.Mode.Perm()
+	if perm == 0 {
+		perm = 0o644
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	if err := afero.WriteFile(fs, p, f.Data, perm); err != nil {
+		return err
+	}
+
+	return fs.Chmod(p, perm)
+}
+
+// MapFSEqual checks whether a directory has exactly the files of a fstest.MapFS with the same content. The
+// permissions are compared only for the entries that have one.
+func MapFSEqual(t TestingT, expected fstest.MapFS, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "MapFSEqual", func(t TestingT) bool {
+		return assertMapFS(t, c, expected, fs, root, true, msgAndArgs...)
+	})
+}
+
+// MapFSContains checks whether a directory contains the files of a fstest.MapFS with the same content. The
+// permissions are compared only for the entries that have one.
+func MapFSContains(t TestingT, expected fstest.MapFS, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "MapFSContains", func(t TestingT) bool {
+		return assertMapFS(t, c, expected, fs, root, false, msgAndArgs...)
+	})
+}
+
+func assertMapFS(t TestingT, c *config, expected fstest.MapFS, fs afero.Fs, root string, exhaustive bool, msgAndArgs ...interface{}) bool {
+	a := &fsComparison{
+		t:            t,
+		c:            c,
+		msgAndArgs:   msgAndArgs,
+		expected:     FromIOFS(expected),
+		expectedRoot: ".",
+		actual:       fs,
+		actualRoot:   root,
+		exhaustive:   exhaustive,
+		comparePerm: func(p string) bool {
+			f, ok := expected[path.Clean(filepath.ToSlash(p))]
+
+			return ok && f.Mode.Perm() != 0
+		},
+	}
+
+	return a.run()
+}
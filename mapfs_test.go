@@ -0,0 +1,85 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFromMapFS_ToMapFS(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	fs, err := aferoassert.FromMapFS(fstest.MapFS{
+		"root/file 1":          {Data: []byte("file 1"), Mode: 0o600, ModTime: modTime},
+		"root/folder 2/file 2": {Data: []byte("file 2")},
+		"root/folder 3":        {Mode: os.ModeDir | 0o700},
+	})
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, `
+- file 1 'perm:"0600"'
+- folder 2 'perm:"0755"':
+    - file 2 'perm:"0644" content:"file 2"'
+- folder 3 'perm:"0700"': {}
+`, "root"), mockT.message())
+
+	actual, err := aferoassert.ToMapFS(fs, "root")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("file 1"), actual["file 1"].Data)
+	assert.Equal(t, os.FileMode(0o600), actual["file 1"].Mode)
+	assert.True(t, modTime.Equal(actual["file 1"].ModTime))
+	assert.Equal(t, os.ModeDir|0o755, actual["folder 2"].Mode)
+	assert.Equal(t, []byte("file 2"), actual["folder 2/file 2"].Data)
+	assert.Len(t, actual, 4)
+
+	assert.NoError(t, fstest.TestFS(actual, "file 1", "folder 2/file 2"))
+}
+
+func TestToMapFS_NotFound(t *testing.T) {
+	t.Parallel()
+
+	result, err := aferoassert.ToMapFS(afero.NewMemMapFs(), "root")
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestMapFSEqual(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("file 1"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/folder 2/file 2", []byte("file 2"), 0o644))
+
+	expected := fstest.MapFS{
+		"file 1":          {Data: []byte("file 1"), Mode: 0o644},
+		"folder 2/file 2": {Data: []byte("file 2")},
+	}
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.MapFSEqual(mockT, expected, fs, "root"), mockT.message())
+	assert.True(t, aferoassert.MapFSContains(mockT, fstest.MapFS{"file 1": {Data: []byte("file 1")}}, fs, "root"), mockT.message())
+
+	expected["file 1"] = &fstest.MapFile{Data: []byte("file 1"), Mode: 0o600}
+	expected["file 3"] = &fstest.MapFile{}
+
+	assert.False(t, aferoassert.MapFSEqual(mockT, expected, fs, "root"))
+	require.Len(t, mockT.errors, 2)
+
+	assert.Contains(t, mockT.errors[0], `"root/file 1" perm is 0644, expected 0600`)
+	assert.Contains(t, mockT.errors[1], `- file 3, did you mean "root/file 1"?`)
+}
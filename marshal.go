@@ -0,0 +1,101 @@
+package aferoassert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// TreeFromFs builds a file tree from a directory. Every node has a `perm` tag, a `mode` tag is added for the nodes
+// that are neither a regular file nor a directory. The IgnorePaths and FollowLinks options are supported.
+func TreeFromFs(fs afero.Fs, root string, opts ...Option) (FileTree, error) {
+	return treeFromFs(configFromOptions(opts), fs, root)
+}
+
+// MarshalTree renders a directory as a YAML file tree that can be used as an expectation. The IgnorePaths and
+// FollowLinks options are supported.
+func MarshalTree(fs afero.Fs, root string, opts ...Option) ([]byte, error) {
+	return marshalTree(configFromOptions(opts), fs, root)
+}
+
+func treeFromFs(c *config, fs afero.Fs, root string) (FileTree, error) {
+	root = filepath.Clean(root)
+	children := make(map[string][]FileNode)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == root {
+			return nil
+		}
+
+		rel := strings.TrimPrefix(path, root+string(os.PathSeparator))
+
+		if c.ignored(rel) {
+			return skip(info)
+		}
+
+		parent := filepath.Dir(rel)
+
+		children[parent] = append(children[parent], fileNodeFromInfo(info))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTree(children, "."), nil
+}
+
+func marshalTree(c *config, fs afero.Fs, root string) ([]byte, error) {
+	tree, err := treeFromFs(c, fs, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(tree)
+}
+
+func fileNodeFromInfo(info os.FileInfo) FileNode {
+	mode := info.Mode()
+
+	n := FileNode{
+		Name:  info.Name(),
+		IsDir: info.IsDir(),
+		Tags: FileModeTags{
+			"perm": FileModePtr(mode & permBits),
+		},
+	}
+
+	if mode&^os.ModePerm&^os.ModeDir != 0 {
+		n.Tags["mode"] = FileModePtr(mode &^ os.ModePerm)
+	}
+
+	return n
+}
+
+func buildTree(children map[string][]FileNode, dir string) FileTree {
+	nodes := children[dir]
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	tree := make(FileTree, len(nodes))
+
+	for _, n := range nodes {
+		if n.IsDir {
+			n.Children = buildTree(children, filepath.Join(dir, n.Name))
+		}
+
+		tree[n.Name] = n
+	}
+
+	return tree
+}
@@ -0,0 +1,66 @@
+package aferoassert_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/aferomock"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestMarshalTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/folder 2/folder 3", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", []byte("file 1"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/folder 2/file 2", []byte("file 2"), 0o600))
+
+	result, err := aferoassert.MarshalTree(fs, "root")
+	require.NoError(t, err)
+
+	expected := `- file 1 'perm:"0644"'
+- folder 2 'perm:"0755"':
+    - file 2 'perm:"0600"'
+    - folder 3 'perm:"0755"': {}
+`
+
+	assert.Equal(t, expected, string(result))
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, string(result), "root"), mockT.message())
+}
+
+func TestMarshalTree_CouldNotWalk(t *testing.T) {
+	t.Parallel()
+
+	fs := aferomock.MockFs(func(fs *aferomock.Fs) {
+		fs.On("Stat", "root").
+			Return(nil, errors.New("stat error"))
+	})(t)
+
+	result, err := aferoassert.MarshalTree(fs, "root")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "stat error")
+}
+
+func TestMarshalTree_IgnorePaths(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/.git/objects", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file 1.tmp", nil, 0o644))
+
+	result, err := aferoassert.MarshalTree(fs, "root", aferoassert.IgnorePaths(".git", "*.tmp"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "- file 1 'perm:\"0644\"'\n", string(result))
+}
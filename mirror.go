@@ -0,0 +1,171 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+// Mirrored checks whether a directory is an exact mirror of another directory of the same file system, with the same
+// files, types, permissions and content, for example, to test a copy or a sync routine. Both directories are walked
+// together in a single pass and all the differences are reported. The permissions are not compared with IgnorePerm,
+// and the modification times of the files are compared with CompareModTimes.
+//
+//	aferoassert.Mirrored(t, fs, "src", "backup", aferoassert.CompareModTimes())
+func Mirrored(t TestingT, fs afero.Fs, srcRoot, dstRoot string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "Mirrored", func(t TestingT) bool {
+		a := &fsComparison{
+			t:            t,
+			c:            c,
+			msgAndArgs:   msgAndArgs,
+			expected:     fs,
+			expectedRoot: filepath.Clean(srcRoot),
+			actual:       fs,
+			actualRoot:   filepath.Clean(dstRoot),
+			exhaustive:   true,
+			comparePerm:  func(string) bool { return true },
+			result:       true,
+		}
+
+		return a.mirror()
+	})
+}
+
+func (a *fsComparison) mirror() bool {
+	srcInfo, err := stat(a.c, a.expected, a.expectedRoot)
+	if err != nil {
+		return a.fail(statFailureInfo(a.expected, a.expectedRoot, err))
+	}
+
+	dstInfo, err := stat(a.c, a.actual, a.actualRoot)
+	if err != nil {
+		return a.fail(statFailureInfo(a.actual, a.actualRoot, err))
+	}
+
+	if !srcInfo.IsDir() || !dstInfo.IsDir() {
+		a.compareMirrored(a.expectedRoot, srcInfo, a.actualRoot, dstInfo)
+
+		return a.result
+	}
+
+	var missing []string
+
+	if err := a.mirrorDir(".", &missing); err != nil {
+		return a.fail(FailureInfo{Path: a.actualRoot, Message: fmt.Sprintf("could not walk through %q: %s", a.actualRoot, err)})
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+
+		a.fail(FailureInfo{
+			Kind:     FailureNotFound,
+			Path:     a.actualRoot,
+			Expected: missing,
+			Message: fmt.Sprintf("expected these files in %q but not found:\n%s", a.actualRoot, formatMissingFiles(missing, func(p string) string {
+				return didYouMean(a.actual, filepath.Join(a.actualRoot, p))
+			})),
+		})
+	}
+
+	return a.result
+}
+
+// mirrorDir compares the entries of a directory, relative to the roots, by merging the sorted names of both sides.
+func (a *fsComparison) mirrorDir(rel string, missing *[]string) error {
+	srcDir := filepath.Join(a.expectedRoot, rel)
+	dstDir := filepath.Join(a.actualRoot, rel)
+
+	srcNames, err := readDir(a.c, a.expected, srcDir)
+	if err != nil {
+		return err
+	}
+
+	dstNames, err := readDir(a.c, a.actual, dstDir)
+	if err != nil {
+		return err
+	}
+
+	for i, j := 0, 0; i < len(srcNames) || j < len(dstNames); {
+		switch {
+		case j == len(dstNames) || i < len(srcNames) && srcNames[i] < dstNames[j]:
+			if entry := filepath.Join(rel, srcNames[i]); !a.c.ignored(entry) {
+				*missing = append(*missing, entry)
+			}
+
+			i++
+
+		case i == len(srcNames) || srcNames[i] > dstNames[j]:
+			if entry := filepath.Join(rel, dstNames[j]); !a.c.ignored(entry) {
+				path := filepath.Join(a.actualRoot, entry)
+
+				a.fail(FailureInfo{Kind: FailureUnexpected, Path: path, Message: fmt.Sprintf("unexpected file %q", path)})
+			}
+
+			j++
+
+		default:
+			if err := a.mirrorEntry(filepath.Join(rel, srcNames[i]), missing); err != nil {
+				return err
+			}
+
+			i++
+			j++
+		}
+	}
+
+	return nil
+}
+
+func (a *fsComparison) mirrorEntry(rel string, missing *[]string) error {
+	if a.c.ignored(rel) {
+		return nil
+	}
+
+	srcPath := filepath.Join(a.expectedRoot, rel)
+	dstPath := filepath.Join(a.actualRoot, rel)
+
+	srcInfo, err := stat(a.c, a.expected, srcPath)
+	if err != nil {
+		return err
+	}
+
+	dstInfo, err := stat(a.c, a.actual, dstPath)
+	if err != nil {
+		return err
+	}
+
+	if !a.compareMirrored(srcPath, srcInfo, dstPath, dstInfo) || !srcInfo.IsDir() {
+		return nil
+	}
+
+	return a.mirrorDir(rel, missing)
+}
+
+// compareMirrored compares two entries and tells whether they have the same type.
+func (a *fsComparison) compareMirrored(srcPath string, src os.FileInfo, dstPath string, dst os.FileInfo) bool {
+	a.compare(srcPath, src, dstPath, dst)
+
+	if src.Mode().Type() != dst.Mode().Type() {
+		return false
+	}
+
+	if a.c.compareModTime && !src.IsDir() && !src.ModTime().Equal(dst.ModTime()) {
+		a.fail(FailureInfo{
+			Path:     dstPath,
+			Expected: src.ModTime(),
+			Actual:   dst.ModTime(),
+			Message:  fmt.Sprintf("%q modification time is %s, expected %s", dstPath, dst.ModTime(), src.ModTime()),
+		})
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package aferoassert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func newMirroredFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	for _, root := range []string{"src", "dst"} {
+		require.NoError(t, fs.MkdirAll(root+"/dir", 0o755))
+		require.NoError(t, afero.WriteFile(fs, root+"/dir/file", []byte("content\n"), 0o644))
+		require.NoError(t, afero.WriteFile(fs, root+"/file", []byte("file\n"), 0o600))
+	}
+
+	return fs
+}
+
+func TestMirrored(t *testing.T) {
+	t.Parallel()
+
+	fs := newMirroredFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Mirrored(mockT, fs, "src", "dst"), mockT.message())
+
+	require.NoError(t, fs.Chmod("dst/file", 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dst/dir/file", []byte("changed\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dst/extra", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "src/dir/missing", nil, 0o644))
+
+	assert.False(t, aferoassert.Mirrored(mockT, fs, "src", "dst"))
+	assert.Contains(t, mockT.message(), `"dst/file" perm is 0644, expected 0600`)
+	assert.Contains(t, mockT.message(), `"dst/dir/file" content is not as expected`)
+	assert.Contains(t, mockT.message(), `unexpected file "dst/extra"`)
+	assert.Contains(t, mockT.message(), `expected these files in "dst" but not found`)
+	assert.Contains(t, mockT.message(), "missing")
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.Mirrored(mockT, fs, "src", "dst", aferoassert.IgnorePerm(), aferoassert.IgnorePaths("dir", "extra")), mockT.message())
+}
+
+func TestMirrored_TypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	fs := newMirroredFs(t)
+
+	require.NoError(t, fs.RemoveAll("dst/dir"))
+	require.NoError(t, afero.WriteFile(fs, "dst/dir", nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.Mirrored(mockT, fs, "src", "dst"))
+	assert.Contains(t, mockT.message(), `"dst/dir" is not a directory`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.Mirrored(mockT, fs, "src", "unknown"))
+	assert.Contains(t, mockT.message(), `unable to find file "unknown"`)
+}
+
+func TestMirrored_CompareModTimes(t *testing.T) {
+	t.Parallel()
+
+	fs := newMirroredFs(t)
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, p := range []string{"src/file", "src/dir/file", "dst/file", "dst/dir/file"} {
+		require.NoError(t, fs.Chtimes(p, mtime, mtime))
+	}
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Mirrored(mockT, fs, "src", "dst", aferoassert.CompareModTimes()), mockT.message())
+
+	require.NoError(t, fs.Chtimes("dst/file", mtime, mtime.Add(time.Hour)))
+
+	assert.True(t, aferoassert.Mirrored(mockT, fs, "src", "dst"), mockT.message())
+
+	assert.False(t, aferoassert.Mirrored(mockT, fs, "src", "dst", aferoassert.CompareModTimes()))
+	assert.Contains(t, mockT.message(), `"dst/file" modification time is 2020-01-02 04:04:05 +0000 UTC, expected 2020-01-02 03:04:05 +0000 UTC`)
+}
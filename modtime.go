@@ -0,0 +1,59 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// ModTimesAscending checks whether the modification times of the paths are strictly increasing, in the given order,
+// for example, the outputs of a pipeline that has to write them in sequence. All the pairs out of order are reported
+// together.
+//
+//	aferoassert.ModTimesAscending(t, fs, "out/extract.csv", "out/transform.csv", "out/load.log")
+func ModTimesAscending(t TestingT, fs afero.Fs, paths ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, _ := newConfig(nil)
+
+	return c.run(t, "ModTimesAscending", func(t TestingT) bool {
+		return assertModTimesAscending(t, c, fs, paths)
+	})
+}
+
+func assertModTimesAscending(t TestingT, c *config, fs afero.Fs, paths []string) bool {
+	infos := make([]os.FileInfo, len(paths))
+
+	for i, path := range paths {
+		info, err := stat(c, fs, path)
+		if err != nil {
+			return c.fail(t, statFailureInfo(fs, path, err))
+		}
+
+		infos[i] = info
+	}
+
+	violations := make([]string, 0)
+
+	for i := 1; i < len(paths); i++ {
+		prev, cur := infos[i-1].ModTime(), infos[i].ModTime()
+
+		if !cur.After(prev) {
+			violations = append(violations, fmt.Sprintf("%q (modified at %s) is not newer than %q (modified at %s)", paths[i], cur, paths[i-1], prev))
+		}
+	}
+
+	if len(violations) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    paths[0],
+		Actual:  violations,
+		Message: fmt.Sprintf("modification times are not strictly increasing:\n- %s", strings.Join(violations, "\n- ")),
+	})
+}
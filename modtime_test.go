@@ -0,0 +1,86 @@
+package aferoassert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestModTimesAscending(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	fs := afero.NewMemMapFs()
+
+	for name, mtime := range map[string]time.Time{
+		"out/extract.csv":   now.Add(-3 * time.Minute),
+		"out/transform.csv": now.Add(-2 * time.Minute),
+		"out/load.log":      now.Add(-time.Minute),
+		"out/report.txt":    now.Add(-time.Minute),
+	} {
+		require.NoError(t, afero.WriteFile(fs, name, nil, 0o644))
+		require.NoError(t, fs.Chtimes(name, mtime, mtime))
+	}
+
+	testCases := []struct {
+		scenario       string
+		paths          []string
+		expectedResult bool
+		expectedErrors []string
+	}{
+		{
+			scenario:       "no paths",
+			expectedResult: true,
+		},
+		{
+			scenario:       "one path",
+			paths:          []string{"out/load.log"},
+			expectedResult: true,
+		},
+		{
+			scenario:       "ascending",
+			paths:          []string{"out/extract.csv", "out/transform.csv", "out/load.log"},
+			expectedResult: true,
+		},
+		{
+			scenario: "descending",
+			paths:    []string{"out/load.log", "out/transform.csv", "out/extract.csv"},
+			expectedErrors: []string{
+				"modification times are not strictly increasing:",
+				`- "out/transform.csv" (modified at `, `) is not newer than "out/load.log" (modified at `,
+				`- "out/extract.csv" (modified at `, `) is not newer than "out/transform.csv" (modified at `,
+			},
+		},
+		{
+			scenario:       "same time",
+			paths:          []string{"out/extract.csv", "out/load.log", "out/report.txt"},
+			expectedErrors: []string{`- "out/report.txt" (modified at `, `) is not newer than "out/load.log" (modified at `},
+		},
+		{
+			scenario:       "not found",
+			paths:          []string{"out/extract.csv", "out/unknown.csv"},
+			expectedErrors: []string{`unable to find file "out/unknown.csv"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.Equal(t, tc.expectedResult, aferoassert.ModTimesAscending(mockT, fs, tc.paths...), mockT.message())
+
+			for _, err := range tc.expectedErrors {
+				assert.Contains(t, mockT.message(), err)
+			}
+		})
+	}
+}
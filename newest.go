@@ -0,0 +1,88 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// NewestFileMatches checks whether the most recently modified entry of a directory has a name matching the
+// filepath.Match pattern, for example, that the latest snapshot is the one that was just written. With WithMaxAge, the
+// entry also has to be modified within the duration.
+//
+//	aferoassert.NewestFileMatches(t, fs, "snapshots", "snapshot-*.db", aferoassert.WithMaxAge(time.Minute))
+func NewestFileMatches(t TestingT, fs afero.Fs, dir, pattern string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NewestFileMatches", func(t TestingT) bool {
+		return assertNewestFileMatches(t, c, fs, dir, pattern, msgAndArgs...)
+	})
+}
+
+func assertNewestFileMatches(t TestingT, c *config, fs afero.Fs, dir, pattern string, msgAndArgs ...interface{}) bool {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return c.fail(t, FailureInfo{Path: dir, Message: fmt.Sprintf("invalid pattern %q: %s", pattern, err)}, msgAndArgs...)
+	}
+
+	newest, err := newestEntry(c, fs, dir)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: dir, Message: fmt.Sprintf("could not read directory %q: %s", dir, err)}, msgAndArgs...)
+	}
+
+	if newest == nil {
+		return c.fail(t, FailureInfo{Path: dir, Expected: pattern, Message: fmt.Sprintf("%q is empty, expected the newest entry to match %q", dir, pattern)}, msgAndArgs...)
+	}
+
+	path := filepath.Join(dir, newest.Name())
+
+	if ok, _ := filepath.Match(pattern, newest.Name()); !ok {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: pattern,
+			Actual:   newest.Name(),
+			Message:  fmt.Sprintf("the newest entry of %q is %q (modified at %s), expected it to match %q", dir, newest.Name(), newest.ModTime(), pattern),
+		}, msgAndArgs...)
+	}
+
+	if age := time.Since(newest.ModTime()); c.maxAge > 0 && age > c.maxAge {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: c.maxAge,
+			Actual:   age,
+			Message:  fmt.Sprintf("%q was modified %s ago, expected at most %s ago", path, age.Round(time.Millisecond), c.maxAge),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// newestEntry returns the most recently modified entry of the directory, or nil if it is empty. The entries modified
+// at the same time are ordered by name.
+func newestEntry(c *config, fs afero.Fs, dir string) (os.FileInfo, error) {
+	names, err := readDir(c, fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var newest os.FileInfo
+
+	for _, name := range names {
+		info, err := stat(c, fs, filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		if newest == nil || !info.ModTime().Before(newest.ModTime()) {
+			newest = info
+		}
+	}
+
+	return newest, nil
+}
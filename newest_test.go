@@ -0,0 +1,105 @@
+package aferoassert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestNewestFileMatches(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	fs := afero.NewMemMapFs()
+
+	for name, age := range map[string]time.Duration{
+		"snapshots/snapshot-1.db": 3 * time.Hour,
+		"snapshots/snapshot-2.db": 2 * time.Hour,
+		"snapshots/snapshot-3.db": time.Hour,
+		"snapshots/README.md":     4 * time.Hour,
+	} {
+		require.NoError(t, afero.WriteFile(fs, name, nil, 0o644))
+		require.NoError(t, fs.Chtimes(name, now.Add(-age), now.Add(-age)))
+	}
+
+	require.NoError(t, fs.MkdirAll("empty", 0o755))
+
+	testCases := []struct {
+		scenario       string
+		dir            string
+		pattern        string
+		options        []interface{}
+		expectedResult bool
+		expectedError  string
+	}{
+		{
+			scenario:       "newest matches",
+			dir:            "snapshots",
+			pattern:        "snapshot-*.db",
+			expectedResult: true,
+		},
+		{
+			scenario:       "newest matches exactly",
+			dir:            "snapshots",
+			pattern:        "snapshot-3.db",
+			expectedResult: true,
+		},
+		{
+			scenario:      "newest does not match",
+			dir:           "snapshots",
+			pattern:       "snapshot-2.db",
+			expectedError: `the newest entry of "snapshots" is "snapshot-3.db"`,
+		},
+		{
+			scenario:       "within max age",
+			dir:            "snapshots",
+			pattern:        "snapshot-*.db",
+			options:        []interface{}{aferoassert.WithMaxAge(2 * time.Hour)},
+			expectedResult: true,
+		},
+		{
+			scenario:      "older than max age",
+			dir:           "snapshots",
+			pattern:       "snapshot-*.db",
+			options:       []interface{}{aferoassert.WithMaxAge(time.Minute)},
+			expectedError: `"snapshots/snapshot-3.db" was modified 1h0m0`,
+		},
+		{
+			scenario:      "empty directory",
+			dir:           "empty",
+			pattern:       "*",
+			expectedError: `"empty" is empty, expected the newest entry to match "*"`,
+		},
+		{
+			scenario:      "directory not found",
+			dir:           "unknown",
+			pattern:       "*",
+			expectedError: `could not read directory "unknown"`,
+		},
+		{
+			scenario:      "invalid pattern",
+			dir:           "snapshots",
+			pattern:       "[",
+			expectedError: `invalid pattern "["`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+			result := aferoassert.NewestFileMatches(mockT, fs, tc.dir, tc.pattern, tc.options...)
+
+			assert.Equal(t, tc.expectedResult, result, mockT.message())
+			assert.Contains(t, mockT.message(), tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package aferoassert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+)
+
+// OpenFileContent checks whether the content of an open file is equal to the expectation. The file is read with ReadAt
+// so its position is preserved.
+func OpenFileContent(t TestingT, f afero.File, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "OpenFileContent", func(t TestingT) bool {
+		return assertOpenFileContent(t, c, f, expected, msgAndArgs...)
+	})
+}
+
+func assertOpenFileContent(t TestingT, c *config, f afero.File, expected string, msgAndArgs ...interface{}) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: f.Name(), Message: fmt.Sprintf("could not stat %q: %s", f.Name(), err)}, msgAndArgs...)
+	}
+
+	buf := new(bytes.Buffer)
+
+	if _, err := io.Copy(buf, io.NewSectionReader(f, 0, info.Size())); err != nil {
+		return c.fail(t, FailureInfo{Path: f.Name(), Message: fmt.Sprintf("could not read %q: %s", f.Name(), err)}, msgAndArgs...)
+	}
+
+	if expected, actual := c.normalize(expected), c.normalize(buf.String()); expected != actual {
+		return c.fail(t, FailureInfo{
+			Path:     f.Name(),
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q content is not as expected:\n\n%s", f.Name(), c.diff(expected, actual)),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// OpenFileSize checks whether the size of an open file is equal to the expectation.
+func OpenFileSize(t TestingT, f afero.File, expected int64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "OpenFileSize", func(t TestingT) bool {
+		return assertOpenFileSize(t, c, f, expected, msgAndArgs...)
+	})
+}
+
+func assertOpenFileSize(t TestingT, c *config, f afero.File, expected int64, msgAndArgs ...interface{}) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: f.Name(), Message: fmt.Sprintf("could not stat %q: %s", f.Name(), err)}, msgAndArgs...)
+	}
+
+	if info.Size() != expected {
+		return c.fail(t, FailureInfo{
+			Path:     f.Name(),
+			Expected: expected,
+			Actual:   info.Size(),
+			Message:  fmt.Sprintf("%q size is %d bytes, expected %d bytes", f.Name(), info.Size(), expected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// OpenFileAt checks whether an open file has the expected bytes at the offset. The file is read with ReadAt so its
+// position is preserved.
+func OpenFileAt(t TestingT, f afero.File, offset int64, expected []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "OpenFileAt", func(t TestingT) bool {
+		return assertOpenFileAt(t, c, f, offset, expected, msgAndArgs...)
+	})
+}
+
+func assertOpenFileAt(t TestingT, c *config, f afero.File, offset int64, expected []byte, msgAndArgs ...interface{}) bool {
+	actual := make([]byte, len(expected))
+
+	n, err := f.ReadAt(actual, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return c.fail(t, FailureInfo{Path: f.Name(), Message: fmt.Sprintf("could not read %q at %d: %s", f.Name(), offset, err)}, msgAndArgs...)
+	}
+
+	if actual = actual[:n]; !bytes.Equal(expected, actual) {
+		return c.fail(t, FailureInfo{
+			Path:     f.Name(),
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q at offset %d is %q, expected %q", f.Name(), offset, actual, expected),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
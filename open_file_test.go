@@ -0,0 +1,47 @@
+package aferoassert_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestOpenFileAssertions(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.txt", []byte("hello world"), 0o644))
+
+	f, err := fs.Open("file.txt")
+	require.NoError(t, err)
+
+	defer f.Close() // nolint: errcheck
+
+	_, err = f.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.OpenFileContent(mockT, f, "hello world"), mockT.message())
+	assert.True(t, aferoassert.OpenFileSize(mockT, f, 11), mockT.message())
+	assert.True(t, aferoassert.OpenFileAt(mockT, f, 6, []byte("world")), mockT.message())
+
+	assert.False(t, aferoassert.OpenFileContent(mockT, f, "hello"))
+	assert.False(t, aferoassert.OpenFileSize(mockT, f, 5))
+	assert.False(t, aferoassert.OpenFileAt(mockT, f, 6, []byte("world!")))
+	require.Len(t, mockT.errors, 3)
+	assert.Contains(t, mockT.errors[0], `"file.txt" content is not as expected`)
+	assert.Contains(t, mockT.errors[1], `"file.txt" size is 11 bytes, expected 5 bytes`)
+	assert.Contains(t, mockT.errors[2], `"file.txt" at offset 6 is "world", expected "world!"`)
+
+	// The position is preserved.
+	rest, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(rest))
+}
@@ -0,0 +1,444 @@
+package aferoassert
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"golang.org/x/text/unicode/norm"
+)
+
+const defaultDiffContext = 3
+
+var defaultOptions = struct {
+	mu   sync.RWMutex
+	opts []Option
+}{}
+
+// Option configures an assertion. Options are passed together with msgAndArgs, for example:
+//
+//	aferoassert.FileContent(t, fs, "file.txt", expected, aferoassert.WithDiffContext(5))
+//
+// Options are removed from msgAndArgs before the failure message is rendered.
+type Option interface {
+	applyOption(c *config)
+}
+
+type optionFunc func(c *config)
+
+func (f optionFunc) applyOption(c *config) {
+	f(c)
+}
+
+type config struct {
+	diffContext    int
+	dumpActualTree bool
+	ignorePerm     bool
+	windowsPerm    bool
+	failFast       bool
+	followLinks    bool
+	unicodeForm    *norm.Form
+	allocatedSize  bool
+	normalizeEOL   bool
+	ignorePatterns []string
+	retryAttempts  int
+	retryBackoff   time.Duration
+	onFailure      []func(FailureInfo)
+	trace          bool
+	stats          *Stats
+	readLimit      int64
+	compareModTime bool
+	maxAge         time.Duration
+	detectChanges  bool
+	subtests       bool
+	maxContentSize int
+	failNow        bool
+	formatter      Formatter
+	expectationFs  afero.Fs
+	// dumpContentLimit and dumpDir configure DumpOnFailure.
+	dumpContentLimit int64
+	dumpDir          string
+	// updateGolden overrides the -update flag of DirGolden, see WithGoldenUpdate.
+	updateGolden *bool
+	// traverseSymlinkDirs walks the symlinked directories without following the links, see TraverseSymlinkDirs.
+	traverseSymlinkDirs bool
+
+	// assertion is the name of the running assertion, see FailureInfo.
+	assertion string
+	// traceSteps are the steps of the running assertion, see WithTrace.
+	traceSteps []string
+	// visited and bytesRead are the metrics of the running assertion, see WithStats.
+	visited   int
+	bytesRead int64
+	// index serves the stats and the directory listings of a Session.
+	index *walkIndex
+}
+
+// normalize prepares the content for comparison.
+func (c *config) normalize(content string) string {
+	if c.normalizeEOL {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	return content
+}
+
+// normalizePath applies the unicode normalization to the path, see WithUnicodeNormalization.
+func (c *config) normalizePath(path string) string {
+	if c.unicodeForm == nil {
+		return path
+	}
+
+	return c.unicodeForm.String(path)
+}
+
+// ignored checks whether a path relative to the root of the assertion, or one of its parents, matches one of the
+// ignore patterns. A pattern is matched against the whole path and against its base name.
+func (c *config) ignored(path string) bool {
+	for ; path != "." && path != string(filepath.Separator); path = filepath.Dir(path) {
+		for _, p := range c.ignorePatterns {
+			if ok, _ := filepath.Match(p, path); ok { //nolint: errcheck
+				return true
+			}
+
+			if ok, _ := filepath.Match(p, filepath.Base(path)); ok { //nolint: errcheck
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetDefaultOptions sets the options that are applied to every assertion, before the options passed to the assertion.
+// It returns a function to restore the previous defaults, for example:
+//
+//	func TestMain(m *testing.M) {
+//		aferoassert.SetDefaultOptions(aferoassert.NormalizeLineEndings())
+//
+//		os.Exit(m.Run())
+//	}
+func SetDefaultOptions(opts ...Option) (restore func()) {
+	defaultOptions.mu.Lock()
+	defer defaultOptions.mu.Unlock()
+
+	prev := defaultOptions.opts
+	defaultOptions.opts = opts
+
+	return func() {
+		defaultOptions.mu.Lock()
+		defer defaultOptions.mu.Unlock()
+
+		defaultOptions.opts = prev
+	}
+}
+
+// WithDiffContext sets the number of context lines shown around the changes in a content diff.
+func WithDiffContext(lines int) Option {
+	return optionFunc(func(c *config) {
+		if lines < 0 {
+			lines = 0
+		}
+
+		c.diffContext = lines
+	})
+}
+
+// WithActualTree appends a YAML snapshot of the actual directory to the first failure of the tree assertions, so the
+// expectation can be copied from the failure message.
+func WithActualTree() Option {
+	return optionFunc(func(c *config) {
+		c.dumpActualTree = true
+	})
+}
+
+// FailFast stops the tree assertions at the first mismatch instead of walking the entire tree and reporting all of
+// them.
+func FailFast() Option {
+	return optionFunc(func(c *config) {
+		c.failFast = true
+	})
+}
+
+// FollowLinks makes the path-based assertions, such as Exists or Perm, check the target of a symlink instead of the
+// link itself.
+func FollowLinks() Option {
+	return optionFunc(func(c *config) {
+		c.followLinks = true
+	})
+}
+
+// NoFollowLinks makes the path-based assertions check a symlink itself instead of its target, if the file system
+// supports it. This is the default behavior, the option can be used to override the default options.
+func NoFollowLinks() Option {
+	return optionFunc(func(c *config) {
+		c.followLinks = false
+	})
+}
+
+// TraverseSymlinkDirs makes the tree walks, such as TreeEqual, descend into the symlinked directories, with the
+// targets read from the file system, if it supports symlinks, such as afero.OsFs. Unlike FollowLinks, the symlinks are
+// kept, a symlinked directory has both the Dir and the Symlink bits, for example `mode:"Dir|Symlink"`, and a symlink to
+// a file is not followed.
+func TraverseSymlinkDirs() Option {
+	return optionFunc(func(c *config) {
+		c.traverseSymlinkDirs = true
+	})
+}
+
+// WithUnicodeNormalization normalizes the expected and the actual names in the tree assertions before comparing them,
+// for example, trees created on macOS use NFD while the expectations are usually written in NFC.
+//
+//	aferoassert.TreeEqual(t, fs, tree, "root", aferoassert.WithUnicodeNormalization(norm.NFC))
+func WithUnicodeNormalization(form norm.Form) Option {
+	return optionFunc(func(c *config) {
+		c.unicodeForm = &form
+	})
+}
+
+// AllocatedSize makes FileSize check the number of bytes allocated on disk instead of the logical size, so the tests
+// can verify that a sparse file was created sparsely. The file system has to expose the allocated blocks via Sys(),
+// like afero.OsFs on Unix does.
+func AllocatedSize() Option {
+	return optionFunc(func(c *config) {
+		c.allocatedSize = true
+	})
+}
+
+// IgnorePerm skips the permission checks of Perm and of the `perm` tags.
+func IgnorePerm() Option {
+	return optionFunc(func(c *config) {
+		c.ignorePerm = true
+	})
+}
+
+// WindowsPerm compares only the owner write bit of the permissions because that is the only bit Windows maps to the
+// file attributes, a file is either 0666 or 0444 there. The mode is enabled automatically when running on Windows
+// against an afero.OsFs, or an afero.BasePathFs, afero.ReadOnlyFs, afero.CopyOnWriteFs or RecordFs that wraps one, so
+// the same expectations pass on both Linux and Windows.
+func WindowsPerm() Option {
+	return optionFunc(func(c *config) {
+		c.windowsPerm = true
+	})
+}
+
+// NormalizeLineEndings converts CRLF to LF in both the expected and the actual content before comparing them.
+func NormalizeLineEndings() Option {
+	return optionFunc(func(c *config) {
+		c.normalizeEOL = true
+	})
+}
+
+// IgnorePaths excludes the paths matching the patterns from the tree assertions. The patterns follow filepath.Match
+// and are matched against the path relative to the root and against the base name. An ignored directory is skipped
+// with all of its children.
+func IgnorePaths(patterns ...string) Option {
+	return optionFunc(func(c *config) {
+		c.ignorePatterns = append(c.ignorePatterns, patterns...)
+	})
+}
+
+// WithRetry runs the assertion up to attempts times until it passes, waiting for backoff before the second attempt and
+// doubling the wait after every failed attempt. Only the failures of the last attempt are reported. It helps with the
+// files that are written asynchronously, for example:
+//
+//	aferoassert.FileContent(t, fs, "out.log", "done\n", aferoassert.WithRetry(5, 10*time.Millisecond))
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.retryAttempts = attempts
+		c.retryBackoff = backoff
+	})
+}
+
+// WithOnFailure registers a function that is called with the details of every failure before it is reported, so the
+// tests can attach extra diagnostics, for example, a listing of the file system. The failures of the attempts that are
+// retried, see WithRetry, are not passed to the function.
+func WithOnFailure(fn func(FailureInfo)) Option {
+	return optionFunc(func(c *config) {
+		c.onFailure = append(c.onFailure, fn)
+	})
+}
+
+// WithTrace records every stat, open and readdir step of the assertion with its result, and logs them with t.Log
+// when the assertion fails, so a failure on CI can be diagnosed without re-running the test, for example:
+//
+//	aferoassert.SetDefaultOptions(aferoassert.WithTrace())
+func WithTrace() Option {
+	return optionFunc(func(c *config) {
+		c.trace = true
+	})
+}
+
+// WithStats adds the metrics of the assertion to the stats, so the suites can track the cost of their file system
+// assertions and set budgets on them, for example:
+//
+//	var stats aferoassert.Stats
+//
+//	aferoassert.YAMLTreeEqual(t, fs, expected, "root", aferoassert.WithStats(&stats))
+//
+//	assert.Less(t, stats.Duration, time.Second)
+func WithStats(stats *Stats) Option {
+	return optionFunc(func(c *config) {
+		c.stats = stats
+	})
+}
+
+// WithReadLimit limits the number of bytes that FileContentRegexp reads from the file, for example, to check the header
+// of a large log file. The default is to read the whole file.
+func WithReadLimit(n int64) Option {
+	return optionFunc(func(c *config) {
+		c.readLimit = n
+	})
+}
+
+// CompareModTimes makes Mirrored compare the modification times of the files, for example, to test a copy that
+// preserves them.
+func CompareModTimes() Option {
+	return optionFunc(func(c *config) {
+		c.compareModTime = true
+	})
+}
+
+// WithMaxAge makes NewestFileMatches check that the newest entry was modified within the duration.
+func WithMaxAge(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.maxAge = d
+	})
+}
+
+// DetectConcurrentChanges makes the tree assertions stat the tree before and after the assertion and fail if an entry
+// was added, removed or modified in the meantime, for example, by a goroutine that is still writing. Without it, such
+// a race shows up as intermittent failures that are hard to explain.
+func DetectConcurrentChanges() Option {
+	return optionFunc(func(c *config) {
+		c.detectChanges = true
+	})
+}
+
+// WithSubtests makes the tree assertions check every expected path in a subtest named after the path, when the
+// TestingT supports it, so the result of every path is shown by go test -v and a path can be selected with -run. The
+// TestingT supports the subtests if it has a `Run(name string, f func(t *testing.T)) bool` method, such as *testing.T,
+// or a `Run(name string, f func(t aferoassert.TestingT)) bool` method, otherwise the paths are checked in place. The
+// missing paths are reported in a subtest each instead of in one failure.
+func WithSubtests() Option {
+	return optionFunc(func(c *config) {
+		c.subtests = true
+	})
+}
+
+// WithFailNow makes an assertion stop the test with FailNow when it fails, if the TestingT supports it, such as
+// *testing.T, like the require package does. It can be set for all the assertions with SetDefaultOptions.
+func WithFailNow() Option {
+	return optionFunc(func(c *config) {
+		c.failNow = true
+	})
+}
+
+// WithFormatter renders the failure messages with the formatter, for example to have shorter or localized messages. The
+// formatter gets the default message, so it can keep it for the kinds of failures it does not handle:
+//
+//	aferoassert.WithFormatter(aferoassert.FormatterFunc(func(info aferoassert.FailureInfo) string {
+//		if info.Kind == aferoassert.FailureNotFound {
+//			return "missing: " + info.Path
+//		}
+//
+//		return info.Message
+//	}))
+func WithFormatter(f Formatter) Option {
+	return optionFunc(func(c *config) {
+		c.formatter = f
+	})
+}
+
+// WithMaxContentSize limits the number of bytes of the content, such as a diff, that are embedded in a failure message,
+// the rest is replaced by the number of truncated bytes. It can be set for all the assertions with SetDefaultOptions,
+// so a failure on a large file does not flood the log.
+func WithMaxContentSize(n int) Option {
+	return optionFunc(func(c *config) {
+		c.maxContentSize = n
+	})
+}
+
+// WithExpectationFs sets the file system of the files that have the expected contents of the `contentFile` tags, it is
+// the OS file system by default, so the paths are relative to the directory of the test package.
+func WithExpectationFs(fs afero.Fs) Option {
+	return optionFunc(func(c *config) {
+		c.expectationFs = fs
+	})
+}
+
+// WithDumpContent makes DumpOnFailure include the content of the regular files that are not larger than maxSize bytes.
+func WithDumpContent(maxSize int64) Option {
+	return optionFunc(func(c *config) {
+		c.dumpContentLimit = maxSize
+	})
+}
+
+// WithDumpDir makes DumpOnFailure write the dump into a file named after the test in a directory on disk, instead of
+// the test log.
+func WithDumpDir(dir string) Option {
+	return optionFunc(func(c *config) {
+		c.dumpDir = dir
+	})
+}
+
+// WithGoldenUpdate makes DirGolden replace the golden directory by the actual one instead of comparing them, or compare
+// them when update is false, regardless of the -update flag, for example:
+//
+//	var update = flag.Bool("update", false, "update the golden files")
+//
+//	aferoassert.DirGolden(t, fs, "out", "testdata/expected", aferoassert.WithGoldenUpdate(*update))
+func WithGoldenUpdate(update bool) Option {
+	return optionFunc(func(c *config) {
+		c.updateGolden = &update
+	})
+}
+
+// configFromOptions builds the config for the functions that take the options explicitly.
+func configFromOptions(opts []Option) *config {
+	c, _ := newConfig(optionArgs(opts))
+
+	return c
+}
+
+// optionArgs converts the options to the msgAndArgs of an assertion.
+func optionArgs(opts []Option) []interface{} {
+	msgAndArgs := make([]interface{}, 0, len(opts))
+
+	for _, o := range opts {
+		msgAndArgs = append(msgAndArgs, o)
+	}
+
+	return msgAndArgs
+}
+
+// newConfig builds the config from the options found in msgAndArgs and returns the remaining arguments.
+func newConfig(msgAndArgs []interface{}) (*config, []interface{}) {
+	c := &config{
+		diffContext: defaultDiffContext,
+	}
+
+	defaultOptions.mu.RLock()
+
+	for _, o := range defaultOptions.opts {
+		o.applyOption(c)
+	}
+
+	defaultOptions.mu.RUnlock()
+
+	args := make([]interface{}, 0, len(msgAndArgs))
+
+	for _, arg := range msgAndArgs {
+		if o, ok := arg.(Option); ok {
+			o.applyOption(c)
+
+			continue
+		}
+
+		args = append(args, arg)
+	}
+
+	return c, args
+}
@@ -0,0 +1,509 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/text/unicode/norm"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestSetDefaultOptions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.txt", []byte("hello\r\nworld\r\n"), 0o600))
+
+	mockT := new(testing.T)
+	assert.False(t, aferoassert.FileContent(mockT, fs, "file.txt", "hello\nworld\n"))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.Perm(mockT, fs, "file.txt", 0o644))
+
+	restore := aferoassert.SetDefaultOptions(aferoassert.NormalizeLineEndings(), aferoassert.IgnorePerm())
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.FileContent(mockT, fs, "file.txt", "hello\nworld\n"))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.Perm(mockT, fs, "file.txt", 0o644))
+
+	restore()
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.FileContent(mockT, fs, "file.txt", "hello\nworld\n"))
+}
+
+func TestIgnorePaths(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file.txt", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/.DS_Store", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/node_modules/lib/index.js", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/folder/.DS_Store", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/folder/file.tmp", nil, 0o644))
+
+	tree := `
+- file.txt
+- folder:
+- node_modules:
+    - missing.js
+`
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root",
+		aferoassert.IgnorePaths(".DS_Store", "node_modules", "folder/*.tmp"),
+	), mockT.message())
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root", aferoassert.IgnorePaths(".DS_Store")))
+}
+
+func TestWindowsPerm(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/writable", nil, 0o666))
+	require.NoError(t, afero.WriteFile(fs, "root/readonly", nil, 0o444))
+
+	mockT := new(testing.T)
+	assert.True(t, aferoassert.Perm(mockT, fs, "root/writable", 0o644, aferoassert.WindowsPerm()))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.Perm(mockT, fs, "root/writable", 0o444, aferoassert.WindowsPerm()))
+
+	tree := `
+- writable 'perm:"0600"'
+- readonly 'perm:"0400"'
+`
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root", aferoassert.WindowsPerm()))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root"))
+}
+
+func TestFailFast(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file 2", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file 3", nil, 0o644))
+
+	tree := `
+- file 1 'perm:"0600"'
+- file 2 'perm:"0600"'
+- file 4
+`
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root"))
+	assert.Len(t, mockT.errors, 4)
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root", aferoassert.FailFast()))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"root/file 1" perm is 0644, expected 0600`)
+}
+
+func TestFollowLinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "file"), nil, 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "file"), filepath.Join(dir, "link")))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "broken")))
+
+	link := filepath.Join(dir, "link")
+	broken := filepath.Join(dir, "broken")
+
+	mockT := new(testing.T)
+	assert.False(t, aferoassert.Perm(mockT, fs, link, 0o600))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.Perm(mockT, fs, link, 0o600, aferoassert.FollowLinks()))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.Exists(mockT, fs, broken))
+
+	mockT = new(testing.T)
+	assert.False(t, aferoassert.Exists(mockT, fs, broken, aferoassert.FollowLinks()))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.Exists(mockT, fs, broken, aferoassert.FollowLinks(), aferoassert.NoFollowLinks()))
+}
+
+func TestFollowLinks_SymlinkCycle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "a", "b", "file"), nil, 0o644))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "a"), filepath.Join(dir, "a", "b", "link")))
+
+	tree := `
+- a:
+    - b:
+        - file
+        - link
+`
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, dir), mockT.message())
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, dir, aferoassert.FollowLinks()))
+
+	expected := fmt.Sprintf("symlink cycle detected: %s -> %s -> %s",
+		filepath.Join(dir, "a"),
+		filepath.Join(dir, "a", "b"),
+		filepath.Join(dir, "a", "b", "link"),
+	)
+
+	assert.Contains(t, mockT.message(), expected)
+}
+
+func TestWithUnicodeNormalization(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	// "café" in NFD.
+	require.NoError(t, afero.WriteFile(fs, "root/cafe\u0301/menu", nil, 0o644))
+
+	// "café" in NFC.
+	tree := "- caf\u00e9:\n    - menu\n"
+
+	mockT := new(testing.T)
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root"))
+
+	mockT = new(testing.T)
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "root", aferoassert.WithUnicodeNormalization(norm.NFC)))
+}
+
+// eventualFs creates the file after the given number of opens.
+type eventualFs struct {
+	afero.Fs
+
+	path  string
+	opens int
+}
+
+func (fs *eventualFs) Open(name string) (afero.File, error) {
+	if fs.opens--; fs.opens == 0 {
+		_ = afero.WriteFile(fs.Fs, fs.path, []byte("done"), 0o644) // nolint: errcheck
+	}
+
+	return fs.Fs.Open(name)
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	fs := &eventualFs{Fs: afero.NewMemMapFs(), path: "out.log", opens: 3}
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.FileContent(mockT, fs, "out.log", "done", aferoassert.WithRetry(5, time.Millisecond)))
+	assert.Empty(t, mockT.errors)
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.FileExists(mockT, afero.NewMemMapFs(), "out.log", aferoassert.WithRetry(3, time.Millisecond)))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `unable to find file "out.log"`)
+}
+
+func TestWithOnFailure(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.txt", []byte("hello"), 0o644))
+
+	var failures []aferoassert.FailureInfo
+
+	onFailure := aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+		failures = append(failures, info)
+	})
+
+	assert.True(t, aferoassert.FileContent(&testingT{}, fs, "file.txt", "hello", onFailure))
+	assert.Empty(t, failures)
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.FileContent(mockT, fs, "file.txt", "world", onFailure, aferoassert.WithRetry(2, time.Millisecond)))
+	assert.Len(t, mockT.errors, 1)
+	require.Len(t, failures, 1)
+
+	assert.Equal(t, "FileContent", failures[0].Assertion)
+	assert.Equal(t, "file.txt", failures[0].Path)
+	assert.Equal(t, "world", failures[0].Expected)
+	assert.Equal(t, "hello", failures[0].Actual)
+	assert.Contains(t, failures[0].Message, `"file.txt" content is not as expected`)
+
+	failures = nil
+
+	assert.False(t, aferoassert.YAMLTreeEqual(&testingT{}, fs, `- file.txt 'perm:"0600"'`, ".", onFailure))
+	require.Len(t, failures, 1)
+
+	assert.Equal(t, "YAMLTreeEqual", failures[0].Assertion)
+	assert.Equal(t, "file.txt", failures[0].Path)
+	assert.Equal(t, os.FileMode(0o600), failures[0].Expected)
+	assert.Equal(t, os.FileMode(0o644), failures[0].Actual)
+}
+
+func TestWithMaxContentSize(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "large.txt", []byte(strings.Repeat("a\n", 1000)), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "unicode.txt", []byte("héllo wörld"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.FileContent(mockT, fs, "large.txt", "b\n", aferoassert.WithMaxContentSize(40)))
+	assert.Contains(t, mockT.message(), "@@ -1,2 +1,1001 \n")
+	assert.Contains(t, mockT.message(), "... (3008 bytes truncated)")
+	assert.NotContains(t, mockT.message(), "+a\n")
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileContentRegexp(mockT, fs, "unicode.txt", "^bye", aferoassert.WithMaxContentSize(2)))
+	assert.Contains(t, mockT.message(), "Expect \"h\n")
+	assert.Contains(t, mockT.message(), "... (12 bytes truncated)\" to match \"^bye\"")
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.FileContent(mockT, fs, "large.txt", "b\n"))
+	assert.NotContains(t, mockT.message(), "truncated")
+}
+
+func TestWithFailNow(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file.txt", []byte("hello"), 0o644))
+
+	mockT := &failNowT{}
+	assert.True(t, aferoassert.FileContent(mockT, fs, "file.txt", "hello", aferoassert.WithFailNow()))
+	assert.False(t, mockT.failedNow)
+
+	assert.False(t, aferoassert.FileContent(mockT, fs, "file.txt", "world"))
+	assert.False(t, mockT.failedNow)
+
+	assert.False(t, aferoassert.FileContent(mockT, fs, "file.txt", "world", aferoassert.WithFailNow()))
+	assert.True(t, mockT.failedNow)
+	assert.Len(t, mockT.errors, 2)
+
+	// The option is kept through the Assertions object.
+	mockT = &failNowT{}
+	a := aferoassert.New(mockT, fs)
+
+	assert.False(t, a.YAMLTreeEqual("- unknown.txt\n", ".", aferoassert.WithFailNow()))
+	assert.True(t, mockT.failedNow)
+
+	// A TestingT without FailNow, such as a Collector, is not stopped.
+	c := aferoassert.NewCollector(&testingT{})
+
+	assert.False(t, aferoassert.FileExists(c, fs, "unknown.txt", aferoassert.WithFailNow()))
+	assert.True(t, c.Failed())
+}
+
+func TestWithFailNow_InvalidExpectation(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "invalid.yaml", []byte("- a: [\n"), 0o644))
+
+	testCases := []struct {
+		scenario string
+		assert   func(t aferoassert.TestingT) bool
+		expected string
+	}{
+		{
+			scenario: "YAMLTreeEqual",
+			assert: func(t aferoassert.TestingT) bool {
+				return aferoassert.YAMLTreeEqual(t, fs, "- a: [", ".", aferoassert.WithFailNow())
+			},
+			expected: "could not unmarshal expectation: yaml:",
+		},
+		{
+			scenario: "YAMLTreeContains",
+			assert: func(t aferoassert.TestingT) bool {
+				return aferoassert.YAMLTreeContains(t, fs, "- a: [", ".", aferoassert.WithFailNow())
+			},
+			expected: "could not unmarshal expectation: yaml:",
+		},
+		{
+			scenario: "YAMLTreeEqualFile",
+			assert: func(t aferoassert.TestingT) bool {
+				return aferoassert.YAMLTreeEqualFile(t, fs, fs, "invalid.yaml", ".", aferoassert.WithFailNow())
+			},
+			expected: `could not unmarshal expectation "invalid.yaml": yaml:`,
+		},
+		{
+			scenario: "TreeEqualFromReader",
+			assert: func(t aferoassert.TestingT) bool {
+				return aferoassert.TreeEqualFromReader(t, fs, strings.NewReader("- a: ["), ".", aferoassert.WithFailNow())
+			},
+			expected: "could not unmarshal expectation: yaml:",
+		},
+		{
+			scenario: "TreeEqualFromBytes",
+			assert: func(t aferoassert.TestingT) bool {
+				return aferoassert.TreeEqualFromBytes(t, fs, []byte("- a: ["), ".", aferoassert.WithFailNow())
+			},
+			expected: "could not unmarshal expectation: yaml:",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &failNowT{}
+
+			assert.False(t, tc.assert(mockT))
+			assert.True(t, mockT.failedNow)
+			assert.Contains(t, mockT.message(), tc.expected)
+		})
+	}
+}
+
+func TestWithFormatter(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/app", []byte("hello"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/extra", nil, 0o644))
+
+	var failures []aferoassert.FailureInfo
+
+	formatter := aferoassert.WithFormatter(aferoassert.FormatterFunc(func(info aferoassert.FailureInfo) string {
+		switch info.Kind {
+		case aferoassert.FailureNotFound:
+			return fmt.Sprintf("missing: %v", info.Expected)
+		case aferoassert.FailureUnexpected:
+			return "extra: " + info.Path
+		case aferoassert.FailurePerm:
+			return fmt.Sprintf("perm of %s: %o != %o", info.Path, info.Actual, info.Expected)
+		}
+
+		return info.Message
+	}))
+
+	onFailure := aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+		failures = append(failures, info)
+	})
+
+	tree := `
+- app 'perm:"0600" content:"world"'
+- missing
+`
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist", formatter, onFailure))
+	require.Len(t, mockT.errors, 4)
+	assert.Contains(t, mockT.errors[0], "perm of dist/app: 644 != 600")
+	assert.Contains(t, mockT.errors[1], `"dist/app" content is not as expected`)
+	assert.Contains(t, mockT.errors[2], "extra: dist/extra")
+	assert.Contains(t, mockT.errors[3], "missing: [missing]")
+
+	// The hooks get the formatted messages.
+	require.Len(t, failures, 4)
+	assert.Equal(t, aferoassert.FailurePerm, failures[0].Kind)
+	assert.Equal(t, aferoassert.FailureContent, failures[1].Kind)
+	assert.Equal(t, "extra: dist/extra", failures[2].Message)
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.FileExists(mockT, fs, "dist/unknown", formatter))
+	assert.Contains(t, mockT.message(), "missing: <nil>")
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.NoFileExists(mockT, fs, "dist/app", formatter))
+	assert.Contains(t, mockT.message(), "extra: dist/app")
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.DirExists(mockT, fs, "dist/app", formatter))
+	assert.Contains(t, mockT.message(), `"dist/app" is a file`)
+}
+
+func TestTraverseSymlinkDirs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(dir, "releases", "v1"), 0o755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "releases", "v1", "app"), nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "config"), nil, 0o644))
+	require.NoError(t, os.Symlink(filepath.Join("releases", "v1"), filepath.Join(dir, "current")))
+	require.NoError(t, os.Symlink("config", filepath.Join(dir, "config.link")))
+
+	tree := `
+- config
+- config.link 'mode:"Symlink"'
+- current 'mode:"Dir|Symlink"':
+    - app
+- releases:
+    - v1:
+        - app
+`
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, dir))
+	assert.Contains(t, mockT.message(), "is not a directory")
+
+	mockT = &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, dir, aferoassert.TraverseSymlinkDirs()), mockT.message())
+
+	out, err := aferoassert.MarshalTree(fs, dir, aferoassert.TraverseSymlinkDirs())
+	require.NoError(t, err)
+
+	mockT = &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, string(out), dir, aferoassert.TraverseSymlinkDirs()), mockT.message())
+	assert.Contains(t, string(out), `current 'mode:"Dir|Symlink"`)
+}
+
+func TestTraverseSymlinkDirs_SymlinkCycle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	require.NoError(t, os.Symlink("..", filepath.Join(dir, "a", "b", "link")))
+
+	mockT := &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, "- a:\n    - b:\n        - link\n", dir, aferoassert.TraverseSymlinkDirs()))
+
+	expected := fmt.Sprintf("symlink cycle detected: %s -> %s -> %s",
+		filepath.Join(dir, "a"),
+		filepath.Join(dir, "a", "b"),
+		filepath.Join(dir, "a", "b", "link"),
+	)
+
+	assert.Contains(t, mockT.message(), expected)
+}
@@ -0,0 +1,153 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+
+	"github.com/spf13/afero"
+)
+
+// windowsPermMask is the only permission bit that Windows maps to the file attributes, the read-only attribute.
+const windowsPermMask os.FileMode = 0o200
+
+// permBits are the bits compared by the perm tag and Perm, the permission bits with the setuid, setgid and sticky ones.
+const permBits = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+
+// normalizePerm converts the special bits of the Unix octal notation, such as 02000 in 02755, to the ones of
+// os.FileMode, so both 0o2755 and os.ModeSetgid|0o755 can be used.
+func normalizePerm(perm os.FileMode) os.FileMode {
+	result := perm & permBits
+
+	if perm&0o4000 != 0 {
+		result |= os.ModeSetuid
+	}
+
+	if perm&0o2000 != 0 {
+		result |= os.ModeSetgid
+	}
+
+	if perm&0o1000 != 0 {
+		result |= os.ModeSticky
+	}
+
+	return result
+}
+
+// formatPerm formats the permission in the Unix octal notation, for example 0755 or 02755.
+func formatPerm(perm os.FileMode) string {
+	return fmt.Sprintf("0%o", unixPerm(perm))
+}
+
+// unixPerm converts the permission to the Unix octal notation, the reverse of normalizePerm.
+func unixPerm(perm os.FileMode) uint32 {
+	octal := uint32(perm & os.ModePerm)
+
+	if perm&os.ModeSetuid != 0 {
+		octal |= 0o4000
+	}
+
+	if perm&os.ModeSetgid != 0 {
+		octal |= 0o2000
+	}
+
+	if perm&os.ModeSticky != 0 {
+		octal |= 0o1000
+	}
+
+	return octal
+}
+
+// permMatches checks whether the actual permission matches the expectation. In the Windows mode, only the owner write
+// bit is compared.
+func permMatches(c *config, fs afero.Fs, expected, actual os.FileMode) bool {
+	if c.windowsPerm || runtime.GOOS == "windows" && onOsFs(reflect.ValueOf(fs)) {
+		return expected&windowsPermMask == actual&windowsPermMask
+	}
+
+	return expected == actual
+}
+
+// fsWrapperTypes are the file systems that wrap other ones, their wrapped file systems are in unexported fields.
+var fsWrapperTypes = map[reflect.Type]bool{
+	reflect.TypeOf((*afero.BasePathFs)(nil)):    true,
+	reflect.TypeOf((*afero.ReadOnlyFs)(nil)):    true,
+	reflect.TypeOf((*afero.CopyOnWriteFs)(nil)): true,
+	reflect.TypeOf((*RecordedFs)(nil)):          true,
+}
+
+// onOsFs tells whether the files of a file system are on the OS file system, that is, whether it is an afero.OsFs or
+// wraps one with afero.BasePathFs, afero.ReadOnlyFs, afero.CopyOnWriteFs or RecordFs.
+func onOsFs(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+
+	if v.Type() == reflect.TypeOf((*afero.OsFs)(nil)) {
+		return true
+	}
+
+	if !fsWrapperTypes[v.Type()] || v.IsNil() {
+		return false
+	}
+
+	s := v.Elem()
+
+	for i := 0; i < s.NumField(); i++ {
+		if f := s.Field(i); f.Kind() == reflect.Interface && !f.IsNil() && onOsFs(f.Elem()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isOsFs(fs afero.Fs) bool {
+	_, ok := fs.(*afero.OsFs)
+
+	return ok
+}
+
+// PermWithUmask checks whether a path has the permission that results from the requested one with the umask, for
+// example, a file created with 0666 has 0644 with the umask 022, so the tests pass regardless of the umask of the
+// machine.
+//
+//	aferoassert.PermWithUmask(t, fs, "out.log", 0o666, 0o022)
+func PermWithUmask(t TestingT, fs afero.Fs, path string, requested, umask os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "PermWithUmask", func(t TestingT) bool {
+		return assertPermWithUmask(t, c, fs, path, requested, umask, msgAndArgs...)
+	})
+}
+
+func assertPermWithUmask(t TestingT, c *config, fs afero.Fs, path string, requested, umask os.FileMode, msgAndArgs ...interface{}) bool {
+	info, err := stat(c, fs, path)
+	if err != nil {
+		return c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
+	}
+
+	if c.ignorePerm {
+		return true
+	}
+
+	expected := requested & os.ModePerm &^ umask
+	actual := info.Mode() & os.ModePerm
+
+	if !permMatches(c, fs, expected, actual) {
+		return c.fail(t, FailureInfo{
+			Kind:     FailurePerm,
+			Path:     path,
+			Expected: expected,
+			Actual:   actual,
+			Message:  fmt.Sprintf("%q permission is 0%o, expected 0%o (0%o requested with umask 0%o)", path, actual, expected, requested&os.ModePerm, umask&os.ModePerm),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
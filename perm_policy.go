@@ -0,0 +1,256 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidPermPolicy indicates that the permission policy is invalid.
+var ErrInvalidPermPolicy = errors.New("invalid perm policy")
+
+// PermPolicy is a list of permission rules, every rule matching a path is enforced.
+type PermPolicy []PermRule
+
+// PermRule is a permission rule for the paths matching a glob pattern.
+type PermRule struct {
+	// Pattern is a slash-separated glob pattern relative to the root, "**" matches any number of directories.
+	Pattern string
+	// Perm is the exact permission, if set.
+	Perm *os.FileMode
+	// Max is the mask of the permission bits that are allowed to be set, if set.
+	Max *os.FileMode
+	// UID is the user that owns the path, if set.
+	UID *int
+	// GID is the group that owns the path, if set.
+	GID *int
+}
+
+// ParsePermPolicy parses a YAML permission policy. The keys are the glob patterns and the values are either a
+// permission or a mapping of `perm`, `max`, `uid` and `gid`, for example:
+//
+//	"**/*.sh": 0755
+//	"secrets/**":
+//	  max: 0600
+//	  uid: 1000
+func ParsePermPolicy(data []byte) (PermPolicy, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: expected a mapping at line %d", ErrInvalidPermPolicy, root.Line)
+	}
+
+	policy := make(PermPolicy, 0, len(root.Content)/2)
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		rule, err := parsePermRule(root.Content[i].Value, root.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		policy = append(policy, rule)
+	}
+
+	return policy, nil
+}
+
+func parsePermRule(pattern string, value *yaml.Node) (PermRule, error) {
+	rule := PermRule{Pattern: pattern}
+
+	if value.Kind == yaml.ScalarNode {
+		perm, err := parsePolicyPerm(value)
+		if err != nil {
+			return rule, err
+		}
+
+		rule.Perm = perm
+
+		return rule, nil
+	}
+
+	if value.Kind != yaml.MappingNode {
+		return rule, fmt.Errorf("%w: unexpected value of %q at line %d", ErrInvalidPermPolicy, pattern, value.Line)
+	}
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		v := value.Content[i+1]
+
+		var err error
+
+		switch key := value.Content[i].Value; key {
+		case "perm":
+			rule.Perm, err = parsePolicyPerm(v)
+
+		case "max":
+			rule.Max, err = parsePolicyPerm(v)
+
+		case "uid":
+			rule.UID, err = parsePolicyID(v)
+
+		case "gid":
+			rule.GID, err = parsePolicyID(v)
+
+		default:
+			err = fmt.Errorf("%w: unknown key %q at line %d", ErrInvalidPermPolicy, key, value.Content[i].Line)
+		}
+
+		if err != nil {
+			return rule, err
+		}
+	}
+
+	return rule, nil
+}
+
+func parsePolicyPerm(node *yaml.Node) (*os.FileMode, error) {
+	perm, err := strconv.ParseUint(strings.TrimPrefix(node.Value, "0o"), 8, 32)
+	if err != nil || perm > uint64(os.ModePerm) {
+		return nil, fmt.Errorf("%w: invalid perm %q at line %d", ErrInvalidPermPolicy, node.Value, node.Line)
+	}
+
+	return FileModeFromUint64(perm), nil
+}
+
+func parsePolicyID(node *yaml.Node) (*int, error) {
+	id, err := strconv.Atoi(node.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid id %q at line %d", ErrInvalidPermPolicy, node.Value, node.Line)
+	}
+
+	return &id, nil
+}
+
+// AssertPermPolicy checks whether every path of a directory satisfies the rules of the permission policy. All the
+// violations are reported together.
+func AssertPermPolicy(t TestingT, fs afero.Fs, root string, policy PermPolicy, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertPermPolicy", func(t TestingT) bool {
+		return assertPermPolicy(t, c, fs, root, policy, msgAndArgs...)
+	})
+}
+
+func assertPermPolicy(t TestingT, c *config, fs afero.Fs, root string, policy PermPolicy, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+	violations := make([]string, 0)
+
+	err := walk(c, fs, root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(root, p)
+		if rel == "." {
+			return nil
+		}
+
+		if c.ignored(rel) {
+			return skip(info)
+		}
+
+		for _, rule := range policy {
+			if matchGlob(rule.Pattern, filepath.ToSlash(rel)) {
+				violations = append(violations, rule.violations(p, info)...)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not walk through %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if len(violations) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  violations,
+		Message: fmt.Sprintf("%q violates the perm policy:\n- %s", root, strings.Join(violations, "\n- ")),
+	}, msgAndArgs...)
+}
+
+func (r PermRule) violations(p string, info os.FileInfo) []string {
+	var result []string
+
+	perm := info.Mode().Perm()
+
+	if r.Perm != nil && perm != *r.Perm {
+		result = append(result, fmt.Sprintf("%q perm is 0%o, expected 0%o (%s)", p, perm, *r.Perm, r.Pattern))
+	}
+
+	if r.Max != nil && perm&^*r.Max != 0 {
+		result = append(result, fmt.Sprintf("%q perm is 0%o, expected at most 0%o (%s)", p, perm, *r.Max, r.Pattern))
+	}
+
+	if r.UID == nil && r.GID == nil {
+		return result
+	}
+
+	uid, gid, ok := fileOwner(info)
+	if !ok {
+		return append(result, fmt.Sprintf("could not get the owner of %q (%s)", p, r.Pattern))
+	}
+
+	if r.UID != nil && uid != *r.UID {
+		result = append(result, fmt.Sprintf("%q uid is %d, expected %d (%s)", p, uid, *r.UID, r.Pattern))
+	}
+
+	if r.GID != nil && gid != *r.GID {
+		result = append(result, fmt.Sprintf("%q gid is %d, expected %d (%s)", p, gid, *r.GID, r.Pattern))
+	}
+
+	return result
+}
+
+// matchGlob reports whether the slash-separated name matches the pattern, "**" matches any number of directories.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(pattern[0], name[0]); !ok { //nolint: errcheck
+			return false
+		}
+
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
@@ -0,0 +1,145 @@
+package aferoassert_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestParsePermPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := aferoassert.ParsePermPolicy([]byte(`
+"**/*.sh": 0755
+secrets/**:
+  max: 0o600
+  uid: 1000
+  gid: 100
+`))
+	require.NoError(t, err)
+
+	uid, gid := 1000, 100
+	expected := aferoassert.PermPolicy{
+		{Pattern: "**/*.sh", Perm: aferoassert.FileModePtr(0o755)},
+		{Pattern: "secrets/**", Max: aferoassert.FileModePtr(0o600), UID: &uid, GID: &gid},
+	}
+
+	assert.Equal(t, expected, policy)
+}
+
+func TestParsePermPolicy_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		policy        string
+		expectedError string
+	}{
+		{
+			scenario:      "not a mapping",
+			policy:        `- "*.sh"`,
+			expectedError: "invalid perm policy: expected a mapping at line 1",
+		},
+		{
+			scenario:      "invalid perm",
+			policy:        `"*.sh": 0999`,
+			expectedError: `invalid perm policy: invalid perm "0999" at line 1`,
+		},
+		{
+			scenario:      "invalid id",
+			policy:        "\"*.sh\":\n  uid: root",
+			expectedError: `invalid perm policy: invalid id "root" at line 2`,
+		},
+		{
+			scenario:      "unknown key",
+			policy:        "\"*.sh\":\n  mode: 0755",
+			expectedError: `invalid perm policy: unknown key "mode" at line 2`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := aferoassert.ParsePermPolicy([]byte(tc.policy))
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestAssertPermPolicy(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/bin", 0o755))
+	require.NoError(t, fs.MkdirAll("root/secrets/db", 0o700))
+	require.NoError(t, afero.WriteFile(fs, "root/run.sh", nil, 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/bin/build.sh", nil, 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/secrets/db/password", nil, 0o600))
+
+	policy, err := aferoassert.ParsePermPolicy([]byte(`
+"**/*.sh": 0755
+secrets/**:
+  max: 0700
+`))
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertPermPolicy(mockT, fs, "root", policy), mockT.message())
+
+	require.NoError(t, fs.Chmod("root/bin/build.sh", 0o644))
+	require.NoError(t, fs.Chmod("root/secrets/db/password", 0o640))
+
+	assert.False(t, aferoassert.AssertPermPolicy(mockT, fs, "root", policy))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"root" violates the perm policy:`)
+	assert.Contains(t, mockT.errors[0], `- "root/bin/build.sh" perm is 0644, expected 0755 (**/*.sh)`)
+	assert.Contains(t, mockT.errors[0], `- "root/secrets/db/password" perm is 0640, expected at most 0700 (secrets/**)`)
+}
+
+func TestAssertPermPolicy_Owner(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("owners are not supported on windows")
+	}
+
+	root := filepath.Join(t.TempDir(), "root")
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(root, 0o755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(root, "file"), nil, 0o644))
+
+	policy, err := aferoassert.ParsePermPolicy([]byte("file:\n  uid: " + strconv.Itoa(os.Getuid())))
+	require.NoError(t, err)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertPermPolicy(mockT, fs, root, policy), mockT.message())
+
+	policy, err = aferoassert.ParsePermPolicy([]byte("file:\n  uid: " + strconv.Itoa(os.Getuid()+1)))
+	require.NoError(t, err)
+
+	assert.False(t, aferoassert.AssertPermPolicy(mockT, fs, root, policy))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "file\" uid is "+strconv.Itoa(os.Getuid()))
+
+	// MemMapFs has no owner.
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.AssertPermPolicy(mockT, newSourceFs(t), "root", aferoassert.PermPolicy{{Pattern: "**", UID: new(int)}}))
+	require.NotEmpty(t, mockT.errors)
+	assert.Contains(t, mockT.errors[0], `could not get the owner of "root/`)
+}
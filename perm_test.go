@@ -0,0 +1,171 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestPermWithUmask(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "out.log", nil, 0o644))
+	require.NoError(t, fs.Mkdir("out", 0o750))
+	require.NoError(t, fs.Chmod("out", 0o750))
+
+	testCases := []struct {
+		scenario       string
+		path           string
+		requested      os.FileMode
+		umask          os.FileMode
+		options        []interface{}
+		expectedResult bool
+		expectedError  string
+	}{
+		{
+			scenario:       "file with umask 022",
+			path:           "out.log",
+			requested:      0o666,
+			umask:          0o022,
+			expectedResult: true,
+		},
+		{
+			scenario:       "dir with umask 027",
+			path:           "out",
+			requested:      0o777,
+			umask:          0o027,
+			expectedResult: true,
+		},
+		{
+			scenario:      "wrong umask",
+			path:          "out.log",
+			requested:     0o666,
+			umask:         0o002,
+			expectedError: `"out.log" permission is 0644, expected 0664 (0666 requested with umask 02)`,
+		},
+		{
+			scenario:       "ignore perm",
+			path:           "out.log",
+			requested:      0o666,
+			umask:          0o002,
+			options:        []interface{}{aferoassert.IgnorePerm()},
+			expectedResult: true,
+		},
+		{
+			scenario:      "not found",
+			path:          "unknown",
+			requested:     0o666,
+			umask:         0o022,
+			expectedError: `unable to find file "unknown"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+			result := aferoassert.PermWithUmask(mockT, fs, tc.path, tc.requested, tc.umask, tc.options...)
+
+			assert.Equal(t, tc.expectedResult, result, mockT.message())
+			assert.Contains(t, mockT.message(), tc.expectedError)
+		})
+	}
+}
+
+func TestPerm_SpecialBits(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.Mkdir("shared", 0o755))
+	require.NoError(t, fs.Chmod("shared", os.ModeSetgid|0o755))
+	require.NoError(t, afero.WriteFile(fs, "app", nil, 0o755))
+	require.NoError(t, fs.Chmod("app", os.ModeSetuid|0o755))
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.Perm(mockT, fs, "shared", 0o2755), mockT.message())
+	assert.True(t, aferoassert.Perm(mockT, fs, "shared", os.ModeSetgid|0o755), mockT.message())
+	assert.True(t, aferoassert.Perm(mockT, fs, "app", 0o4755), mockT.message())
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.Perm(mockT, fs, "shared", 0o755))
+	assert.Contains(t, mockT.message(), `"shared" permission is 02755, expected 0755`)
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.Perm(mockT, fs, "app", 0o1755))
+	assert.Contains(t, mockT.message(), `"app" permission is 04755, expected 01755`)
+
+	tree := `
+- shared 'perm:"2755"':
+- app 'perm:"04755"'
+`
+
+	mockT = &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "."), mockT.message())
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, "- shared 'perm:\"0755\"':\n- app 'perm:\"0755\"'\n", "."))
+	assert.Contains(t, mockT.message(), `"shared" perm is 02755, expected 0755`)
+	assert.Contains(t, mockT.message(), `"app" perm is 04755, expected 0755`)
+
+	snapshot, err := aferoassert.MarshalTree(fs, ".")
+	require.NoError(t, err)
+
+	mockT = &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, string(snapshot), "."), mockT.message())
+	assert.Contains(t, string(snapshot), `perm:"02755"`)
+}
+
+func TestPermTag_SpecialBits(t *testing.T) {
+	t.Parallel()
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- shared 'perm:"2755"':
+- tmp 'perm:"1777"':
+- app 'perm:"04755"'
+- lib 'perm:"644"'
+`), &tree))
+
+	assert.Equal(t, os.ModeSetgid|0o755, *tree["shared"].Tags.Perm())
+	assert.Equal(t, os.ModeSticky|0o777, *tree["tmp"].Tags.Perm())
+	assert.Equal(t, os.ModeSetuid|0o755, *tree["app"].Tags.Perm())
+	assert.Equal(t, os.FileMode(0o644), *tree["lib"].Tags.Perm())
+
+	out, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+
+	expected := `- app 'perm:"04755"'
+- lib 'perm:"0644"'
+- shared 'perm:"02755"': {}
+- tmp 'perm:"01777"': {}
+`
+
+	assert.Equal(t, expected, string(out))
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, aferoassert.BuildTree(fs, "root", tree))
+
+	mockT := &testingT{}
+	assert.True(t, aferoassert.Perm(mockT, fs, "root/shared", 0o2755), mockT.message())
+	assert.True(t, aferoassert.Perm(mockT, fs, "root/app", 0o4755), mockT.message())
+
+	for _, perm := range []string{"8755", "17777", "0o755"} {
+		err := yaml.Unmarshal([]byte("- file 'perm:\""+perm+"\"'"), &tree)
+
+		assert.ErrorIs(t, err, aferoassert.ErrInvalidFileMode, perm)
+	}
+}
@@ -0,0 +1,58 @@
+//go:build windows
+// +build windows
+
+package aferoassert_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestPerm_WrappedOsFs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	require.NoError(t, afero.WriteFile(afero.NewOsFs(), filepath.Join(root, "file"), nil, 0o644))
+
+	testCases := []struct {
+		scenario string
+		fs       afero.Fs
+	}{
+		{
+			scenario: "BasePathFs",
+			fs:       afero.NewBasePathFs(afero.NewOsFs(), root),
+		},
+		{
+			scenario: "ReadOnlyFs",
+			fs:       afero.NewReadOnlyFs(afero.NewBasePathFs(afero.NewOsFs(), root)),
+		},
+		{
+			scenario: "CopyOnWriteFs",
+			fs:       afero.NewCopyOnWriteFs(afero.NewBasePathFs(afero.NewOsFs(), root), afero.NewMemMapFs()),
+		},
+		{
+			scenario: "RecordFs",
+			fs:       aferoassert.RecordFs(afero.NewBasePathFs(afero.NewOsFs(), root)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			// Windows reports 0666 for a writable file.
+			assert.True(t, aferoassert.Perm(mockT, tc.fs, "file", 0o644), mockT.message())
+		})
+	}
+}
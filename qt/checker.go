@@ -0,0 +1,192 @@
+// Package qt provides the assertions as checkers for github.com/frankban/quicktest, for example:
+//
+//	import aferoqt "go.nhat.io/aferoassert/qt"
+//
+//	qt.Assert(t, "config.yaml", aferoqt.FileExistsIn(fs))
+//	qt.Assert(t, "config.yaml", aferoqt.ContentIn(fs), "debug: true\n")
+//
+// The got value of every checker is the path, the options are applied to every check.
+package qt
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/frankban/quicktest"
+	"github.com/spf13/afero"
+
+	"go.nhat.io/aferoassert"
+)
+
+type checker struct {
+	argNames []string
+	opts     []aferoassert.Option
+	assert   func(t aferoassert.TestingT, path string, args []interface{}, msgAndArgs ...interface{}) (bool, error)
+}
+
+// ArgNames satisfies quicktest.Checker.
+func (c *checker) ArgNames() []string {
+	return c.argNames
+}
+
+// Check satisfies quicktest.Checker.
+func (c *checker) Check(got interface{}, args []interface{}, _ func(key string, value interface{})) error {
+	path, ok := got.(string)
+	if !ok {
+		return quicktest.BadCheckf("first argument is not a path: %T", got)
+	}
+
+	messages := make([]string, 0)
+	msgAndArgs := make([]interface{}, 0, len(c.opts)+1)
+
+	for _, o := range c.opts {
+		msgAndArgs = append(msgAndArgs, o)
+	}
+
+	msgAndArgs = append(msgAndArgs, aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+		messages = append(messages, info.Message)
+	}))
+
+	ok, err := c.assert(discardT{}, path, args, msgAndArgs...)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		return nil
+	}
+
+	return errors.New(strings.Join(messages, "\n")) // nolint: goerr113
+}
+
+// discardT discards the failures, they are collected by the OnFailure hook instead.
+type discardT struct{}
+
+func (discardT) Errorf(string, ...interface{}) {}
+
+func pathChecker(fs afero.Fs, opts []aferoassert.Option, assert func(t aferoassert.TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) bool) *checker {
+	return &checker{
+		argNames: []string{"path"},
+		opts:     opts,
+		assert: func(t aferoassert.TestingT, path string, _ []interface{}, msgAndArgs ...interface{}) (bool, error) {
+			return assert(t, fs, path, msgAndArgs...), nil
+		},
+	}
+}
+
+// ExistsIn checks whether the path exists in the file system.
+func ExistsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return pathChecker(fs, opts, aferoassert.Exists)
+}
+
+// NoExistsIn checks whether the path does not exist in the file system.
+func NoExistsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return pathChecker(fs, opts, aferoassert.NoExists)
+}
+
+// FileExistsIn checks whether the path is a file in the file system.
+func FileExistsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return pathChecker(fs, opts, aferoassert.FileExists)
+}
+
+// NoFileExistsIn checks whether the path is not a file in the file system.
+func NoFileExistsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return pathChecker(fs, opts, aferoassert.NoFileExists)
+}
+
+// DirExistsIn checks whether the path is a directory in the file system.
+func DirExistsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return pathChecker(fs, opts, aferoassert.DirExists)
+}
+
+// NoDirExistsIn checks whether the path is not a directory in the file system.
+func NoDirExistsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return pathChecker(fs, opts, aferoassert.NoDirExists)
+}
+
+// PermIn checks whether the path has the expected permission, the want value is an os.FileMode.
+func PermIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return &checker{
+		argNames: []string{"path", "want"},
+		opts:     opts,
+		assert: func(t aferoassert.TestingT, path string, args []interface{}, msgAndArgs ...interface{}) (bool, error) {
+			want, ok := args[0].(os.FileMode)
+			if !ok {
+				return false, quicktest.BadCheckf("want is not an os.FileMode: %T", args[0])
+			}
+
+			return aferoassert.Perm(t, fs, path, want, msgAndArgs...), nil
+		},
+	}
+}
+
+// ContentIn checks whether the content of the file is the want string.
+func ContentIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return &checker{
+		argNames: []string{"path", "want"},
+		opts:     opts,
+		assert: func(t aferoassert.TestingT, path string, args []interface{}, msgAndArgs ...interface{}) (bool, error) {
+			want, ok := args[0].(string)
+			if !ok {
+				return false, quicktest.BadCheckf("want is not a string: %T", args[0])
+			}
+
+			return aferoassert.FileContent(t, fs, path, want, msgAndArgs...), nil
+		},
+	}
+}
+
+// ContentMatchesIn checks whether the content of the file matches the want regular expression, which is either a
+// string or a *regexp.Regexp.
+func ContentMatchesIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return &checker{
+		argNames: []string{"path", "regexp"},
+		opts:     opts,
+		assert: func(t aferoassert.TestingT, path string, args []interface{}, msgAndArgs ...interface{}) (bool, error) {
+			return aferoassert.FileContentRegexp(t, fs, path, args[0], msgAndArgs...), nil
+		},
+	}
+}
+
+// TreeEqualIn checks whether the directory is the same as the want tree, which is either an aferoassert.FileTree or
+// its YAML representation.
+func TreeEqualIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return treeChecker(opts, func(t aferoassert.TestingT, tree aferoassert.FileTree, path string, msgAndArgs ...interface{}) bool {
+		return aferoassert.TreeEqual(t, fs, tree, path, msgAndArgs...)
+	}, func(t aferoassert.TestingT, tree string, path string, msgAndArgs ...interface{}) bool {
+		return aferoassert.YAMLTreeEqual(t, fs, tree, path, msgAndArgs...)
+	})
+}
+
+// TreeContainsIn checks whether the directory contains the want tree, which is either an aferoassert.FileTree or its
+// YAML representation.
+func TreeContainsIn(fs afero.Fs, opts ...aferoassert.Option) quicktest.Checker {
+	return treeChecker(opts, func(t aferoassert.TestingT, tree aferoassert.FileTree, path string, msgAndArgs ...interface{}) bool {
+		return aferoassert.TreeContains(t, fs, tree, path, msgAndArgs...)
+	}, func(t aferoassert.TestingT, tree string, path string, msgAndArgs ...interface{}) bool {
+		return aferoassert.YAMLTreeContains(t, fs, tree, path, msgAndArgs...)
+	})
+}
+
+func treeChecker(
+	opts []aferoassert.Option,
+	assertTree func(t aferoassert.TestingT, tree aferoassert.FileTree, path string, msgAndArgs ...interface{}) bool,
+	assertYAMLTree func(t aferoassert.TestingT, tree string, path string, msgAndArgs ...interface{}) bool,
+) *checker {
+	return &checker{
+		argNames: []string{"path", "want"},
+		opts:     opts,
+		assert: func(t aferoassert.TestingT, path string, args []interface{}, msgAndArgs ...interface{}) (bool, error) {
+			switch want := args[0].(type) {
+			case aferoassert.FileTree:
+				return assertTree(t, want, path, msgAndArgs...), nil
+
+			case string:
+				return assertYAMLTree(t, want, path, msgAndArgs...), nil
+			}
+
+			return false, quicktest.BadCheckf("want is not a file tree: %T", args[0])
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package qt_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/frankban/quicktest"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+	aferoqt "go.nhat.io/aferoassert/qt"
+)
+
+func newFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file.txt", []byte("hello"), 0o644))
+
+	return fs
+}
+
+func TestCheckers_Success(t *testing.T) {
+	t.Parallel()
+
+	fs := newFs(t)
+
+	quicktest.Assert(t, "root", aferoqt.ExistsIn(fs))
+	quicktest.Assert(t, "root/unknown", aferoqt.NoExistsIn(fs))
+	quicktest.Assert(t, "root/file.txt", aferoqt.FileExistsIn(fs))
+	quicktest.Assert(t, "root", aferoqt.NoFileExistsIn(fs))
+	quicktest.Assert(t, "root", aferoqt.DirExistsIn(fs))
+	quicktest.Assert(t, "root/file.txt", aferoqt.NoDirExistsIn(fs))
+	quicktest.Assert(t, "root/file.txt", aferoqt.PermIn(fs), os.FileMode(0o644))
+	quicktest.Assert(t, "root/file.txt", aferoqt.ContentIn(fs), "hello")
+	quicktest.Assert(t, "root/file.txt", aferoqt.ContentMatchesIn(fs), "^hel+o$")
+	quicktest.Assert(t, "root", aferoqt.TreeEqualIn(fs), `- file.txt 'perm:"0644"'`)
+	quicktest.Assert(t, "root", aferoqt.TreeContainsIn(fs), aferoassert.FileTree{"file.txt": {Name: "file.txt"}})
+}
+
+func TestCheckers_Failure(t *testing.T) {
+	t.Parallel()
+
+	fs := newFs(t)
+
+	testCases := []struct {
+		scenario      string
+		checker       quicktest.Checker
+		got           interface{}
+		args          []interface{}
+		expectedError string
+	}{
+		{
+			scenario:      "not a path",
+			checker:       aferoqt.ExistsIn(fs),
+			got:           42,
+			expectedError: "bad check: first argument is not a path: int",
+		},
+		{
+			scenario:      "not exists",
+			checker:       aferoqt.ExistsIn(fs),
+			got:           "root/unknown",
+			expectedError: `unable to find file "root/unknown"`,
+		},
+		{
+			scenario:      "wrong perm type",
+			checker:       aferoqt.PermIn(fs),
+			got:           "root/file.txt",
+			args:          []interface{}{0o644},
+			expectedError: "bad check: want is not an os.FileMode: int",
+		},
+		{
+			scenario:      "wrong content",
+			checker:       aferoqt.ContentIn(fs, aferoassert.WithDiffContext(0)),
+			got:           "root/file.txt",
+			args:          []interface{}{"world"},
+			expectedError: `"root/file.txt" content is not as expected`,
+		},
+		{
+			scenario:      "wrong tree",
+			checker:       aferoqt.TreeEqualIn(fs),
+			got:           "root",
+			args:          []interface{}{"- other.txt"},
+			expectedError: `unexpected file "root/file.txt"`,
+		},
+		{
+			scenario:      "wrong tree type",
+			checker:       aferoqt.TreeContainsIn(fs),
+			got:           "root",
+			args:          []interface{}{42},
+			expectedError: "bad check: want is not a file tree: int",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.checker.Check(tc.got, tc.args, func(string, interface{}) {})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
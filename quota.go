@@ -0,0 +1,99 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// quotaOffenders is the number of the largest files reported when the quota is exceeded.
+const quotaOffenders = 5
+
+// TreeWithinQuota checks whether the files of a directory, computed in a single walk, take at most maxBytes bytes and
+// are at most maxFiles, for example, to guard against output bloat. A negative limit is not checked. The failure
+// reports the actual totals and the largest files.
+func TreeWithinQuota(t TestingT, fs afero.Fs, root string, maxBytes, maxFiles int64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "TreeWithinQuota", func(t TestingT) bool {
+		return assertTreeWithinQuota(t, c, fs, root, maxBytes, maxFiles, msgAndArgs...)
+	})
+}
+
+type sizedPath struct {
+	path string
+	size int64
+}
+
+func assertTreeWithinQuota(t TestingT, c *config, fs afero.Fs, root string, maxBytes, maxFiles int64, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+
+	var (
+		totalBytes int64
+		files      []sizedPath
+	)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if rel := relPath(root, path); rel != "." && c.ignored(rel) {
+			return skip(info)
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		totalBytes += info.Size()
+		files = append(files, sizedPath{path: path, size: info.Size()})
+
+		return nil
+	})
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not walk through %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	exceeded := make([]string, 0, 2)
+
+	if maxBytes >= 0 && totalBytes > maxBytes {
+		exceeded = append(exceeded, fmt.Sprintf("%d bytes, expected at most %d bytes", totalBytes, maxBytes))
+	}
+
+	if totalFiles := int64(len(files)); maxFiles >= 0 && totalFiles > maxFiles {
+		exceeded = append(exceeded, fmt.Sprintf("%d files, expected at most %d files", totalFiles, maxFiles))
+	}
+
+	if len(exceeded) == 0 {
+		return true
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].size > files[j].size
+	})
+
+	if len(files) > quotaOffenders {
+		files = files[:quotaOffenders]
+	}
+
+	largest := make([]string, len(files))
+
+	for i, f := range files {
+		largest[i] = fmt.Sprintf("\t%s (%d bytes)", f.path, f.size)
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  totalBytes,
+		Message: fmt.Sprintf("%q exceeds the quota, it has %s\nlargest files:\n%s", root, strings.Join(exceeded, " and "), strings.Join(largest, "\n")),
+	}, msgAndArgs...)
+}
@@ -0,0 +1,42 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestTreeWithinQuota(t *testing.T) {
+	t.Parallel()
+
+	// 14 + 6 bytes, 2 files.
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.TreeWithinQuota(mockT, fs, "root", 20, 2), mockT.message())
+	assert.True(t, aferoassert.TreeWithinQuota(mockT, fs, "root", -1, -1), mockT.message())
+
+	assert.False(t, aferoassert.TreeWithinQuota(mockT, fs, "root", 19, 1))
+	assert.False(t, aferoassert.TreeWithinQuota(mockT, fs, "root", -1, 1))
+	assert.False(t, aferoassert.TreeWithinQuota(mockT, fs, "unknown", -1, 1))
+	require.Len(t, mockT.errors, 3)
+	assert.Contains(t, mockT.errors[0], `"root" exceeds the quota, it has 20 bytes, expected at most 19 bytes and 2 files, expected at most 1 files`)
+	assert.Contains(t, mockT.errors[0], "largest files:\n\t            \t\troot/file 1 (14 bytes)\n\t            \t\troot/folder 2/file 2 (6 bytes)")
+	assert.Contains(t, mockT.errors[1], `"root" exceeds the quota, it has 2 files, expected at most 1 files`)
+	assert.Contains(t, mockT.errors[2], `could not walk through "unknown"`)
+}
+
+func TestTreeWithinQuota_IgnorePaths(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	require.NoError(t, afero.WriteFile(fs, "root/big.tmp", make([]byte, 1024), 0o644))
+
+	assert.True(t, aferoassert.TreeWithinQuota(mockT, fs, "root", 20, 2, aferoassert.IgnorePaths("*.tmp")), mockT.message())
+}
@@ -0,0 +1,100 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/afero"
+)
+
+// readOnlyProbe is the name of the path that AssertFsReadOnly tries to create.
+const readOnlyProbe = ".aferoassert-readonly-probe"
+
+// AssertFsReadOnly checks whether a file system rejects the mutations with a permission error, for example, to verify
+// that a read-only wrapper or mount is configured correctly. It tries to create, open for writing, make directories,
+// rename and remove a probe path in the current directory of the file system, and to chmod, chtimes and open for
+// writing an existing entry, without changing it. A probe that unexpectedly succeeds is cleaned up.
+func AssertFsReadOnly(t TestingT, fs afero.Fs, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertFsReadOnly", func(t TestingT) bool {
+		return assertFsReadOnly(t, c, fs, msgAndArgs...)
+	})
+}
+
+func assertFsReadOnly(t TestingT, c *config, fs afero.Fs, msgAndArgs ...interface{}) bool {
+	violations := make([]string, 0)
+
+	check := func(op string, err error) {
+		if err == nil {
+			violations = append(violations, fmt.Sprintf("%s succeeded", op))
+		} else if !isPermissionError(err) {
+			violations = append(violations, fmt.Sprintf("%s failed with a non-permission error: %s", op, err))
+		}
+	}
+
+	check(fmt.Sprintf("Create(%q)", readOnlyProbe), closeIfOpened(fs.Create(readOnlyProbe)))
+	check(fmt.Sprintf("OpenFile(%q, O_WRONLY|O_CREATE)", readOnlyProbe), closeIfOpened(fs.OpenFile(readOnlyProbe, os.O_WRONLY|os.O_CREATE, 0o644)))
+	check(fmt.Sprintf("Remove(%q)", readOnlyProbe), fs.Remove(readOnlyProbe))
+	check(fmt.Sprintf("Mkdir(%q)", readOnlyProbe), fs.Mkdir(readOnlyProbe, 0o755))
+	check(fmt.Sprintf("RemoveAll(%q)", readOnlyProbe), fs.RemoveAll(readOnlyProbe))
+	check(fmt.Sprintf("Rename(%q)", readOnlyProbe), fs.Rename(readOnlyProbe, readOnlyProbe+".renamed"))
+
+	if info, ok := firstEntry(fs); ok {
+		name := info.Name()
+
+		check(fmt.Sprintf("Chmod(%q)", name), fs.Chmod(name, info.Mode()))
+		check(fmt.Sprintf("Chtimes(%q)", name), fs.Chtimes(name, info.ModTime(), info.ModTime()))
+
+		if info.Mode().IsRegular() {
+			check(fmt.Sprintf("OpenFile(%q, O_WRONLY)", name), closeIfOpened(fs.OpenFile(name, os.O_WRONLY, 0)))
+		}
+	}
+
+	_ = fs.RemoveAll(readOnlyProbe)              //nolint: errcheck
+	_ = fs.RemoveAll(readOnlyProbe + ".renamed") //nolint: errcheck
+
+	if len(violations) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Actual:  violations,
+		Message: fmt.Sprintf("%s is not read-only:\n- %s", fs.Name(), strings.Join(violations, "\n- ")),
+	}, msgAndArgs...)
+}
+
+func isPermissionError(err error) bool {
+	return errors.Is(err, os.ErrPermission) || errors.Is(err, syscall.EROFS)
+}
+
+func closeIfOpened(f afero.File, err error) error {
+	if err == nil {
+		_ = f.Close() //nolint: errcheck
+	}
+
+	return err
+}
+
+// firstEntry returns the first entry of the current directory, other than the probe.
+func firstEntry(fs afero.Fs) (os.FileInfo, bool) {
+	entries, err := afero.ReadDir(fs, ".")
+	if err != nil {
+		return nil, false
+	}
+
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), readOnlyProbe) {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
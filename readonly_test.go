@@ -0,0 +1,40 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestAssertFsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	base := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(base, "file", []byte("hello"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.AssertFsReadOnly(mockT, afero.NewReadOnlyFs(base)), mockT.message())
+
+	assert.False(t, aferoassert.AssertFsReadOnly(mockT, base))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "MemMapFS is not read-only:")
+	assert.Contains(t, mockT.errors[0], `- Create(".aferoassert-readonly-probe") succeeded`)
+	assert.Contains(t, mockT.errors[0], `- Chmod("file") succeeded`)
+	assert.Contains(t, mockT.errors[0], `- OpenFile("file", O_WRONLY) succeeded`)
+
+	// The probes are cleaned up and the existing files are not changed.
+	exists, err := afero.Exists(base, ".aferoassert-readonly-probe")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.FileContent(mockT, base, "file", "hello"), mockT.message())
+	assert.True(t, aferoassert.Perm(mockT, base, "file", 0o644), mockT.message())
+}
@@ -0,0 +1,356 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// The names of the recorded operations.
+const (
+	OpChmod     = "Chmod"
+	OpChown     = "Chown"
+	OpChtimes   = "Chtimes"
+	OpCreate    = "Create"
+	OpMkdir     = "Mkdir"
+	OpMkdirAll  = "MkdirAll"
+	OpOpen      = "Open"
+	OpOpenFile  = "OpenFile"
+	OpRemove    = "Remove"
+	OpRemoveAll = "RemoveAll"
+	OpRename    = "Rename"
+)
+
+const writeFlags = os.O_WRONLY | os.O_RDWR | os.O_CREATE | os.O_TRUNC | os.O_APPEND
+
+var _ afero.Lstater = (*RecordedFs)(nil)
+
+// Operation is an operation performed on a RecordedFs.
+type Operation struct {
+	Name string
+	Path string
+	// NewPath is the destination of a rename.
+	NewPath string
+	Flag    int
+	Err     error
+}
+
+// IsWrite tells whether the operation modifies the file system, or opens a file for writing.
+func (o Operation) IsWrite() bool {
+	switch o.Name {
+	case OpOpen:
+		return false
+
+	case OpOpenFile:
+		return o.Flag&writeFlags != 0
+	}
+
+	return true
+}
+
+// String returns the operation in a readable format, for example `Remove("dir/file")`.
+func (o Operation) String() string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%s(%q", o.Name, o.Path)
+
+	if o.Name == OpRename {
+		_, _ = fmt.Fprintf(&sb, ", %q", o.NewPath)
+	}
+
+	if o.Name == OpOpenFile {
+		_, _ = fmt.Fprintf(&sb, ", %#o", o.Flag)
+	}
+
+	_, _ = sb.WriteString(")")
+
+	if o.Err != nil {
+		_, _ = fmt.Fprintf(&sb, ": %s", o.Err)
+	}
+
+	return sb.String()
+}
+
+// RecordedFs is an afero.Fs that records the operations performed on the wrapped file system, see RecordFs.
+type RecordedFs struct {
+	afero.Fs
+
+	mu  sync.Mutex
+	ops []Operation
+}
+
+// RecordFs wraps a file system and records the Create, Open, OpenFile, Mkdir, MkdirAll, Remove, RemoveAll, Rename,
+// Chmod, Chown and Chtimes calls, so the assertions can check what was touched rather than the final state.
+func RecordFs(fs afero.Fs) *RecordedFs {
+	return &RecordedFs{Fs: fs}
+}
+
+// Operations returns the recorded operations, in order.
+func (r *RecordedFs) Operations() []Operation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Operation(nil), r.ops...)
+}
+
+// Reset forgets the recorded operations.
+func (r *RecordedFs) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ops = nil
+}
+
+func (r *RecordedFs) record(op Operation) {
+	op.Path = filepath.Clean(op.Path)
+
+	if op.NewPath != "" {
+		op.NewPath = filepath.Clean(op.NewPath)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ops = append(r.ops, op)
+}
+
+// Create creates a file and records the operation.
+func (r *RecordedFs) Create(name string) (afero.File, error) {
+	f, err := r.Fs.Create(name)
+
+	r.record(Operation{Name: OpCreate, Path: name, Err: err})
+
+	return f, err
+}
+
+// Mkdir creates a directory and records the operation.
+func (r *RecordedFs) Mkdir(name string, perm os.FileMode) error {
+	err := r.Fs.Mkdir(name, perm)
+
+	r.record(Operation{Name: OpMkdir, Path: name, Err: err})
+
+	return err
+}
+
+// MkdirAll creates a directory and its parents and records the operation.
+func (r *RecordedFs) MkdirAll(path string, perm os.FileMode) error {
+	err := r.Fs.MkdirAll(path, perm)
+
+	r.record(Operation{Name: OpMkdirAll, Path: path, Err: err})
+
+	return err
+}
+
+// Open opens a file for reading and records the operation.
+func (r *RecordedFs) Open(name string) (afero.File, error) {
+	f, err := r.Fs.Open(name)
+
+	r.record(Operation{Name: OpOpen, Path: name, Err: err})
+
+	return f, err
+}
+
+// OpenFile opens a file and records the operation.
+func (r *RecordedFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	f, err := r.Fs.OpenFile(name, flag, perm)
+
+	r.record(Operation{Name: OpOpenFile, Path: name, Flag: flag, Err: err})
+
+	return f, err
+}
+
+// Remove removes a file or an empty directory and records the operation.
+func (r *RecordedFs) Remove(name string) error {
+	err := r.Fs.Remove(name)
+
+	r.record(Operation{Name: OpRemove, Path: name, Err: err})
+
+	return err
+}
+
+// RemoveAll removes a path and its children and records the operation.
+func (r *RecordedFs) RemoveAll(path string) error {
+	err := r.Fs.RemoveAll(path)
+
+	r.record(Operation{Name: OpRemoveAll, Path: path, Err: err})
+
+	return err
+}
+
+// Rename renames a file and records the operation.
+func (r *RecordedFs) Rename(oldname, newname string) error {
+	err := r.Fs.Rename(oldname, newname)
+
+	r.record(Operation{Name: OpRename, Path: oldname, NewPath: newname, Err: err})
+
+	return err
+}
+
+// Chmod changes the mode of a file and records the operation.
+func (r *RecordedFs) Chmod(name string, mode os.FileMode) error {
+	err := r.Fs.Chmod(name, mode)
+
+	r.record(Operation{Name: OpChmod, Path: name, Err: err})
+
+	return err
+}
+
+// Chown changes the owner of a file and records the operation.
+func (r *RecordedFs) Chown(name string, uid, gid int) error {
+	err := r.Fs.Chown(name, uid, gid)
+
+	r.record(Operation{Name: OpChown, Path: name, Err: err})
+
+	return err
+}
+
+// Chtimes changes the access and the modification times of a file and records the operation.
+func (r *RecordedFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	err := r.Fs.Chtimes(name, atime, mtime)
+
+	r.record(Operation{Name: OpChtimes, Path: name, Err: err})
+
+	return err
+}
+
+// LstatIfPossible calls Lstat of the wrapped file system if it is supported, it is not recorded.
+func (r *RecordedFs) LstatIfPossible(name string) (os.FileInfo, bool, error) {
+	if l, ok := r.Fs.(afero.Lstater); ok {
+		return l.LstatIfPossible(name)
+	}
+
+	info, err := r.Fs.Stat(name)
+
+	return info, false, err
+}
+
+// Name returns the name of the wrapped file system.
+func (r *RecordedFs) Name() string {
+	return "RecordedFs(" + r.Fs.Name() + ")"
+}
+
+// AssertNoWrites checks whether no operation modified the recorded file system or opened a file for writing.
+func AssertNoWrites(t TestingT, rec *RecordedFs, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertNoWrites", func(t TestingT) bool {
+		return assertNoWrites(t, c, rec, msgAndArgs...)
+	})
+}
+
+func assertNoWrites(t TestingT, c *config, rec *RecordedFs, msgAndArgs ...interface{}) bool {
+	writes := filterOperations(rec.Operations(), Operation.IsWrite)
+	if len(writes) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Actual:  writes,
+		Message: fmt.Sprintf("expected no writes but found:\n%s", formatOperations(writes)),
+	}, msgAndArgs...)
+}
+
+// AssertRemoved checks whether the path was successfully removed by Remove or RemoveAll, either directly or by
+// removing one of its parents.
+func AssertRemoved(t TestingT, rec *RecordedFs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertRemoved", func(t TestingT) bool {
+		return assertRemoved(t, c, rec, path, msgAndArgs...)
+	})
+}
+
+func assertRemoved(t TestingT, c *config, rec *RecordedFs, path string, msgAndArgs ...interface{}) bool {
+	path = filepath.Clean(path)
+
+	for _, op := range rec.Operations() {
+		if op.Err != nil {
+			continue
+		}
+
+		if op.Name == OpRemove && op.Path == path || op.Name == OpRemoveAll && isParentOrSelf(op.Path, path) {
+			return true
+		}
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    path,
+		Message: fmt.Sprintf("expected %q to be removed, operations:\n%s", path, formatOperations(rec.Operations())),
+	}, msgAndArgs...)
+}
+
+// AssertOpenedOnce checks whether the path was opened exactly once by Create, Open or OpenFile.
+func AssertOpenedOnce(t TestingT, rec *RecordedFs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "AssertOpenedOnce", func(t TestingT) bool {
+		return assertOpenedOnce(t, c, rec, path, msgAndArgs...)
+	})
+}
+
+func assertOpenedOnce(t TestingT, c *config, rec *RecordedFs, path string, msgAndArgs ...interface{}) bool {
+	path = filepath.Clean(path)
+
+	opens := filterOperations(rec.Operations(), func(op Operation) bool {
+		return op.Path == path && (op.Name == OpCreate || op.Name == OpOpen || op.Name == OpOpenFile)
+	})
+
+	if len(opens) == 1 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:     path,
+		Expected: 1,
+		Actual:   len(opens),
+		Message:  fmt.Sprintf("expected %q to be opened once but it was opened %d times", path, len(opens)),
+	}, msgAndArgs...)
+}
+
+func filterOperations(ops []Operation, keep func(Operation) bool) []Operation {
+	result := make([]Operation, 0, len(ops))
+
+	for _, op := range ops {
+		if keep(op) {
+			result = append(result, op)
+		}
+	}
+
+	return result
+}
+
+func formatOperations(ops []Operation) string {
+	if len(ops) == 0 {
+		return "\t(none)"
+	}
+
+	lines := make([]string, len(ops))
+
+	for i, op := range ops {
+		lines[i] = "\t" + op.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isParentOrSelf tells whether the parent is the path or one of its parents.
+func isParentOrSelf(parent, path string) bool {
+	return parent == path || parent == "." || strings.HasPrefix(path, strings.TrimSuffix(parent, string(os.PathSeparator))+string(os.PathSeparator))
+}
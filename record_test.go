@@ -0,0 +1,101 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestRecordFs(t *testing.T) {
+	t.Parallel()
+
+	rec := aferoassert.RecordFs(afero.NewMemMapFs())
+
+	require.NoError(t, rec.MkdirAll("root/dir", 0o755))
+	require.NoError(t, afero.WriteFile(rec, "root/dir/file", []byte("hello"), 0o644))
+	require.NoError(t, rec.Chmod("root/dir/file", 0o600))
+	require.NoError(t, rec.Rename("root/dir/file", "root/file"))
+
+	_, err := rec.Open("root/unknown")
+	require.Error(t, err)
+
+	ops := rec.Operations()
+	require.Len(t, ops, 5)
+
+	assert.Equal(t, `MkdirAll("root/dir")`, ops[0].String())
+	assert.Equal(t, `OpenFile("root/dir/file", 01101)`, ops[1].String())
+	assert.Equal(t, `Chmod("root/dir/file")`, ops[2].String())
+	assert.Equal(t, `Rename("root/dir/file", "root/file")`, ops[3].String())
+	assert.Equal(t, `Open("root/unknown"): open root/unknown: file does not exist`, ops[4].String())
+
+	rec.Reset()
+
+	assert.Empty(t, rec.Operations())
+}
+
+func TestAssertNoWrites(t *testing.T) {
+	t.Parallel()
+
+	rec := aferoassert.RecordFs(newSourceFs(t))
+	mockT := &testingT{}
+
+	_, err := afero.ReadFile(rec, "root/file 1")
+	require.NoError(t, err)
+
+	f, err := rec.OpenFile("root/file 1", os.O_RDONLY, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.True(t, aferoassert.AssertNoWrites(mockT, rec), mockT.message())
+
+	require.NoError(t, rec.Chmod("root/file 1", 0o600))
+
+	assert.False(t, aferoassert.AssertNoWrites(mockT, rec))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `Chmod("root/file 1")`)
+}
+
+func TestAssertRemoved(t *testing.T) {
+	t.Parallel()
+
+	rec := aferoassert.RecordFs(newSourceFs(t))
+	mockT := &testingT{}
+
+	require.NoError(t, rec.Remove("root/file 1"))
+	require.NoError(t, rec.RemoveAll("root/folder 2"))
+	require.Error(t, rec.Remove("root/unknown"))
+
+	assert.True(t, aferoassert.AssertRemoved(mockT, rec, "root/file 1"), mockT.message())
+	assert.True(t, aferoassert.AssertRemoved(mockT, rec, "root/folder 2/file 2"), mockT.message())
+
+	assert.False(t, aferoassert.AssertRemoved(mockT, rec, "root/unknown"))
+	assert.False(t, aferoassert.AssertRemoved(mockT, rec, "root/folder"))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `expected "root/unknown" to be removed, operations:`)
+}
+
+func TestAssertOpenedOnce(t *testing.T) {
+	t.Parallel()
+
+	rec := aferoassert.RecordFs(newSourceFs(t))
+	mockT := &testingT{}
+
+	_, err := afero.ReadFile(rec, "root/file 1")
+	require.NoError(t, err)
+
+	assert.True(t, aferoassert.AssertOpenedOnce(mockT, rec, "root/file 1"), mockT.message())
+
+	_, err = afero.ReadFile(rec, "root/file 1")
+	require.NoError(t, err)
+
+	assert.False(t, aferoassert.AssertOpenedOnce(mockT, rec, "root/file 1"))
+	assert.False(t, aferoassert.AssertOpenedOnce(mockT, rec, "root/file 2"))
+	require.Len(t, mockT.errors, 2)
+	assert.Contains(t, mockT.errors[0], `expected "root/file 1" to be opened once but it was opened 2 times`)
+	assert.Contains(t, mockT.errors[1], `expected "root/file 2" to be opened once but it was opened 0 times`)
+}
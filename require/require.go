@@ -0,0 +1,880 @@
+// Package require provides the same assertions as the aferoassert package but stops the test when an assertion fails,
+// like github.com/stretchr/testify/require does.
+package require
+
+import (
+	"io"
+	iofs "io/fs"
+	"os"
+	"testing/fstest"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"go.nhat.io/aferoassert"
+)
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT = aferoassert.TestingT
+
+type tHelper interface {
+	Helper()
+}
+
+type failNower interface {
+	FailNow()
+}
+
+// failNow stops the test if the TestingT supports it.
+func failNow(t TestingT) {
+	if t, ok := t.(failNower); ok {
+		t.FailNow()
+	}
+}
+
+// Exists checks whether a path exists. It stops the test on failure.
+func Exists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.Exists(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NoExists checks whether a file does not exist in a given path. It stops the test on failure.
+func NoExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NoExists(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileExists checks whether a file exists in the given path. It stops the test on failure.
+func FileExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileExists(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NoFileExists checks whether a file does not exist in a given path. It stops the test on failure.
+func NoFileExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NoFileExists(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// DirExists checks whether a directory exists in the given path. It stops the test on failure.
+func DirExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.DirExists(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NoDirExists checks whether a directory does not exist in the given path. It stops the test on failure.
+func NoDirExists(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NoDirExists(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// Perm checks whether a path has the expected permission or not. It stops the test on failure.
+func Perm(t TestingT, fs afero.Fs, path string, expected os.FileMode, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.Perm(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileSize checks whether a file has the expected size. It stops the test on failure.
+func FileSize(t TestingT, fs afero.Fs, path string, expected int64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileSize(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileSparse checks whether a file is sparse. It stops the test on failure.
+func FileSparse(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileSparse(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileContent checks whether a file content is as expected or not. It stops the test on failure.
+func FileContent(t TestingT, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileContent(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileContentRegexp checks whether a file content matches the expectation or not. It stops the test on failure.
+func FileContentRegexp(t TestingT, fs afero.Fs, path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileContentRegexp(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileSemanticEqual checks whether a file content is equal to the expectation using the registered comparer. It stops
+// the test on failure.
+func FileSemanticEqual(t TestingT, fs afero.Fs, path string, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileSemanticEqual(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeEqual checks whether a directory is the same as the expectation or not. It stops the test on failure.
+func TreeEqual(t TestingT, fs afero.Fs, tree aferoassert.FileTree, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeEqual(t, fs, tree, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// YAMLTreeEqual checks whether a directory is the same as the expectation or not. It stops the test on failure.
+func YAMLTreeEqual(t TestingT, fs afero.Fs, expected, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.YAMLTreeEqual(t, fs, expected, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeContains checks whether a directory contains a file tree or not. It stops the test on failure.
+func TreeContains(t TestingT, fs afero.Fs, tree aferoassert.FileTree, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeContains(t, fs, tree, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// YAMLTreeContains checks whether a directory contains a file tree or not. It stops the test on failure.
+func YAMLTreeContains(t TestingT, fs afero.Fs, expected, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.YAMLTreeContains(t, fs, expected, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// EmbeddedTreeEqual checks whether a directory has exactly the files of an embedded file system. It stops the test on
+// failure.
+func EmbeddedTreeEqual(t TestingT, embedded iofs.FS, fs afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.EmbeddedTreeEqual(t, embedded, fs, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// EmbeddedFileEqual checks whether a file has the same content as a file of an embedded file system. It stops the test
+// on failure.
+func EmbeddedFileEqual(t TestingT, embedded iofs.FS, name string, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.EmbeddedFileEqual(t, embedded, name, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// MapFSEqual checks whether a directory has exactly the files of a fstest.MapFS with the same content. It stops the
+// test on failure.
+func MapFSEqual(t TestingT, expected fstest.MapFS, fs afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.MapFSEqual(t, expected, fs, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// MapFSContains checks whether a directory contains the files of a fstest.MapFS with the same content. It stops the
+// test on failure.
+func MapFSContains(t TestingT, expected fstest.MapFS, fs afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.MapFSContains(t, expected, fs, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FsConformance runs a battery of checks against the file system to verify that it behaves like the standard ones. It
+// stops the test on failure.
+func FsConformance(t TestingT, fs afero.Fs, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FsConformance(t, fs, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FsEqual checks whether a directory has the same files, permissions and content in both file systems. It stops the
+// test on failure.
+func FsEqual(t TestingT, expected, actual afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FsEqual(t, expected, actual, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertUnchanged checks whether a directory is the same as when the snapshot was taken. It stops the test on failure.
+func AssertUnchanged(t TestingT, fs afero.Fs, root string, snapshot aferoassert.Snapshot, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertUnchanged(t, fs, root, snapshot, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertOnlyChanged checks whether only the allowed paths of a directory were created, removed or modified since the
+// snapshot was taken. It stops the test on failure.
+func AssertOnlyChanged(
+	t TestingT,
+	fs afero.Fs,
+	root string,
+	snapshot aferoassert.Snapshot,
+	allowed []string,
+	msgAndArgs ...interface{},
+) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertOnlyChanged(t, fs, root, snapshot, allowed, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// DirGolden checks whether a directory has exactly the files of a reference directory on disk, or updates the
+// reference directory when the test runs with -update. It stops the test on failure.
+func DirGolden(t TestingT, fs afero.Fs, actualRoot, goldenDir string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.DirGolden(t, fs, actualRoot, goldenDir, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// DirChecksumEqual checks whether the checksum of a directory is the expected one. It stops the test on failure.
+func DirChecksumEqual(t TestingT, fs afero.Fs, root string, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.DirChecksumEqual(t, fs, root, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// SecureTree audits a directory and reports all the violations of the security policy together. It stops the test on
+// failure.
+func SecureTree(t TestingT, fs afero.Fs, root string, policy aferoassert.SecurityPolicy, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.SecureTree(t, fs, root, policy, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertPermPolicy checks whether every path of a directory satisfies the rules of the permission policy. It stops
+// the test on failure.
+func AssertPermPolicy(t TestingT, fs afero.Fs, root string, policy aferoassert.PermPolicy, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertPermPolicy(t, fs, root, policy, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertNoWrites checks whether no operation modified the recorded file system or opened a file for writing. It stops
+// the test on failure.
+func AssertNoWrites(t TestingT, rec *aferoassert.RecordedFs, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertNoWrites(t, rec, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertRemoved checks whether the path was successfully removed from the recorded file system. It stops the test on
+// failure.
+func AssertRemoved(t TestingT, rec *aferoassert.RecordedFs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertRemoved(t, rec, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertOpenedOnce checks whether the path was opened exactly once in the recorded file system. It stops the test on
+// failure.
+func AssertOpenedOnce(t TestingT, rec *aferoassert.RecordedFs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertOpenedOnce(t, rec, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertFsReadOnly checks whether the file system rejects the mutations with a permission error. It stops the test on
+// failure.
+func AssertFsReadOnly(t TestingT, fs afero.Fs, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertFsReadOnly(t, fs, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// InLayer checks whether a path exists in a layer of a union file system. It stops the test on failure.
+func InLayer(t TestingT, layer afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.InLayer(t, layer, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NotInBase checks whether a path does not exist in the base of a union file system. It stops the test on failure.
+func NotInBase(t TestingT, base afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NotInBase(t, base, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// UnionResolvesTo checks whether a read of a path in a union file system is served from the expected layer. It stops
+// the test on failure.
+func UnionResolvesTo(t TestingT, union, layer afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.UnionResolvesTo(t, union, layer, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// CachedAfterRead reads a file through a cache file system and checks whether the cache layer then contains the file.
+// It stops the test on failure.
+func CachedAfterRead(t TestingT, union, cache afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.CachedAfterRead(t, union, cache, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// CacheExpired checks whether the next read of a file through an afero.CacheOnReadFs is not served from the cache
+// layer. It stops the test on failure.
+func CacheExpired(t TestingT, base, cache afero.Fs, path string, cacheTime time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.CacheExpired(t, base, cache, path, cacheTime, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// OpenFileContent checks whether the content of an open file is equal to the expectation. It stops the test on
+// failure.
+func OpenFileContent(t TestingT, f afero.File, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.OpenFileContent(t, f, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// OpenFileSize checks whether the size of an open file is equal to the expectation. It stops the test on failure.
+func OpenFileSize(t TestingT, f afero.File, expected int64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.OpenFileSize(t, f, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// OpenFileAt checks whether an open file has the expected bytes at the offset. It stops the test on failure.
+func OpenFileAt(t TestingT, f afero.File, offset int64, expected []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.OpenFileAt(t, f, offset, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// Xattr checks whether a path has the extended attribute with the expected value. It stops the test on failure.
+func Xattr(t TestingT, fs afero.Fs, path, name, value string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.Xattr(t, fs, path, name, value, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeWithinQuota checks whether the files of a directory take at most maxBytes bytes and are at most maxFiles. It
+// stops the test on failure.
+func TreeWithinQuota(t TestingT, fs afero.Fs, root string, maxBytes, maxFiles int64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeWithinQuota(t, fs, root, maxBytes, maxFiles, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FsContentSetEqual checks whether the regular files of a directory have the same set of contents in both file
+// systems, regardless of their paths. It stops the test on failure.
+func FsContentSetEqual(t TestingT, expected, actual afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FsContentSetEqual(t, expected, actual, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// WaitForPath waits until a path exists, it fails if the path does not exist before the timeout. It stops the test on
+// failure.
+func WaitForPath(t TestingT, fs afero.Fs, path string, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.WaitForPath(t, fs, path, timeout, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// AssertConcurrently runs independent checks in a pool of workers and reports their failures in order. It stops the
+// test on failure.
+func AssertConcurrently(t TestingT, fs afero.Fs, checks ...aferoassert.Check) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.AssertConcurrently(t, fs, checks...) {
+		failNow(t)
+	}
+}
+
+// FileCRC32 checks whether the CRC-32 (IEEE) of the content of a file is the expected one. It stops the test on
+// failure.
+func FileCRC32(t TestingT, fs afero.Fs, path string, expected uint32, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileCRC32(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileXXHash checks whether the 64-bit xxHash of the content of a file is the expected one. It stops the test on
+// failure.
+func FileXXHash(t TestingT, fs afero.Fs, path string, expected uint64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileXXHash(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreePerms checks whether every regular file under a directory has the file permission and every directory under it
+// has the directory permission. It stops the test on failure.
+func TreePerms(t TestingT, fs afero.Fs, root string, filePerm, dirPerm os.FileMode, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreePerms(t, fs, root, filePerm, dirPerm, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NoSpecialFiles checks whether a directory contains only regular files, directories and symlinks. It stops the test on
+// failure.
+func NoSpecialFiles(t TestingT, fs afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NoSpecialFiles(t, fs, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NoHardlinks checks whether none of the regular files of a directory has more than one link. It stops the test on
+// failure.
+func NoHardlinks(t TestingT, fs afero.Fs, root string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NoHardlinks(t, fs, root, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// RealPathEqual checks whether a path of a file system that maps the paths, such as afero.BasePathFs, is mapped to the
+// expected real path. It stops the test on failure.
+func RealPathEqual(t TestingT, fs afero.Fs, virtualPath, expectedReal string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.RealPathEqual(t, fs, virtualPath, expectedReal, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// Mirrored checks whether a directory is an exact mirror of another directory of the same file system. It stops the
+// test on failure.
+func Mirrored(t TestingT, fs afero.Fs, srcRoot, dstRoot string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.Mirrored(t, fs, srcRoot, dstRoot, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// YAMLTreeEqualFile checks whether a directory is the same as the YAML expectation read from a file. It stops the test
+// on failure.
+func YAMLTreeEqualFile(
+	t TestingT,
+	fs afero.Fs,
+	expectationFs afero.Fs,
+	expectationPath, path string,
+	msgAndArgs ...interface{},
+) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.YAMLTreeEqualFile(t, fs, expectationFs, expectationPath, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// YAMLTreeContainsFile checks whether a directory contains the YAML expectation read from a file. It stops the test on
+// failure.
+func YAMLTreeContainsFile(
+	t TestingT,
+	fs afero.Fs,
+	expectationFs afero.Fs,
+	expectationPath, path string,
+	msgAndArgs ...interface{},
+) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.YAMLTreeContainsFile(t, fs, expectationFs, expectationPath, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeEqualFromReader checks whether a directory is the same as the YAML expectation read from a reader. It stops the
+// test on failure.
+func TreeEqualFromReader(t TestingT, fs afero.Fs, r io.Reader, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeEqualFromReader(t, fs, r, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeContainsFromReader checks whether a directory contains the YAML expectation read from a reader. It stops the test
+// on failure.
+func TreeContainsFromReader(t TestingT, fs afero.Fs, r io.Reader, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeContainsFromReader(t, fs, r, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeEqualFromBytes checks whether a directory is the same as the YAML expectation. It stops the test on failure.
+func TreeEqualFromBytes(t TestingT, fs afero.Fs, expected []byte, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeEqualFromBytes(t, fs, expected, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// TreeContainsFromBytes checks whether a directory contains the YAML expectation. It stops the test on failure.
+func TreeContainsFromBytes(t TestingT, fs afero.Fs, expected []byte, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.TreeContainsFromBytes(t, fs, expected, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// JSONFileValue checks whether the value at a JSON path of a JSON file is equal to the expectation. It stops the test
+// on failure.
+func JSONFileValue(t TestingT, fs afero.Fs, path, query string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.JSONFileValue(t, fs, path, query, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileValid checks whether a file is valid according to the validator registered for its extension. It stops the test
+// on failure.
+func FileValid(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileValid(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileLinesContain checks whether a file contains all the lines, in any order. It stops the test on failure.
+func FileLinesContain(t TestingT, fs afero.Fs, path string, lines ...string) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileLinesContain(t, fs, path, lines...) {
+		failNow(t)
+	}
+}
+
+// FileCompressedAs checks whether a file is compressed with one of the expected formats by checking its magic bytes. It
+// stops the test on failure.
+func FileCompressedAs(
+	t TestingT,
+	fs afero.Fs,
+	path string,
+	expected aferoassert.Compression,
+	msgAndArgs ...interface{},
+) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileCompressedAs(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileHeader checks whether a file starts with the expected bytes. It stops the test on failure.
+func FileHeader(t TestingT, fs afero.Fs, path string, expected []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileHeader(t, fs, path, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// FileHeaderAt checks whether a file has the expected bytes at the offset. It stops the test on failure.
+func FileHeaderAt(t TestingT, fs afero.Fs, path string, offset int64, expected []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.FileHeaderAt(t, fs, path, offset, expected, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// ImageDimensions checks whether an image has the expected width and height. It stops the test on failure.
+func ImageDimensions(t TestingT, fs afero.Fs, path string, width, height int, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.ImageDimensions(t, fs, path, width, height, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// SymlinkIsRelative checks whether a path is a symlink with a relative target. It stops the test on failure.
+func SymlinkIsRelative(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.SymlinkIsRelative(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// SymlinkIsAbsolute checks whether a path is a symlink with an absolute target. It stops the test on failure.
+func SymlinkIsAbsolute(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.SymlinkIsAbsolute(t, fs, path, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// NewestFileMatches checks whether the most recently modified entry of a directory has a name matching the pattern. It
+// stops the test on failure.
+func NewestFileMatches(t TestingT, fs afero.Fs, dir, pattern string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.NewestFileMatches(t, fs, dir, pattern, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// RotationPolicy checks whether the rotated files of a log in a directory follow the naming scheme and are ordered by
+// their modification times. It stops the test on failure.
+func RotationPolicy(t TestingT, fs afero.Fs, dir, base string, maxRotated int, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.RotationPolicy(t, fs, dir, base, maxRotated, msgAndArgs...) {
+		failNow(t)
+	}
+}
+
+// ModTimesAscending checks whether the modification times of the paths are strictly increasing. It stops the test on
+// failure.
+func ModTimesAscending(t TestingT, fs afero.Fs, paths ...string) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.ModTimesAscending(t, fs, paths...) {
+		failNow(t)
+	}
+}
+
+// PermWithUmask checks whether a path has the permission that results from the requested one with the umask. It stops
+// the test on failure.
+func PermWithUmask(t TestingT, fs afero.Fs, path string, requested, umask os.FileMode, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !aferoassert.PermWithUmask(t, fs, path, requested, umask, msgAndArgs...) {
+		failNow(t)
+	}
+}
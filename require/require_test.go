@@ -0,0 +1,61 @@
+package require_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert/require"
+)
+
+type testingT struct {
+	errors []string
+	failed bool
+}
+
+func (t *testingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, format)
+}
+
+func (t *testingT) FailNow() {
+	t.failed = true
+}
+
+func TestFileExists(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	assert.NoError(t, afero.WriteFile(fs, "file.txt", nil, 0o644))
+
+	mockT := &testingT{}
+
+	require.FileExists(mockT, fs, "file.txt")
+
+	assert.Empty(t, mockT.errors)
+	assert.False(t, mockT.failed)
+
+	require.FileExists(mockT, fs, "unknown.txt")
+
+	assert.Len(t, mockT.errors, 1)
+	assert.True(t, mockT.failed)
+}
+
+func TestYAMLTreeEqual(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	assert.NoError(t, afero.WriteFile(fs, "root/file.txt", nil, 0o644))
+
+	mockT := &testingT{}
+
+	require.YAMLTreeEqual(mockT, fs, `- file.txt`, "root")
+
+	assert.False(t, mockT.failed)
+
+	require.YAMLTreeEqual(mockT, fs, `- other.txt`, "root")
+
+	assert.True(t, mockT.failed)
+}
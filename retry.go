@@ -0,0 +1,57 @@
+package aferoassert
+
+import "time"
+
+// silentT records the failures of an attempt that is going to be retried.
+type silentT struct {
+	failed bool
+}
+
+func (t *silentT) Errorf(string, ...interface{}) {
+	t.failed = true
+}
+
+// run runs the assertion, retrying it when WithRetry is set. The failures of the attempts other than the last one are
+// discarded.
+func (c *config) run(t TestingT, assertion string, assert func(t TestingT) bool) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c.assertion = assertion
+
+	if c.stats != nil {
+		defer c.recordStats(time.Now())
+	}
+
+	backoff := c.retryBackoff
+
+	for i := 1; i < c.retryAttempts; i++ {
+		st := &silentT{}
+		c.traceSteps = nil
+
+		if assert(st) && !st.failed {
+			return true
+		}
+
+		time.Sleep(backoff)
+
+		backoff *= 2
+	}
+
+	c.traceSteps = nil
+
+	if ok := assert(t); ok {
+		return true
+	}
+
+	if c.trace {
+		c.logTrace(t)
+	}
+
+	if c.failNow {
+		failNow(t)
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// RotationPolicy checks whether the rotated files of a log in a directory follow the naming scheme of the log rotation
+// tools, such as logrotate or lumberjack: the log "app.log" is rotated to "app.log.1", "app.log.2.gz", ... where the
+// index has no gaps, is at most maxRotated, and the higher the index, the older the file. The compression suffix is
+// optional. All the violations are reported together.
+//
+//	aferoassert.RotationPolicy(t, fs, "/var/log", "app.log", 3)
+func RotationPolicy(t TestingT, fs afero.Fs, dir, base string, maxRotated int, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "RotationPolicy", func(t TestingT) bool {
+		return assertRotationPolicy(t, c, fs, dir, base, maxRotated, msgAndArgs...)
+	})
+}
+
+func assertRotationPolicy(t TestingT, c *config, fs afero.Fs, dir, base string, maxRotated int, msgAndArgs ...interface{}) bool {
+	names, err := readDir(c, fs, dir)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: dir, Message: fmt.Sprintf("could not read directory %q: %s", dir, err)}, msgAndArgs...)
+	}
+
+	violations := make([]string, 0)
+	// rotated maps the index to the rotated file, the index 0 is the active log.
+	rotated := make(map[int]os.FileInfo)
+	last := 0
+
+	for _, name := range names {
+		if name != base && !strings.HasPrefix(name, base+".") {
+			continue
+		}
+
+		idx, ok := rotationIndex(base, name)
+		if !ok {
+			violations = append(violations, fmt.Sprintf("%q does not follow the naming scheme %s.N[.ext]", name, base))
+
+			continue
+		}
+
+		if prev, ok := rotated[idx]; ok {
+			violations = append(violations, fmt.Sprintf("%q and %q have the same index %d", prev.Name(), name, idx))
+
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		info, err := stat(c, fs, path)
+		if err != nil {
+			return c.fail(t, statFailureInfo(fs, path, err), msgAndArgs...)
+		}
+
+		if idx > maxRotated {
+			violations = append(violations, fmt.Sprintf("%q exceeds the %d rotated files", name, maxRotated))
+		}
+
+		if idx > last {
+			last = idx
+		}
+
+		rotated[idx] = info
+	}
+
+	for idx := 1; idx <= last; idx++ {
+		if _, ok := rotated[idx]; !ok {
+			violations = append(violations, fmt.Sprintf("%s.%d is missing", base, idx))
+		}
+	}
+
+	// The files are checked in pairs with the next lower index that exists, so a gap is not reported twice.
+	var newer os.FileInfo
+
+	for idx := 0; idx <= last; idx++ {
+		info, ok := rotated[idx]
+		if !ok {
+			continue
+		}
+
+		if newer != nil && info.ModTime().After(newer.ModTime()) {
+			violations = append(violations, fmt.Sprintf("%q (modified at %s) is newer than %q (modified at %s)",
+				info.Name(), info.ModTime(), newer.Name(), newer.ModTime()))
+		}
+
+		newer = info
+	}
+
+	if len(violations) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    dir,
+		Actual:  violations,
+		Message: fmt.Sprintf("%q does not follow the rotation policy of %q:\n- %s", dir, base, strings.Join(violations, "\n- ")),
+	}, msgAndArgs...)
+}
+
+// rotationIndex parses the index of a rotated file, such as 2 for "app.log.2.gz", or 0 for the active log.
+func rotationIndex(base, name string) (int, bool) {
+	if name == base {
+		return 0, true
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(name, base+"."), ".", 2)
+
+	if len(parts) == 2 && (parts[1] == "" || strings.Contains(parts[1], ".")) {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(parts[0])
+	if err != nil || idx < 1 || parts[0] != strconv.Itoa(idx) {
+		return 0, false
+	}
+
+	return idx, true
+}
@@ -0,0 +1,121 @@
+package aferoassert_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// newLogFs creates the files in the "logs" directory, the later files are the older ones.
+func newLogFs(t *testing.T, names ...string) afero.Fs {
+	t.Helper()
+
+	now := time.Now()
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("logs", 0o755))
+
+	for i, name := range names {
+		mtime := now.Add(-time.Duration(i) * time.Hour)
+		path := filepath.Join("logs", name)
+
+		require.NoError(t, afero.WriteFile(fs, path, nil, 0o644))
+		require.NoError(t, fs.Chtimes(path, mtime, mtime))
+	}
+
+	return fs
+}
+
+func TestRotationPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		fs             afero.Fs
+		expectedResult bool
+		expectedErrors []string
+	}{
+		{
+			scenario:       "no logs",
+			fs:             newLogFs(t),
+			expectedResult: true,
+		},
+		{
+			scenario:       "rotated and compressed",
+			fs:             newLogFs(t, "app.log", "app.log.1", "app.log.2.gz", "app.log.3.gz", "other.log.9"),
+			expectedResult: true,
+		},
+		{
+			scenario:       "without the active log",
+			fs:             newLogFs(t, "app.log.1", "app.log.2"),
+			expectedResult: true,
+		},
+		{
+			scenario:       "too many rotated files",
+			fs:             newLogFs(t, "app.log", "app.log.1", "app.log.2", "app.log.3", "app.log.4.gz"),
+			expectedErrors: []string{`"logs" does not follow the rotation policy of "app.log":`, `- "app.log.4.gz" exceeds the 3 rotated files`},
+		},
+		{
+			scenario:       "gap",
+			fs:             newLogFs(t, "app.log", "app.log.1", "app.log.3"),
+			expectedErrors: []string{"- app.log.2 is missing"},
+		},
+		{
+			scenario:       "same index",
+			fs:             newLogFs(t, "app.log", "app.log.1", "app.log.1.gz"),
+			expectedErrors: []string{`- "app.log.1" and "app.log.1.gz" have the same index 1`},
+		},
+		{
+			scenario: "naming scheme",
+			fs:       newLogFs(t, "app.log", "app.log.old", "app.log.0", "app.log.01", "app.log.1.tar.gz", "app.log.1."),
+			expectedErrors: []string{
+				`- "app.log.0" does not follow the naming scheme app.log.N[.ext]`,
+				`- "app.log.01" does not follow the naming scheme app.log.N[.ext]`,
+				`- "app.log.1." does not follow the naming scheme app.log.N[.ext]`,
+				`- "app.log.1.tar.gz" does not follow the naming scheme app.log.N[.ext]`,
+				`- "app.log.old" does not follow the naming scheme app.log.N[.ext]`,
+			},
+		},
+		{
+			scenario:       "wrong order",
+			fs:             newLogFs(t, "app.log", "app.log.2", "app.log.1"),
+			expectedErrors: []string{`- "app.log.2" (modified at`, `) is newer than "app.log.1" (modified at`},
+		},
+		{
+			scenario:       "wrong order with the active log",
+			fs:             newLogFs(t, "app.log.1", "app.log"),
+			expectedErrors: []string{`- "app.log.1" (modified at`, `) is newer than "app.log" (modified at`},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.Equal(t, tc.expectedResult, aferoassert.RotationPolicy(mockT, tc.fs, "logs", "app.log", 3), mockT.message())
+
+			for _, err := range tc.expectedErrors {
+				assert.Contains(t, mockT.message(), err)
+			}
+		})
+	}
+}
+
+func TestRotationPolicy_DirNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.RotationPolicy(mockT, afero.NewMemMapFs(), "logs", "app.log", 3))
+	assert.Contains(t, mockT.message(), `could not read directory "logs"`)
+}
@@ -0,0 +1,116 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrSymlinkNotSupported indicates that the file system can not create the symlinks of a file tree.
+var ErrSymlinkNotSupported = errors.New("symlinks are not supported")
+
+// BuildTree creates the directories, the files and the symlinks of a file tree in the root directory, so the fixtures
+// and the expectations can share the same format. The files are written with the `content` tag, or filled with zeros
+// up to the `size` tag, the symlinks are created with the `target` tag, and the permissions are set with the `perm`
+// tag, after the children of a directory are created, so a read-only directory can be built. The conditions are
+// evaluated like FileTree.Flatten does. The other tags are not applied. The ranges of the names, such as
+// `file-{001..500}.dat`, are expanded when the tree is unmarshaled.
+//
+//	err := aferoassert.BuildTree(fs, "releases", tree)
+func BuildTree(fs afero.Fs, root string, tree FileTree) error {
+	if err := fs.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(tree))
+
+	for name := range tree {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		n := tree[name]
+
+		if !n.Attrs.conditionMet() {
+			continue
+		}
+
+		if err := buildNode(fs, filepath.Join(root, name), n.withoutSkippedTags()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScaffoldYAML creates the file tree of a YAML expectation in the root directory, see BuildTree.
+//
+//	err := aferoassert.ScaffoldYAML(fs, "releases", `
+//	- v1:
+//	    - app 'perm:"0755" content:"#!/bin/sh"'
+//	- current 'target:"v1"'
+//	`)
+func ScaffoldYAML(fs afero.Fs, root, expected string) error {
+	var tree FileTree
+
+	if err := yaml.Unmarshal([]byte(expected), &tree); err != nil {
+		return fmt.Errorf("could not unmarshal expectation: %w", err)
+	}
+
+	return BuildTree(fs, root, tree)
+}
+
+func buildNode(fs afero.Fs, path string, n FileNode) error {
+	if target, ok := n.Attrs.Target(); ok {
+		l, ok := fs.(afero.Linker)
+		if !ok {
+			return fmt.Errorf("%w by %s: could not create %q", ErrSymlinkNotSupported, fs.Name(), path)
+		}
+
+		return l.SymlinkIfPossible(target, path)
+	}
+
+	if n.IsDir {
+		if err := BuildTree(fs, path, n.Children); err != nil {
+			return err
+		}
+	} else if err := buildFile(fs, path, n.Attrs); err != nil {
+		return err
+	}
+
+	// The permissions are set with Chmod so they are not affected by the umask.
+	if perm := n.Tags.Perm(); perm != nil {
+		return fs.Chmod(path, normalizePerm(*perm))
+	}
+
+	return nil
+}
+
+func buildFile(fs afero.Fs, path string, attrs FileAttrs) error {
+	content, ok := attrs.Content()
+	size, hasSize := attrs.Size()
+
+	if ok || !hasSize {
+		return afero.WriteFile(fs, path, []byte(content), 0o644)
+	}
+
+	f, err := fs.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if err := f.Truncate(size); err != nil {
+		_ = f.Close() // nolint: errcheck
+
+		return err
+	}
+
+	return f.Close()
+}
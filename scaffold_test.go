@@ -0,0 +1,100 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestScaffoldYAML(t *testing.T) {
+	t.Parallel()
+
+	const tree = `
+- bin 'perm:"0500"':
+    - app 'perm:"0755" content:"#!/bin/sh"'
+- config:
+    - app.yaml 'perm:"0600" content:"debug=true"'
+    - empty: {}
+- README.md
+`
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, aferoassert.ScaffoldYAML(fs, "dist", tree))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist"), mockT.message())
+	assert.True(t, aferoassert.FileContent(mockT, fs, "dist/README.md", ""), mockT.message())
+	assert.True(t, aferoassert.Perm(mockT, fs, "dist/config", 0o755), mockT.message())
+}
+
+func TestScaffoldYAML_Conditions(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, aferoassert.ScaffoldYAML(fs, "dist", fmt.Sprintf(`
+- app 'perm:"0700,skip-%[1]s"'
+- app.exe 'if:"%[2]s"'
+`, runtime.GOOS, otherOS())))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Perm(mockT, fs, "dist/app", 0o644), mockT.message())
+	assert.True(t, aferoassert.NoExists(mockT, fs, "dist/app.exe"), mockT.message())
+}
+
+func TestScaffoldYAML_Invalid(t *testing.T) {
+	t.Parallel()
+
+	err := aferoassert.ScaffoldYAML(afero.NewMemMapFs(), "dist", `- app 'perm:"rwx"'`)
+
+	assert.ErrorIs(t, err, aferoassert.ErrInvalidFileMode)
+	assert.Contains(t, err.Error(), "could not unmarshal expectation")
+}
+
+func TestBuildTree_Symlink(t *testing.T) {
+	t.Parallel()
+
+	const tree = `
+- v1:
+    - app 'content:"v1"'
+- current 'target:"v1"'
+`
+
+	root := filepath.Join(t.TempDir(), "releases")
+	fs := afero.NewOsFs()
+
+	require.NoError(t, aferoassert.ScaffoldYAML(fs, root, tree))
+
+	target, err := os.Readlink(filepath.Join(root, "current"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1", target)
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, root), mockT.message())
+
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, `
+- current 'target:"v2"'
+- v1:
+    - app 'target:"v1"'
+`, root))
+	assert.Contains(t, mockT.message(), `current" links to "v1", expected "v2"`)
+	assert.Contains(t, mockT.message(), `app" is not a symlink`)
+
+	err = aferoassert.ScaffoldYAML(afero.NewMemMapFs(), "releases", tree)
+
+	assert.ErrorIs(t, err, aferoassert.ErrSymlinkNotSupported)
+	assert.EqualError(t, err, `symlinks are not supported by MemMapFS: could not create "releases/current"`)
+}
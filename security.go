@@ -0,0 +1,153 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// defaultPrivateKeyPatterns are the names of the private key files when SecurityPolicy.PrivateKeyPatterns is empty.
+var defaultPrivateKeyPatterns = []string{"*.key", "*.pem", "id_rsa", "id_dsa", "id_ecdsa", "id_ed25519"}
+
+// SecurityPolicy configures SecureTree. The zero value enables all the checks.
+type SecurityPolicy struct {
+	// AllowWorldWritable allows the entries that are writable by everyone.
+	AllowWorldWritable bool
+	// AllowSetuid allows the entries with the setuid or the setgid bit.
+	AllowSetuid bool
+	// AllowSymlinkEscapes allows the symlinks that point outside of the tree.
+	AllowSymlinkEscapes bool
+	// AllowSpecialFiles allows the devices, the named pipes and the sockets.
+	AllowSpecialFiles bool
+	// PrivateKeyPatterns are the filepath.Match patterns of the private key files that must not be accessible by the
+	// group or the others, for example 0600. The common key names, such as "*.pem" or "id_rsa", are used when it is
+	// empty.
+	PrivateKeyPatterns []string
+}
+
+// SecureTree audits a directory in a single walk and reports all the violations of the policy together: the
+// world-writable entries, the setuid and setgid bits, the symlinks pointing outside of the tree, the device, pipe and
+// socket files, and the private key files that are accessible by the group or the others.
+func SecureTree(t TestingT, fs afero.Fs, root string, policy SecurityPolicy, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "SecureTree", func(t TestingT) bool {
+		return assertSecureTree(t, c, fs, root, policy, msgAndArgs...)
+	})
+}
+
+func assertSecureTree(t TestingT, c *config, fs afero.Fs, root string, policy SecurityPolicy, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+	violations := make([]string, 0)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if rel := relPath(root, path); rel != "." && c.ignored(rel) {
+			return skip(info)
+		}
+
+		violations = append(violations, securityViolations(fs, root, path, info, policy)...)
+
+		return nil
+	})
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not walk through %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if len(violations) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  violations,
+		Message: fmt.Sprintf("%q violates the security policy:\n- %s", root, strings.Join(violations, "\n- ")),
+	}, msgAndArgs...)
+}
+
+func securityViolations(fs afero.Fs, root, path string, info os.FileInfo, policy SecurityPolicy) []string {
+	var result []string
+
+	mode := info.Mode()
+	isSymlink := mode&os.ModeSymlink != 0
+
+	// The permissions of a symlink are meaningless.
+	if !policy.AllowWorldWritable && !isSymlink && mode&0o002 != 0 && mode&os.ModeSticky == 0 {
+		result = append(result, fmt.Sprintf("%q is world-writable (0%o)", path, mode.Perm()))
+	}
+
+	if !policy.AllowSetuid {
+		if mode&os.ModeSetuid != 0 {
+			result = append(result, fmt.Sprintf("%q has the setuid bit", path))
+		}
+
+		if mode&os.ModeSetgid != 0 {
+			result = append(result, fmt.Sprintf("%q has the setgid bit", path))
+		}
+	}
+
+	if !policy.AllowSpecialFiles && mode&(os.ModeDevice|os.ModeNamedPipe|os.ModeSocket) != 0 {
+		result = append(result, fmt.Sprintf("%q is a special file (%s)", path, FormatFileMode(mode.Type())))
+	}
+
+	if !policy.AllowSymlinkEscapes && isSymlink {
+		if target, escaped := symlinkEscapes(fs, root, path); escaped {
+			result = append(result, fmt.Sprintf("%q points outside of the tree: %s", path, target))
+		}
+	}
+
+	if mode.IsRegular() && isPrivateKey(info.Name(), policy.PrivateKeyPatterns) && mode.Perm()&0o077 != 0 {
+		result = append(result, fmt.Sprintf("%q is a private key accessible by others (0%o), expected 0600", path, mode.Perm()))
+	}
+
+	return result
+}
+
+// symlinkEscapes tells whether the symlink points outside of the root.
+func symlinkEscapes(fs afero.Fs, root, path string) (string, bool) {
+	r, ok := fs.(afero.LinkReader)
+	if !ok {
+		return "", false
+	}
+
+	target, err := r.ReadlinkIfPossible(path)
+	if err != nil {
+		return "", false
+	}
+
+	resolved := target
+	if !filepath.IsAbs(target) {
+		resolved = filepath.Join(filepath.Dir(path), target)
+	}
+
+	rel, err := filepath.Rel(root, filepath.Clean(resolved))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return target, true
+	}
+
+	return target, false
+}
+
+func isPrivateKey(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		patterns = defaultPrivateKeyPatterns
+	}
+
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, name); ok { //nolint: errcheck
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package aferoassert_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestSecureTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/.ssh", 0o700))
+	require.NoError(t, afero.WriteFile(fs, "root/.ssh/id_rsa", nil, 0o600))
+	require.NoError(t, afero.WriteFile(fs, "root/run.sh", nil, 0o755))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.SecureTree(mockT, fs, "root", aferoassert.SecurityPolicy{}), mockT.message())
+
+	require.NoError(t, fs.Chmod("root/.ssh/id_rsa", 0o644))
+	require.NoError(t, fs.Chmod("root/run.sh", 0o777|os.ModeSetuid))
+
+	assert.False(t, aferoassert.SecureTree(mockT, fs, "root", aferoassert.SecurityPolicy{}))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"root" violates the security policy:`)
+	assert.Contains(t, mockT.errors[0], `- "root/.ssh/id_rsa" is a private key accessible by others (0644), expected 0600`)
+	assert.Contains(t, mockT.errors[0], `- "root/run.sh" is world-writable (0777)`)
+	assert.Contains(t, mockT.errors[0], `- "root/run.sh" has the setuid bit`)
+
+	mockT = &testingT{}
+	policy := aferoassert.SecurityPolicy{AllowWorldWritable: true, AllowSetuid: true, PrivateKeyPatterns: []string{"*.pem"}}
+
+	assert.True(t, aferoassert.SecureTree(mockT, fs, "root", policy), mockT.message())
+}
+
+func TestSecureTree_SymlinkEscapes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(root, "sub"), 0o755))
+	require.NoError(t, os.Symlink("../sub", filepath.Join(root, "sub", "inside")))
+	require.NoError(t, os.Symlink("../../etc", filepath.Join(root, "sub", "outside")))
+	require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(root, "absolute")))
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.SecureTree(mockT, fs, root, aferoassert.SecurityPolicy{}))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], "absolute\" points outside of the tree: /etc/passwd")
+	assert.Contains(t, mockT.errors[0], "outside\" points outside of the tree: ../../etc")
+	assert.NotContains(t, mockT.errors[0], "inside")
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.SecureTree(mockT, fs, root, aferoassert.SecurityPolicy{AllowSymlinkEscapes: true}), mockT.message())
+}
+
+func TestSecureTree_CouldNotWalk(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.SecureTree(mockT, afero.NewMemMapFs(), "unknown", aferoassert.SecurityPolicy{}))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `could not walk through "unknown"`)
+}
@@ -0,0 +1,267 @@
+package aferoassert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// Session serves the assertions on a directory from an index that is built by walking the directory once, so the
+// tests that check the same large tree many times don't walk it again for every assertion, for example:
+//
+//	s := aferoassert.NewSession(fs, "root")
+//
+//	for _, tc := range testCases {
+//		s.TreeContains(t, tc.tree, "root")
+//		s.Perm(t, tc.path, tc.perm)
+//	}
+//
+// Only the paths under the root and the contents of the directories are cached, the content of the files is always
+// read from the file system. When the links are followed, see FollowLinks, the index is not used. The session has to
+// be invalidated after the directory is modified, see Invalidate.
+type Session struct {
+	fs   afero.Fs
+	root string
+
+	mu  sync.Mutex
+	idx *walkIndex
+}
+
+// NewSession walks the directory and creates a session from it.
+func NewSession(fs afero.Fs, root string) *Session {
+	s := &Session{fs: fs, root: filepath.Clean(root)}
+	s.idx = buildIndex(s.fs, s.root)
+
+	return s
+}
+
+// Invalidate drops the index, the directory is walked again on the next assertion.
+func (s *Session) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idx = nil
+}
+
+// index returns the index and builds it if it was invalidated.
+func (s *Session) index() *walkIndex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.idx == nil {
+		s.idx = buildIndex(s.fs, s.root)
+	}
+
+	return s.idx
+}
+
+// withIndex makes the assertion serve the stats and the directory listings from the index.
+func (s *Session) withIndex(msgAndArgs []interface{}) []interface{} {
+	idx := s.index()
+
+	return append(msgAndArgs, optionFunc(func(c *config) {
+		c.index = idx
+	}))
+}
+
+// Exists checks whether a path exists.
+func (s *Session) Exists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Exists(t, s.fs, path, s.withIndex(msgAndArgs)...)
+}
+
+// NoExists checks whether a path does not exist.
+func (s *Session) NoExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoExists(t, s.fs, path, s.withIndex(msgAndArgs)...)
+}
+
+// FileExists checks whether a file exists in the given path.
+func (s *Session) FileExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileExists(t, s.fs, path, s.withIndex(msgAndArgs)...)
+}
+
+// NoFileExists checks whether a file does not exist in the given path.
+func (s *Session) NoFileExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoFileExists(t, s.fs, path, s.withIndex(msgAndArgs)...)
+}
+
+// DirExists checks whether a directory exists in the given path.
+func (s *Session) DirExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return DirExists(t, s.fs, path, s.withIndex(msgAndArgs)...)
+}
+
+// NoDirExists checks whether a directory does not exist in the given path.
+func (s *Session) NoDirExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoDirExists(t, s.fs, path, s.withIndex(msgAndArgs)...)
+}
+
+// Perm checks whether a path has the expected permission.
+func (s *Session) Perm(t TestingT, path string, expected os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Perm(t, s.fs, path, expected, s.withIndex(msgAndArgs)...)
+}
+
+// FileSize checks whether a file has the expected size.
+func (s *Session) FileSize(t TestingT, path string, expected int64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return FileSize(t, s.fs, path, expected, s.withIndex(msgAndArgs)...)
+}
+
+// TreeEqual checks whether a directory is the same as the expectation.
+func (s *Session) TreeEqual(t TestingT, tree FileTree, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeEqual(t, s.fs, tree, path, s.withIndex(msgAndArgs)...)
+}
+
+// YAMLTreeEqual checks whether a directory is the same as the YAML expectation.
+func (s *Session) YAMLTreeEqual(t TestingT, expected, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return YAMLTreeEqual(t, s.fs, expected, path, s.withIndex(msgAndArgs)...)
+}
+
+// TreeContains checks whether a directory contains the expectation.
+func (s *Session) TreeContains(t TestingT, tree FileTree, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeContains(t, s.fs, tree, path, s.withIndex(msgAndArgs)...)
+}
+
+// YAMLTreeContains checks whether a directory contains the YAML expectation.
+func (s *Session) YAMLTreeContains(t TestingT, expected, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return YAMLTreeContains(t, s.fs, expected, path, s.withIndex(msgAndArgs)...)
+}
+
+// TreeWithinQuota checks whether a directory does not exceed the number of bytes and the number of files.
+func (s *Session) TreeWithinQuota(t TestingT, root string, maxBytes, maxFiles int64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return TreeWithinQuota(t, s.fs, root, maxBytes, maxFiles, s.withIndex(msgAndArgs)...)
+}
+
+type statResult struct {
+	info os.FileInfo
+	err  error
+}
+
+type dirResult struct {
+	names []string
+	err   error
+}
+
+// walkIndex contains the stats and the directory listings of a directory, see Session.
+type walkIndex struct {
+	root  string
+	stats map[string]statResult
+	dirs  map[string]dirResult
+}
+
+// buildIndex walks the directory without following the links.
+func buildIndex(fs afero.Fs, root string) *walkIndex {
+	idx := &walkIndex{
+		root:  root,
+		stats: make(map[string]statResult),
+		dirs:  make(map[string]dirResult),
+	}
+
+	idx.add(fs, root)
+
+	return idx
+}
+
+func (idx *walkIndex) add(fs afero.Fs, path string) {
+	info, err := doStat(&config{}, fs, path)
+
+	idx.stats[path] = statResult{info: info, err: err}
+
+	if err != nil || !info.IsDir() {
+		return
+	}
+
+	names, err := readDirNames(fs, path)
+
+	idx.dirs[path] = dirResult{names: names, err: err}
+
+	for _, name := range names {
+		idx.add(fs, filepath.Join(path, name))
+	}
+}
+
+// contains checks whether the path is under the root of the index.
+func (idx *walkIndex) contains(path string) bool {
+	rel, err := filepath.Rel(idx.root, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// stat returns the cached stat of the path, ok is false when the path is not indexed.
+func (idx *walkIndex) stat(path string) (info os.FileInfo, err error, ok bool) { // nolint: revive,stylecheck
+	path = filepath.Clean(path)
+
+	if !idx.contains(path) {
+		return nil, nil, false
+	}
+
+	if r, ok := idx.stats[path]; ok {
+		return r.info, r.err, true
+	}
+
+	// The path does not exist if its parent was listed successfully.
+	if d, ok := idx.dirs[filepath.Dir(path)]; ok && d.err == nil {
+		return nil, &os.PathError{Op: "lstat", Path: path, Err: os.ErrNotExist}, true
+	}
+
+	return nil, nil, false
+}
+
+// readDirNames returns the cached names of the directory, ok is false when the directory is not indexed.
+func (idx *walkIndex) readDirNames(path string) ([]string, error, bool) { // nolint: revive,stylecheck
+	d, ok := idx.dirs[filepath.Clean(path)]
+
+	return d.names, d.err, ok
+}
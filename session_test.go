@@ -0,0 +1,70 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	s := aferoassert.NewSession(fs, "root")
+	mockT := &testingT{}
+
+	var stats aferoassert.Stats
+
+	expected := `
+- file 1 'content:"line 1\nline 2\n"'
+- folder 2:
+    - file 2 'perm:"0600"'
+`
+
+	assert.True(t, s.YAMLTreeEqual(mockT, expected, "root", aferoassert.WithStats(&stats)), mockT.message())
+	assert.True(t, s.FileExists(mockT, "root/file 1", aferoassert.WithStats(&stats)), mockT.message())
+	assert.True(t, s.Perm(mockT, "root/folder 2", 0o755, aferoassert.WithStats(&stats)), mockT.message())
+	assert.True(t, s.NoExists(mockT, "root/unknown", aferoassert.WithStats(&stats)), mockT.message())
+	assert.True(t, s.TreeWithinQuota(mockT, "root", 20, 3, aferoassert.WithStats(&stats)), mockT.message())
+
+	// Nothing is stat'ed, the file system is walked once by NewSession.
+	assert.Equal(t, 5, stats.Assertions)
+	assert.Zero(t, stats.FilesVisited)
+
+	// The index is stale until it is invalidated.
+	require.NoError(t, afero.WriteFile(fs, "root/file 3", nil, 0o644))
+
+	assert.True(t, s.YAMLTreeEqual(mockT, expected, "root"), mockT.message())
+	assert.True(t, s.NoFileExists(mockT, "root/file 3"), mockT.message())
+
+	s.Invalidate()
+
+	assert.False(t, s.YAMLTreeEqual(mockT, expected, "root"))
+	assert.Contains(t, mockT.message(), `unexpected file "root/file 3"`)
+
+	mockT = &testingT{}
+
+	assert.True(t, s.FileExists(mockT, "root/file 3"), mockT.message())
+}
+
+func TestSession_OutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	s := aferoassert.NewSession(fs, "root/folder 2")
+	mockT := &testingT{}
+
+	var stats aferoassert.Stats
+
+	assert.True(t, s.FileExists(mockT, "root/file 1", aferoassert.WithStats(&stats)), mockT.message())
+	assert.True(t, s.FileExists(mockT, "root/folder 2/file 2", aferoassert.WithStats(&stats)), mockT.message())
+
+	assert.Equal(t, 1, stats.FilesVisited)
+
+	assert.False(t, s.FileExists(mockT, "root/folder 2/unknown"))
+	assert.Contains(t, mockT.message(), `unable to find file "root/folder 2/unknown"`)
+}
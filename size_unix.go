@@ -0,0 +1,19 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package aferoassert
+
+import (
+	"os"
+	"syscall"
+)
+
+// allocatedSize returns the number of bytes allocated on disk for the file.
+func allocatedSize(info os.FileInfo) (int64, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+
+	return int64(st.Blocks) * 512, true //nolint: unconvert
+}
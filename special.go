@@ -0,0 +1,61 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// specialFileModes are the types of the entries that archive formats, such as tar or zip, can not store portably.
+const specialFileModes = os.ModeDevice | os.ModeCharDevice | os.ModeNamedPipe | os.ModeSocket | os.ModeIrregular
+
+// NoSpecialFiles checks whether a directory contains only regular files, directories and symlinks, and reports all the
+// devices, named pipes, sockets and irregular files together, for example, before the directory is archived.
+func NoSpecialFiles(t TestingT, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NoSpecialFiles", func(t TestingT) bool {
+		return assertNoSpecialFiles(t, c, fs, root, msgAndArgs...)
+	})
+}
+
+func assertNoSpecialFiles(t TestingT, c *config, fs afero.Fs, root string, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+	special := make([]string, 0)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if rel := relPath(root, path); rel != "." && c.ignored(rel) {
+			return skip(info)
+		}
+
+		if mode := info.Mode().Type(); mode&specialFileModes != 0 {
+			special = append(special, fmt.Sprintf("%q is a special file (%s)", path, FormatFileMode(mode)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not walk through %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if len(special) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  special,
+		Message: fmt.Sprintf("%q contains special files:\n- %s", root, strings.Join(special, "\n- ")),
+	}, msgAndArgs...)
+}
@@ -0,0 +1,74 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// specialFs reports the types of some paths, because the special files can not be created in afero.MemMapFs.
+type specialFs struct {
+	afero.Fs
+
+	types map[string]os.FileMode
+}
+
+func (fs specialFs) Stat(name string) (os.FileInfo, error) {
+	info, err := fs.Fs.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode, ok := fs.types[name]; ok {
+		return typedFileInfo{FileInfo: info, mode: mode | info.Mode().Perm()}, nil
+	}
+
+	return info, nil
+}
+
+type typedFileInfo struct {
+	os.FileInfo
+
+	mode os.FileMode
+}
+
+func (i typedFileInfo) Mode() os.FileMode {
+	return i.mode
+}
+
+func TestNoSpecialFiles(t *testing.T) {
+	t.Parallel()
+
+	mem := afero.NewMemMapFs()
+
+	require.NoError(t, mem.MkdirAll("root/run", 0o755))
+	require.NoError(t, afero.WriteFile(mem, "root/file", nil, 0o644))
+	require.NoError(t, afero.WriteFile(mem, "root/run/fifo", nil, 0o644))
+	require.NoError(t, afero.WriteFile(mem, "root/run/app.sock", nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.NoSpecialFiles(mockT, mem, "root"), mockT.message())
+
+	fs := specialFs{Fs: mem, types: map[string]os.FileMode{
+		"root/run/fifo":     os.ModeNamedPipe,
+		"root/run/app.sock": os.ModeSocket,
+	}}
+
+	assert.False(t, aferoassert.NoSpecialFiles(mockT, fs, "root"))
+	assert.Contains(t, mockT.message(), `"root" contains special files:`)
+	assert.Contains(t, mockT.message(), `"root/run/app.sock" is a special file (Socket)`)
+	assert.Contains(t, mockT.message(), `"root/run/fifo" is a special file (NamedPipe)`)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.NoSpecialFiles(mockT, fs, "root", aferoassert.IgnorePaths("run")), mockT.message())
+
+	assert.False(t, aferoassert.NoSpecialFiles(mockT, fs, "unknown"))
+	assert.Contains(t, mockT.message(), `could not walk through "unknown"`)
+}
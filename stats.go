@@ -0,0 +1,49 @@
+package aferoassert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+var statsMu sync.Mutex
+
+// Stats are the metrics of the assertions, see WithStats. The metrics of all the assertions using the same Stats are
+// added up.
+type Stats struct {
+	// Assertions is the number of assertions.
+	Assertions int
+	// FilesVisited is the number of paths that were stat'ed.
+	FilesVisited int
+	// BytesRead is the number of bytes read from the files.
+	BytesRead int64
+	// Duration is the wall time spent in the assertions, including the retries.
+	Duration time.Duration
+}
+
+// recordStats adds the metrics of the assertion to the stats.
+func (c *config) recordStats(start time.Time) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	c.stats.Assertions++
+	c.stats.FilesVisited += c.visited
+	c.stats.BytesRead += c.bytesRead
+	c.stats.Duration += time.Since(start)
+}
+
+// countingFile counts the bytes read from a file, see WithStats.
+type countingFile struct {
+	afero.File
+
+	c *config
+}
+
+func (f *countingFile) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+
+	f.c.bytesRead += int64(n)
+
+	return n, err
+}
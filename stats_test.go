@@ -0,0 +1,35 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestWithStats(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	var stats aferoassert.Stats
+
+	assert.True(t, aferoassert.FileContent(mockT, fs, "root/file 1", "line 1\nline 2\n", aferoassert.WithStats(&stats)), mockT.message())
+
+	assert.Equal(t, 1, stats.Assertions)
+	assert.Equal(t, 1, stats.FilesVisited)
+	assert.Equal(t, int64(14), stats.BytesRead)
+	assert.Positive(t, stats.Duration)
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, `
+- file 1 'content:"line 1\nline 2\n"'
+- folder 2:
+    - file 2
+`, "root", aferoassert.WithStats(&stats)), mockT.message())
+
+	assert.Equal(t, 2, stats.Assertions)
+	assert.Equal(t, 5, stats.FilesVisited)
+	assert.Equal(t, int64(28), stats.BytesRead)
+}
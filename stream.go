@@ -0,0 +1,57 @@
+package aferoassert
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// regexpPreviewSize is the number of bytes of the content shown when FileContentRegexp fails.
+const regexpPreviewSize = 4096
+
+// errRuneReader records the read error that regexp.MatchReader ignores.
+type errRuneReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *errRuneReader) ReadRune() (rune, int, error) {
+	c, n, err := r.r.ReadRune()
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+
+	return c, n, err
+}
+
+// previewBuffer keeps the first bytes written to it and counts all of them.
+type previewBuffer struct {
+	buf       []byte
+	limit     int
+	truncated bool
+	size      int64
+}
+
+func (b *previewBuffer) Write(p []byte) (int, error) {
+	b.size += int64(len(p))
+
+	if n := b.limit - len(b.buf); n < len(p) {
+		b.truncated = true
+
+		if n > 0 {
+			b.buf = append(b.buf, p[:n]...)
+		}
+	} else {
+		b.buf = append(b.buf, p...)
+	}
+
+	return len(p), nil
+}
+
+func (b *previewBuffer) String() string {
+	if b.truncated {
+		return string(b.buf) + "..."
+	}
+
+	return string(b.buf)
+}
@@ -0,0 +1,121 @@
+package aferoassert_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+const subtestTree = `
+- bin:
+    - app 'perm:"0755"'
+- config.yaml 'content:"debug=true"'
+- README.md
+`
+
+func newSubtestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/bin/app", nil, 0o755))
+	require.NoError(t, afero.WriteFile(fs, "dist/config.yaml", []byte("debug=true"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/README.md", nil, 0o644))
+
+	return fs
+}
+
+func TestWithSubtests(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, aferoassert.YAMLTreeEqual(t, newSubtestFs(t), subtestTree, "dist", aferoassert.WithSubtests()))
+
+	// Without the support of subtests, the paths are checked in place.
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, newSubtestFs(t), subtestTree, "dist", aferoassert.WithSubtests()), mockT.message())
+}
+
+// TestWithSubtests_Failures runs the failing assertions in a child process because the failures of the subtests can
+// not be intercepted.
+func TestWithSubtests_Failures(t *testing.T) {
+	t.Parallel()
+
+	if os.Getenv("AFEROASSERT_SUBTESTS") == "1" {
+		fs := newSubtestFs(t)
+
+		require.NoError(t, afero.WriteFile(fs, "dist/config.yaml", []byte("debug=false"), 0o644))
+		require.NoError(t, fs.Remove("dist/README.md"))
+
+		aferoassert.YAMLTreeEqual(t, fs, subtestTree, "dist", aferoassert.WithSubtests())
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestWithSubtests_Failures$", "-test.v") // nolint: gosec
+	cmd.Env = append(os.Environ(), "AFEROASSERT_SUBTESTS=1")
+
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err, string(out))
+
+	assert.Contains(t, string(out), "--- PASS: TestWithSubtests_Failures/bin/app")
+	assert.Contains(t, string(out), "--- FAIL: TestWithSubtests_Failures/config.yaml")
+	assert.Contains(t, string(out), `"dist/config.yaml" content is not as expected`)
+	assert.Contains(t, string(out), "--- FAIL: TestWithSubtests_Failures/README.md")
+	assert.Contains(t, string(out), `expected "dist/README.md" but not found`)
+}
+
+// runnerT is a TestingT that runs the subtests in place and records the failures of every subtest.
+type runnerT struct {
+	testingT
+
+	subtests map[string][]string
+}
+
+func (t *runnerT) Run(name string, f func(t aferoassert.TestingT)) bool {
+	sub := &runnerT{}
+
+	f(sub)
+
+	if t.subtests == nil {
+		t.subtests = make(map[string][]string)
+	}
+
+	t.subtests[name] = sub.errors
+
+	for n, errs := range sub.subtests {
+		t.subtests[name+"/"+n] = errs
+	}
+
+	return len(sub.errors) == 0
+}
+
+func TestWithSubtests_Runner(t *testing.T) {
+	t.Parallel()
+
+	fs := newSubtestFs(t)
+
+	require.NoError(t, afero.WriteFile(fs, "dist/config.yaml", []byte("debug=false"), 0o644))
+	require.NoError(t, fs.Remove("dist/README.md"))
+
+	mockT := &runnerT{}
+
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, subtestTree, "dist", aferoassert.WithSubtests()))
+	assert.Empty(t, mockT.errors)
+
+	require.Contains(t, mockT.subtests, "bin/app")
+	assert.Empty(t, mockT.subtests["bin/app"])
+
+	require.Len(t, mockT.subtests["config.yaml"], 1)
+	assert.Contains(t, mockT.subtests["config.yaml"][0], `"dist/config.yaml" content is not as expected`)
+
+	// The missing paths are reported in a subtest each.
+	require.Len(t, mockT.subtests["README.md"], 1)
+	assert.Contains(t, mockT.subtests["README.md"][0], `expected "dist/README.md" but not found`)
+}
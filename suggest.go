@@ -0,0 +1,118 @@
+package aferoassert
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const maxSuggestionDistance = 3
+
+// didYouMean looks for an entry in the parent directory whose name is close to the base name of the path and returns
+// a hint like `, did you mean "config.yaml"?`. It returns an empty string if there is no such entry.
+func didYouMean(fs afero.Fs, path string) string {
+	if s := suggestPath(fs, path); s != "" {
+		return fmt.Sprintf(", did you mean %q?", s)
+	}
+
+	return ""
+}
+
+func suggestPath(fs afero.Fs, path string) string {
+	dir, name := filepath.Split(path)
+
+	entries, err := afero.ReadDir(fs, filepath.Clean(dir))
+	if err != nil {
+		return ""
+	}
+
+	best := ""
+	bestScore := -1
+
+	for _, e := range entries {
+		if e.Name() == name {
+			continue
+		}
+
+		score, ok := nameDistance(name, e.Name())
+		if !ok {
+			continue
+		}
+
+		if bestScore == -1 || score < bestScore {
+			best, bestScore = e.Name(), score
+		}
+	}
+
+	if best == "" {
+		return ""
+	}
+
+	return dir + best
+}
+
+// nameDistance tells how close the two names are. A case difference and a wrong extension are considered closer than
+// any typo.
+func nameDistance(expected, actual string) (int, bool) {
+	if strings.EqualFold(expected, actual) {
+		return 0, true
+	}
+
+	if base := trimExt(expected); base != "" && base == trimExt(actual) {
+		return 1, true
+	}
+
+	threshold := len([]rune(expected))/4 + 1
+	if threshold > maxSuggestionDistance {
+		threshold = maxSuggestionDistance
+	}
+
+	if d := levenshtein(expected, actual); d <= threshold {
+		return d + 1, true
+	}
+
+	return 0, false
+}
+
+func trimExt(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(v int, others ...int) int {
+	for _, o := range others {
+		if o < v {
+			v = o
+		}
+	}
+
+	return v
+}
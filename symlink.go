@@ -0,0 +1,116 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	symlinkRelative = "relative"
+	symlinkAbsolute = "absolute"
+)
+
+// SymlinkIsRelative checks whether a path is a symlink with a relative target, so the tree can be relocated.
+func SymlinkIsRelative(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "SymlinkIsRelative", func(t TestingT) bool {
+		if msg, ok := checkSymlinkFlavor(fs, path, symlinkRelative); !ok {
+			return c.fail(t, FailureInfo{Path: path, Expected: symlinkRelative, Message: msg}, msgAndArgs...)
+		}
+
+		return true
+	})
+}
+
+// SymlinkIsAbsolute checks whether a path is a symlink with an absolute target.
+func SymlinkIsAbsolute(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "SymlinkIsAbsolute", func(t TestingT) bool {
+		if msg, ok := checkSymlinkFlavor(fs, path, symlinkAbsolute); !ok {
+			return c.fail(t, FailureInfo{Path: path, Expected: symlinkAbsolute, Message: msg}, msgAndArgs...)
+		}
+
+		return true
+	})
+}
+
+// checkSymlinkFlavor checks whether the path is a symlink with a relative or an absolute target, it is also used by
+// the `link` tag of the tree assertions.
+func checkSymlinkFlavor(fs afero.Fs, path, flavor string) (string, bool) {
+	if flavor != symlinkRelative && flavor != symlinkAbsolute {
+		return fmt.Sprintf("invalid symlink flavor %q for %q, expected %q or %q", flavor, path, symlinkRelative, symlinkAbsolute), false
+	}
+
+	target, msg, ok := readSymlink(fs, path)
+	if !ok {
+		return msg, false
+	}
+
+	actual := symlinkRelative
+	if filepath.IsAbs(target) {
+		actual = symlinkAbsolute
+	}
+
+	if actual != flavor {
+		return fmt.Sprintf("%q links to %q which is %s, expected %s", path, target, actual, flavor), false
+	}
+
+	return "", true
+}
+
+// checkSymlinkTarget checks whether the path is a symlink to the target, it is used by the `target` tag of the tree
+// assertions.
+func checkSymlinkTarget(fs afero.Fs, path, expected string) (string, bool) {
+	target, msg, ok := readSymlink(fs, path)
+	if !ok {
+		return msg, false
+	}
+
+	if target != expected {
+		return fmt.Sprintf("%q links to %q, expected %q", path, target, expected), false
+	}
+
+	return "", true
+}
+
+// readSymlink reads the target of a symlink, or returns the failure message if the path is not a symlink.
+func readSymlink(fs afero.Fs, path string) (string, string, bool) {
+	l, ok := fs.(afero.Lstater)
+	if !ok {
+		return "", fmt.Sprintf("%s does not support symlinks", fs.Name()), false
+	}
+
+	r, ok := fs.(afero.LinkReader)
+	if !ok {
+		return "", fmt.Sprintf("%s does not support symlinks", fs.Name()), false
+	}
+
+	info, _, err := l.LstatIfPossible(path)
+	if err != nil {
+		return "", statFailure(fs, path, err), false
+	}
+
+	if info.Mode()&os.ModeSymlink == 0 {
+		return "", fmt.Sprintf("%q is not a symlink", path), false
+	}
+
+	target, err := r.ReadlinkIfPossible(path)
+	if err != nil {
+		return "", fmt.Sprintf("could not read the symlink %q: %s", path, err), false
+	}
+
+	return target, "", true
+}
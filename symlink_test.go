@@ -0,0 +1,78 @@
+package aferoassert_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func newSymlinkFs(t *testing.T) (afero.Fs, string) {
+	t.Helper()
+
+	root := filepath.Join(t.TempDir(), "root")
+	fs := afero.NewOsFs()
+
+	require.NoError(t, fs.MkdirAll(filepath.Join(root, "bin"), 0o755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(root, "bin", "app"), nil, 0o755))
+	require.NoError(t, os.Symlink("bin/app", filepath.Join(root, "relative")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "bin", "app"), filepath.Join(root, "absolute")))
+
+	return fs, root
+}
+
+func TestSymlinkIsRelative(t *testing.T) {
+	t.Parallel()
+
+	fs, root := newSymlinkFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.SymlinkIsRelative(mockT, fs, filepath.Join(root, "relative")), mockT.message())
+	assert.True(t, aferoassert.SymlinkIsAbsolute(mockT, fs, filepath.Join(root, "absolute")), mockT.message())
+
+	assert.False(t, aferoassert.SymlinkIsRelative(mockT, fs, filepath.Join(root, "absolute")))
+	assert.Contains(t, mockT.message(), `absolute" links to`)
+	assert.Contains(t, mockT.message(), "which is absolute, expected relative")
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.SymlinkIsAbsolute(mockT, fs, filepath.Join(root, "relative")))
+	assert.Contains(t, mockT.message(), `relative" links to "bin/app" which is relative, expected absolute`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.SymlinkIsRelative(mockT, fs, filepath.Join(root, "bin", "app")))
+	assert.Contains(t, mockT.message(), `app" is not a symlink`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.SymlinkIsRelative(mockT, afero.NewMemMapFs(), "link"))
+	assert.Contains(t, mockT.message(), "MemMapFS does not support symlinks")
+}
+
+func TestSymlinkFlavor_Tag(t *testing.T) {
+	t.Parallel()
+
+	fs, root := newSymlinkFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeContains(mockT, fs, `
+- relative 'link:"relative"'
+- absolute 'link:"absolute"'
+`, root), mockT.message())
+
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, `
+- relative 'link:"relative"'
+- absolute 'link:"relative"'
+- bin:
+    - app 'link:"sideways"'
+`, root))
+	assert.Contains(t, mockT.message(), "which is absolute, expected relative")
+	assert.Contains(t, mockT.message(), `invalid symlink flavor "sideways"`)
+	assert.NotContains(t, mockT.message(), `relative" links`)
+}
@@ -0,0 +1,82 @@
+package aferoassert
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WriteTAP writes the result of a tree assertion in the Test Anything Protocol, with one "ok" or "not ok" test point per
+// expected path of the tree, so the result can be consumed by the harnesses that are not written in Go. The failures
+// are the ones of the assertion, see Collector.WriteTAP, WithOnFailure and ValidationError. The failures of the paths
+// that are not in the tree, such as the unexpected files, are written as extra "not ok" test points. The failure
+// messages are written as diagnostics.
+//
+//	err := aferoassert.Validate(fs, tree, "dist")
+//
+//	var verr *aferoassert.ValidationError
+//	if errors.As(err, &verr) {
+//		aferoassert.WriteTAP(os.Stdout, tree, "dist", verr.Failures)
+//	}
+func WriteTAP(w io.Writer, tree FileTree, root string, failures []FailureInfo) error {
+	root = filepath.Clean(root)
+	expected := tree.Flatten(root)
+	paths := make([]string, 0, len(expected))
+
+	for p := range expected {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	messages := make(map[string][]string)
+	extra := make([]string, 0)
+
+	for _, f := range failures {
+		for p, msg := range tapFailures(f) {
+			if _, ok := expected[p]; !ok && len(messages[p]) == 0 {
+				extra = append(extra, p)
+			}
+
+			messages[p] = append(messages[p], msg)
+		}
+	}
+
+	paths = append(paths, extra...)
+	bw := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(bw, "TAP version 13\n1..%d\n", len(paths))
+
+	for i, p := range paths {
+		if len(messages[p]) == 0 {
+			_, _ = fmt.Fprintf(bw, "ok %d - %s\n", i+1, p)
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(bw, "not ok %d - %s\n", i+1, p)
+
+		for _, msg := range messages[p] {
+			for _, l := range strings.Split(strings.TrimRight(msg, "\n"), "\n") {
+				_, _ = fmt.Fprintf(bw, "# %s\n", l)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// tapFailures maps a failure to the paths it is about, see splitNotFound.
+func tapFailures(f FailureInfo) map[string]string {
+	split := splitNotFound(f)
+	result := make(map[string]string, len(split))
+
+	for _, f := range split {
+		result[filepath.Clean(f.Path)] = f.Message
+	}
+
+	return result
+}
@@ -0,0 +1,139 @@
+package aferoassert_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestWriteTAP(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/assets/app.js", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", nil, 0o600))
+	require.NoError(t, afero.WriteFile(fs, "dist/debug.log", nil, 0o644))
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- assets:
+    - app.js
+    - app.css
+- index.html 'perm:"0644"'
+- robots.txt
+`), &tree))
+
+	c := aferoassert.NewCollector(&testingT{})
+
+	assert.False(t, aferoassert.TreeEqual(c, fs, tree, "dist"))
+
+	var buf bytes.Buffer
+
+	require.NoError(t, c.WriteTAP(&buf, tree, "dist"))
+
+	expected := `TAP version 13
+1..6
+ok 1 - dist/assets
+not ok 2 - dist/assets/app.css
+# "dist/assets/app.css" not found
+ok 3 - dist/assets/app.js
+not ok 4 - dist/index.html
+# "dist/index.html" perm is 0600, expected 0644
+not ok 5 - dist/robots.txt
+# "dist/robots.txt" not found
+not ok 6 - dist/debug.log
+# unexpected file "dist/debug.log"
+`
+
+	assert.Equal(t, expected, buf.String())
+
+	// The failures are forgotten after the report.
+	assert.False(t, c.Report())
+
+	buf.Reset()
+
+	require.NoError(t, c.WriteTAP(&buf, tree, "dist"))
+	assert.NotContains(t, buf.String(), "not ok")
+}
+
+func TestWriteTAP_WithFormatter(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", nil, 0o644))
+
+	tree := aferoassert.FileTree{
+		"index.html": {Name: "index.html"},
+		"robots.txt": {Name: "robots.txt"},
+	}
+
+	c := aferoassert.NewCollector(&testingT{})
+
+	assert.False(t, aferoassert.TreeEqual(c, fs, tree, "dist", aferoassert.WithFormatter(aferoassert.FormatterFunc(func(info aferoassert.FailureInfo) string {
+		return "failed: " + info.Path
+	}))))
+
+	var buf bytes.Buffer
+
+	require.NoError(t, c.WriteTAP(&buf, tree, "dist"))
+
+	expected := `TAP version 13
+1..2
+ok 1 - dist/index.html
+not ok 2 - dist/robots.txt
+# "dist/robots.txt" not found
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteTAP_WithActualTree(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", nil, 0o600))
+
+	tree := aferoassert.FileTree{
+		"index.html": {Name: "index.html", Tags: aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(0o644)}},
+	}
+
+	c := aferoassert.NewCollector(&testingT{})
+
+	assert.False(t, aferoassert.TreeEqual(c, fs, tree, "dist", aferoassert.WithActualTree()))
+
+	var buf bytes.Buffer
+
+	require.NoError(t, c.WriteTAP(&buf, tree, "dist"))
+
+	assert.Contains(t, buf.String(), "1..1\nnot ok 1 - dist/index.html\n")
+	assert.Contains(t, buf.String(), `# actual tree of "dist":`)
+	assert.Equal(t, 1, strings.Count(buf.String(), "not ok"))
+}
+
+func TestWriteTAP_NoFailures(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/index.html", []byte("<html>"), 0o644))
+
+	tree := aferoassert.FileTree{"index.html": {Name: "index.html"}}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, aferoassert.WriteTAP(&buf, tree, "dist/", nil))
+	assert.Equal(t, "TAP version 13\n1..1\nok 1 - dist/index.html\n", buf.String())
+
+	assert.EqualError(t, aferoassert.WriteTAP(failingWriter{}, tree, "dist", nil), "write error")
+}
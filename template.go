@@ -0,0 +1,129 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidRange indicates that a range of a file name, such as `file-{001..500}.dat`, is invalid.
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrInvalidFileSize indicates that the value of the `size` tag is invalid.
+	ErrInvalidFileSize = errors.New("invalid file size")
+)
+
+// maxExpandedNames is the maximum number of names a name with ranges is expanded to, so a typo such as
+// `file-{1..100000000}` is reported instead of allocating millions of nodes.
+const maxExpandedNames = 10000
+
+var (
+	// rangePattern matches a range, or an escaped range, such as `\{1..3}`, that is kept as is.
+	rangePattern    = regexp.MustCompile(`\\?\{(\d+)\.\.(\d+)\}`)
+	literalRange    = regexp.MustCompile(`\{\d+\.\.\d+\}`)
+	fileSizePattern = regexp.MustCompile(`^(\d+)\s*([KMG]i?B?|B)?$`)
+
+	fileSizeUnits = map[string]int64{
+		"":  1,
+		"B": 1,
+		"K": 1 << 10,
+		"M": 1 << 20,
+		"G": 1 << 30,
+	}
+)
+
+// expandName expands the ranges of a file name, for example `file-{01..03}.dat` is expanded to `file-01.dat`,
+// `file-02.dat` and `file-03.dat`. The numbers are padded with zeros to the width of the start of the range when it
+// starts with a zero. A name with several ranges is expanded to all the combinations, up to maxExpandedNames names. A
+// range that is escaped with a backslash, such as `file-\{1..3}`, is kept as is, without the backslash.
+func expandName(name string) ([]string, error) {
+	loc := rangePattern.FindStringSubmatchIndex(name)
+	if loc == nil {
+		return []string{name}, nil
+	}
+
+	if name[loc[0]] == '\\' {
+		rest, err := expandName(name[loc[1]:])
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, 0, len(rest))
+
+		for _, r := range rest {
+			result = append(result, name[:loc[0]]+name[loc[0]+1:loc[1]]+r)
+		}
+
+		return result, nil
+	}
+
+	first, last := name[loc[2]:loc[3]], name[loc[4]:loc[5]]
+
+	start, err := strconv.Atoi(first)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q in %q: %s", ErrInvalidRange, name[loc[0]:loc[1]], name, err)
+	}
+
+	end, err := strconv.Atoi(last)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q in %q: %s", ErrInvalidRange, name[loc[0]:loc[1]], name, err)
+	}
+
+	if start > end {
+		return nil, fmt.Errorf("%w %q in %q: the start is greater than the end", ErrInvalidRange, name[loc[0]:loc[1]], name)
+	}
+
+	width := 0
+	if len(first) > 1 && strings.HasPrefix(first, "0") {
+		width = len(first)
+	}
+
+	// The rest of the name may have more ranges.
+	rest, err := expandName(name[loc[1]:])
+	if err != nil {
+		return nil, err
+	}
+
+	if end-start >= maxExpandedNames || (end-start+1)*len(rest) > maxExpandedNames {
+		return nil, fmt.Errorf("%w %q in %q: the name is expanded to more than %d names", ErrInvalidRange, name[loc[0]:loc[1]], name, maxExpandedNames)
+	}
+
+	result := make([]string, 0, (end-start+1)*len(rest))
+
+	for i := start; i <= end; i++ {
+		for _, r := range rest {
+			result = append(result, fmt.Sprintf("%s%0*d%s", name[:loc[0]], width, i, r))
+		}
+	}
+
+	return result, nil
+}
+
+// parseFileSize parses the value of the `size` tag, such as "512", "4KB" or "1MiB". The units are powers of 1024.
+func parseFileSize(s string) (int64, error) {
+	m := fileSizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("%w %q", ErrInvalidFileSize, s)
+	}
+
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %s", ErrInvalidFileSize, s, err)
+	}
+
+	unit := fileSizeUnits[strings.TrimRight(m[2], "iB")]
+
+	if n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("%w %q: the size overflows int64", ErrInvalidFileSize, s)
+	}
+
+	return n * unit, nil
+}
+
+// escapeName escapes the ranges of a file name with a backslash, so the name is not expanded when it is unmarshaled.
+func escapeName(name string) string {
+	return literalRange.ReplaceAllString(name, `\$0`)
+}
@@ -0,0 +1,225 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileTree_UnmarshalYAML_Range(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		tree          string
+		expectedPaths []string
+		expectedError string
+	}{
+		{
+			scenario:      "no range",
+			tree:          `- file.dat`,
+			expectedPaths: []string{"file.dat"},
+		},
+		{
+			scenario:      "range",
+			tree:          `- file-{1..3}.dat`,
+			expectedPaths: []string{"file-1.dat", "file-2.dat", "file-3.dat"},
+		},
+		{
+			scenario:      "padded range",
+			tree:          `- file-{08..10}.dat 'perm:"0600"'`,
+			expectedPaths: []string{"file-08.dat", "file-09.dat", "file-10.dat"},
+		},
+		{
+			scenario: "nested ranges",
+			tree: `
+- shard-{1..2}:
+    - part-{a..b}-{0..1}
+`,
+			expectedPaths: []string{
+				"shard-1", "shard-1/part-{a..b}-0", "shard-1/part-{a..b}-1",
+				"shard-2", "shard-2/part-{a..b}-0", "shard-2/part-{a..b}-1",
+			},
+		},
+		{
+			scenario:      "descending range",
+			tree:          `- file-{3..1}.dat`,
+			expectedError: `invalid range "{3..1}" in "file-{3..1}.dat": the start is greater than the end`,
+		},
+		{
+			scenario:      "range overflow",
+			tree:          `- file-{1..99999999999999999999}.dat`,
+			expectedError: `invalid range "{1..99999999999999999999}" in "file-{1..99999999999999999999}.dat"`,
+		},
+		{
+			scenario:      "too many names",
+			tree:          `- file-{1..100000000}.dat`,
+			expectedError: `invalid range "{1..100000000}" in "file-{1..100000000}.dat": the name is expanded to more than 10000 names`,
+		},
+		{
+			scenario:      "too many combinations",
+			tree:          `- file-{1..200}-{1..100}.dat`,
+			expectedError: `the name is expanded to more than 10000 names`,
+		},
+		{
+			scenario:      "escaped range",
+			tree:          `- file-\{1..3}-{1..2}.dat`,
+			expectedPaths: []string{"file-{1..3}-1.dat", "file-{1..3}-2.dat"},
+		},
+		{
+			scenario:      "size overflow",
+			tree:          `- file.dat 'size:"9999999999999999999G"'`,
+			expectedError: `invalid file size "9999999999999999999G"`,
+		},
+		{
+			scenario:      "size multiplication overflow",
+			tree:          `- file.dat 'size:"9000000000000G"'`,
+			expectedError: `invalid file size "9000000000000G": the size overflows int64 in "size" tag at line 1`,
+		},
+		{
+			scenario:      "invalid size",
+			tree:          `- file.dat 'size:"4XB"'`,
+			expectedError: `invalid file size "4XB" in "size" tag at line 1`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			var tree aferoassert.FileTree
+
+			err := yaml.Unmarshal([]byte(tc.tree), &tree)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			actual := make([]string, 0)
+
+			for p := range tree.Flatten("") {
+				actual = append(actual, p)
+			}
+
+			assert.ElementsMatch(t, tc.expectedPaths, actual)
+		})
+	}
+}
+
+func TestFileTree_UnmarshalYAML_RangeIsolated(t *testing.T) {
+	t.Parallel()
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- shard-{1..2} 'perm:"0700"':
+    - data 'content:"a"'
+`), &tree))
+
+	tree["shard-1"].Children["data"].Attrs["content"] = "b"
+	*tree["shard-1"].Tags.Perm() = 0o755
+
+	content, _ := tree["shard-2"].Children["data"].Attrs.Content()
+
+	assert.Equal(t, "a", content)
+	assert.Equal(t, 0o700, int(*tree["shard-2"].Tags.Perm()))
+}
+
+func TestFileTree_MarshalYAML_EscapedRange(t *testing.T) {
+	t.Parallel()
+
+	tree := aferoassert.FileTree{"file-{1..3}.dat": {Name: "file-{1..3}.dat"}}
+
+	out, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+
+	assert.Equal(t, "- file-\\{1..3}.dat\n", string(out))
+
+	var actual aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal(out, &actual))
+	assert.Equal(t, tree, actual)
+}
+
+func TestFileAttrs_Size(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		size           string
+		expectedSize   int64
+		expectedResult bool
+	}{
+		{size: "512", expectedSize: 512, expectedResult: true},
+		{size: "512B", expectedSize: 512, expectedResult: true},
+		{size: "4K", expectedSize: 4096, expectedResult: true},
+		{size: "4KB", expectedSize: 4096, expectedResult: true},
+		{size: "4KiB", expectedSize: 4096, expectedResult: true},
+		{size: "2MB", expectedSize: 2 << 20, expectedResult: true},
+		{size: "1GiB", expectedSize: 1 << 30, expectedResult: true},
+		{size: "1.5MB"},
+		{size: "-1"},
+		{size: "KB"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.size, func(t *testing.T) {
+			t.Parallel()
+
+			size, ok := aferoassert.FileAttrs{"size": tc.size}.Size()
+
+			assert.Equal(t, tc.expectedSize, size)
+			assert.Equal(t, tc.expectedResult, ok)
+		})
+	}
+
+	_, ok := aferoassert.FileAttrs{}.Size()
+
+	assert.False(t, ok)
+}
+
+func TestScaffoldYAML_Range(t *testing.T) {
+	t.Parallel()
+
+	const tree = `
+- data:
+    - file-{001..500}.dat 'size:"4KB"'
+- empty-{1..2}: {}
+`
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, aferoassert.ScaffoldYAML(fs, "load", tree))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "load"), mockT.message())
+	assert.True(t, aferoassert.FileSize(mockT, fs, "load/data/file-500.dat", 4096), mockT.message())
+	assert.True(t, aferoassert.DirExists(mockT, fs, "load/empty-2"), mockT.message())
+
+	require.NoError(t, fs.Remove("load/data/file-250.dat"))
+	require.NoError(t, afero.WriteFile(fs, "load/data/file-001.dat", []byte("small"), 0o644))
+
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "load"))
+	assert.Contains(t, mockT.message(), `"load/data/file-001.dat" size is 5, expected 4096`)
+	assert.Contains(t, mockT.message(), "- file-250.dat")
+	assert.NotContains(t, mockT.message(), "file-249.dat")
+
+	files, err := afero.ReadDir(fs, "load/data")
+	require.NoError(t, err)
+
+	assert.Len(t, files, 499)
+}
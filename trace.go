@@ -0,0 +1,52 @@
+package aferoassert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+type tLogger interface {
+	Log(args ...interface{})
+}
+
+// tracef records a step of the assertion, see WithTrace.
+func (c *config) tracef(format string, args ...interface{}) {
+	if c.trace {
+		c.traceSteps = append(c.traceSteps, fmt.Sprintf(format, args...))
+	}
+}
+
+// logTrace logs the recorded steps with t.Log, if it is supported.
+func (c *config) logTrace(t TestingT) {
+	if _, retrying := t.(*silentT); retrying || len(c.traceSteps) == 0 {
+		return
+	}
+
+	if l, ok := t.(tLogger); ok {
+		l.Log(fmt.Sprintf("%s trace:\n\t%s", c.assertion, strings.Join(c.traceSteps, "\n\t")))
+	}
+}
+
+func openFile(c *config, fs afero.Fs, path string) (afero.File, error) {
+	f, err := fs.Open(path)
+
+	c.tracef("open(%q): err: %v", path, err)
+
+	if err == nil && c.stats != nil {
+		f = &countingFile{File: f, c: c}
+	}
+
+	return f, err
+}
+
+func readFile(c *config, fs afero.Fs, path string) ([]byte, error) {
+	data, err := afero.ReadFile(fs, path)
+
+	c.tracef("read(%q): %d bytes, err: %v", path, len(data), err)
+
+	c.bytesRead += int64(len(data))
+
+	return data, err
+}
@@ -0,0 +1,47 @@
+package aferoassert_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+type loggingT struct {
+	testingT
+
+	logs []string
+}
+
+func (t *loggingT) Log(args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+func TestWithTrace(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &loggingT{}
+
+	assert.True(t, aferoassert.FileContent(mockT, fs, "root/file 1", "line 1\nline 2\n", aferoassert.WithTrace()), mockT.message())
+	assert.Empty(t, mockT.logs)
+
+	assert.False(t, aferoassert.FileContent(mockT, fs, "root/file 1", "unexpected", aferoassert.WithTrace()))
+	require.Len(t, mockT.logs, 1)
+	assert.Equal(t, "FileContent trace:\n\topen(\"root/file 1\"): err: <nil>\n\tfstat(\"root/file 1\"): -rw-r--r--", mockT.logs[0])
+
+	mockT = &loggingT{}
+
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, "- unknown", "root", aferoassert.WithTrace()))
+	require.Len(t, mockT.logs, 1)
+	assert.Contains(t, mockT.logs[0], "YAMLTreeEqual trace:\n\tstat(\"root\"): drwxr-xr-x\n\treaddir(\"root\"): 2 entries, err: <nil>")
+
+	// Without trace.
+	mockT = &loggingT{}
+
+	assert.False(t, aferoassert.FileContent(mockT, fs, "root/file 1", "unexpected"))
+	assert.Empty(t, mockT.logs)
+}
@@ -27,6 +27,19 @@ var (
 	tagPattern        = regexp.MustCompile("\\s*'[^`]+'$")
 	fileModeSeparator = "|"
 
+	attrTags = map[string]bool{
+		"content":     true,
+		"contentFile": true,
+		"if":          true,
+		"xattr":       true,
+		"valid":       true,
+		"link":        true,
+		"target":      true,
+		"size":        true,
+		"contains":    true,
+		"sha256":      true,
+	}
+
 	fileModeNames = map[os.FileMode]string{
 		os.ModeDir:        "Dir",
 		os.ModeAppend:     "Append",
@@ -47,17 +60,65 @@ var (
 // FileTree is a map of node.
 type FileTree map[string]FileNode
 
-// Flatten converts the file tree to a flat map, key is the path to file.
+// Flatten converts the file tree to a flat map, key is the path to file. The conditions are evaluated for the running
+// platform: a node whose `if` tag is not met is left out with its children, and a mode tag is left out of the node if
+// one of its skip modifiers matches, for example `perm:"0644,skip-windows,skip-root"`.
 func (t FileTree) Flatten(root string) map[string]FileNode {
-	result := make(map[string]FileNode, len(t))
+	result := make(map[string]FileNode, t.count())
 
-	for _, n := range t {
-		for k, nc := range n.Flatten(root) {
-			result[k] = nc
+	flattenInto(result, root, t)
+
+	return result
+}
+
+// count returns the number of nodes of the tree, at all levels.
+func (t FileTree) count() int {
+	cnt := 0
+	stack := []FileTree{t}
+
+	for len(stack) > 0 {
+		tree := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		cnt += len(tree)
+
+		for _, n := range tree {
+			if len(n.Children) > 0 {
+				stack = append(stack, n.Children)
+			}
 		}
 	}
 
-	return result
+	return cnt
+}
+
+// flattenInto adds the nodes of the tree to the flat map. It uses a stack instead of recursion, so a deep tree does
+// not grow the call stack.
+func flattenInto(result map[string]FileNode, root string, t FileTree) {
+	type dir struct {
+		path string
+		tree FileTree
+	}
+
+	stack := []dir{{path: root, tree: t}}
+
+	for len(stack) > 0 {
+		d := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, n := range d.tree {
+			if !n.Attrs.conditionMet() {
+				continue
+			}
+
+			p := filepath.Join(d.path, n.Name)
+			result[p] = n.withoutSkippedTags()
+
+			if len(n.Children) > 0 {
+				stack = append(stack, dir{path: p, tree: n.Children})
+			}
+		}
+	}
 }
 
 // MarshalYAML satisfies yaml.Marshaler.
@@ -81,8 +142,17 @@ func (t FileTree) MarshalYAML() (interface{}, error) { // nolint: unparam
 	return raw, nil
 }
 
-// UnmarshalYAML satisfies yaml.Unmarshaler.
+// UnmarshalYAML satisfies yaml.Unmarshaler. The ranges of the names, such as `file-{001..500}.dat`, are expanded to one
+// node per number, a range escaped with a backslash, such as `file-\{1..3}`, is kept as is. The `if` tags and the skip
+// modifiers are kept in the nodes, they are evaluated when the tree is flattened, see Flatten.
 func (t *FileTree) UnmarshalYAML(value *yaml.Node) error {
+	// An empty directory is marshaled as `{}`.
+	if value.Kind == yaml.MappingNode && len(value.Content) == 0 {
+		*t = nil
+
+		return nil
+	}
+
 	var raw []FileNode
 
 	if err := value.Decode(&raw); err != nil {
@@ -92,7 +162,24 @@ func (t *FileTree) UnmarshalYAML(value *yaml.Node) error {
 	*t = make(map[string]FileNode, len(raw))
 
 	for _, n := range raw {
-		(*t)[n.Name] = n
+		names, err := expandName(n.Name)
+		if err != nil {
+			return err
+		}
+
+		if len(names) == 1 {
+			n.Name = names[0]
+			(*t)[n.Name] = n
+
+			continue
+		}
+
+		for _, name := range names {
+			c := n.clone()
+			c.Name = name
+
+			(*t)[name] = c
+		}
 	}
 
 	return nil
@@ -100,34 +187,105 @@ func (t *FileTree) UnmarshalYAML(value *yaml.Node) error {
 
 // FileNode contains needed information for assertions.
 type FileNode struct {
-	Name     string
-	Tags     FileModeTags
-	Children FileTree
-	IsDir    bool
+	Name      string
+	Tags      FileModeTags
+	Modifiers FileModeModifiers
+	Attrs     FileAttrs
+	Children  FileTree
+	IsDir     bool
 }
 
-// Flatten converts the file tree to a flat map, key is the path to file.
+// Flatten converts the file tree to a flat map, key is the path to file. The conditions are evaluated like
+// FileTree.Flatten does, the result is empty if the condition of the node is not met.
 func (n FileNode) Flatten(root string) map[string]FileNode {
+	if !n.Attrs.conditionMet() {
+		return map[string]FileNode{}
+	}
+
 	root = filepath.Join(root, n.Name)
 
-	result := make(map[string]FileNode)
-	result[root] = n
+	result := make(map[string]FileNode, 1+n.Children.count())
+	result[root] = n.withoutSkippedTags()
 
-	for k, v := range n.Children.Flatten(root) {
-		result[k] = v
-	}
+	flattenInto(result, root, n.Children)
 
 	return result
 }
 
+// clone copies the node deeply, so the expanded nodes of a range do not share their children.
+func (n FileNode) clone() FileNode {
+	c := n
+
+	if n.Tags != nil {
+		c.Tags = make(FileModeTags, len(n.Tags))
+
+		for k, v := range n.Tags {
+			if v != nil {
+				v = FileModePtr(*v)
+			}
+
+			c.Tags[k] = v
+		}
+	}
+
+	if n.Modifiers != nil {
+		c.Modifiers = make(FileModeModifiers, len(n.Modifiers))
+
+		for k, v := range n.Modifiers {
+			c.Modifiers[k] = append([]string(nil), v...)
+		}
+	}
+
+	if n.Attrs != nil {
+		c.Attrs = make(FileAttrs, len(n.Attrs))
+
+		for k, v := range n.Attrs {
+			c.Attrs[k] = v
+		}
+	}
+
+	if n.Children != nil {
+		c.Children = make(FileTree, len(n.Children))
+
+		for k, v := range n.Children {
+			c.Children[k] = v.clone()
+		}
+	}
+
+	return c
+}
+
+// withoutSkippedTags returns the node without the mode tags whose skip modifier matches the platform.
+func (n FileNode) withoutSkippedTags() FileNode {
+	if len(n.Modifiers) == 0 {
+		return n
+	}
+
+	tags := make(FileModeTags, len(n.Tags))
+
+	for k, v := range n.Tags {
+		if !n.Modifiers.skipped(k) {
+			tags[k] = v
+		}
+	}
+
+	if len(tags) == 0 {
+		tags = nil
+	}
+
+	n.Tags = tags
+
+	return n
+}
+
 // MarshalYAML satisfies yaml.Marshaler.
 func (n FileNode) MarshalYAML() (interface{}, error) { // nolint: unparam
 	var nameBld strings.Builder
 
-	_, _ = nameBld.WriteString(n.Name)
+	_, _ = nameBld.WriteString(escapeName(n.Name))
 
-	if len(n.Tags) > 0 {
-		_, _ = fmt.Fprintf(&nameBld, " '%s'", n.Tags.String())
+	if tags := n.tagsString(); tags != "" {
+		_, _ = fmt.Fprintf(&nameBld, " '%s'", tags)
 	}
 
 	if !n.IsDir {
@@ -139,6 +297,20 @@ func (n FileNode) MarshalYAML() (interface{}, error) { // nolint: unparam
 	return raw, nil
 }
 
+func (n FileNode) tagsString() string {
+	tags := make([]string, 0, 2)
+
+	if len(n.Tags) > 0 {
+		tags = append(tags, n.Tags.format(n.Modifiers))
+	}
+
+	if len(n.Attrs) > 0 {
+		tags = append(tags, n.Attrs.String())
+	}
+
+	return strings.Join(tags, " ")
+}
+
 // UnmarshalYAML satisfies yaml.Unmarshaler.
 func (n *FileNode) UnmarshalYAML(value *yaml.Node) error {
 	// nolint: exhaustive
@@ -169,46 +341,207 @@ func (n *FileNode) UnmarshalYAML(value *yaml.Node) error {
 // FileModeTags is a list of tagged file mode.
 type FileModeTags map[string]*os.FileMode
 
-// Mode returns file mode.
+// Mode returns file mode of the `mode` tag, all its named bits, such as Setgid or Append, have to be the same as the
+// ones of the file.
 func (t FileModeTags) Mode() *os.FileMode {
 	return t["mode"]
 }
 
-// Type returns type file mode.
+// Type returns type file mode of the `type` tag, only the type bits (os.ModeType), such as Dir or Symlink, are compared
+// with the ones of the file, so `type:"Dir"` matches a directory whatever its other bits are.
 func (t FileModeTags) Type() *os.FileMode {
 	return t["type"]
 }
 
-// Perm returns perm file mode.
+// Perm returns perm file mode of the `perm` tag, which is always octal and may have 4 digits for the special bits, for
+// example `perm:"2755"`. The special bits are converted to os.ModeSetuid, os.ModeSetgid and os.ModeSticky.
 func (t FileModeTags) Perm() *os.FileMode {
 	return t["perm"]
 }
 
 // String returns tags in struct tag format.
 func (t FileModeTags) String() string {
+	return t.format(nil)
+}
+
+// format returns the tags in struct tag format, with their modifiers.
+func (t FileModeTags) format(modifiers FileModeModifiers) string {
 	tags := &structtag.Tags{}
 
 	if m := t.Mode(); m != nil {
 		// nolint: errcheck
 		_ = tags.Set(&structtag.Tag{
-			Key:  "mode",
-			Name: fileModeToString(*m),
+			Key:     "mode",
+			Name:    FormatFileMode(*m),
+			Options: modifiers["mode"],
 		})
 	}
 
 	if m := t.Type(); m != nil {
 		// nolint: errcheck
 		_ = tags.Set(&structtag.Tag{
-			Key:  "type",
-			Name: fileModeToString(*m),
+			Key:     "type",
+			Name:    FormatFileMode(*m),
+			Options: modifiers["type"],
 		})
 	}
 
 	if m := t.Perm(); m != nil {
 		// nolint: errcheck
 		_ = tags.Set(&structtag.Tag{
-			Key:  "perm",
-			Name: fmt.Sprintf("0%o", *m&os.ModePerm),
+			Key:     "perm",
+			Name:    formatPerm(normalizePerm(*m)),
+			Options: modifiers["perm"],
+		})
+	}
+
+	return tags.String()
+}
+
+// FileModeModifiers are the modifiers of the mode tags, keyed by the tag, for example {"perm": {"skip-windows"}} for
+// `perm:"0644,skip-windows"`. A mode tag is not checked if one of its skip modifiers matches the platform.
+type FileModeModifiers map[string][]string
+
+// skipped tells whether one of the skip modifiers of the tag matches the platform. The invalid modifiers never match,
+// so the tag is checked.
+func (m FileModeModifiers) skipped(key string) bool {
+	skip, err := skipped(m[key])
+
+	return err == nil && skip
+}
+
+// FileAttrs is a list of tagged attributes that are not file modes, such as the expected content.
+type FileAttrs map[string]string
+
+// Content returns the expected content.
+func (a FileAttrs) Content() (string, bool) {
+	v, ok := a["content"]
+
+	return v, ok
+}
+
+// ContentFile returns the path of the file that has the expected content, of the `contentFile` tag, for example
+// `contentFile:"testdata/expected/config.yaml"`. The path is resolved with WithExpectationFs.
+func (a FileAttrs) ContentFile() (string, bool) {
+	v, ok := a["contentFile"]
+
+	return v, ok
+}
+
+// If returns the condition of the `if` tag, for example `if:"!windows,!root"`. The nodes whose condition is not met are
+// left out when the tree is flattened, see FileTree.Flatten.
+func (a FileAttrs) If() (string, bool) {
+	v, ok := a["if"]
+
+	return v, ok
+}
+
+// conditionMet tells whether the condition of the `if` tag is met, or there is no condition. An invalid condition is
+// met, so a typo does not hide the node from the assertions.
+func (a FileAttrs) conditionMet() bool {
+	cond, ok := a.If()
+	if !ok {
+		return true
+	}
+
+	met, err := evalCondition(cond)
+
+	return err != nil || met
+}
+
+// Valid tells whether the file has to be valid according to the validator registered for its extension, see
+// RegisterValidator.
+func (a FileAttrs) Valid() bool {
+	return a["valid"] == "true"
+}
+
+// Link returns the expected flavor of the symlink target of the `link` tag, either "relative" or "absolute".
+func (a FileAttrs) Link() (string, bool) {
+	v, ok := a["link"]
+
+	return v, ok
+}
+
+// Target returns the target of the symlink of the `target` tag, for example `target:"releases/v2"`.
+func (a FileAttrs) Target() (string, bool) {
+	v, ok := a["target"]
+
+	return v, ok
+}
+
+// Size returns the size of the `size` tag, for example `size:"4KB"`, the units are powers of 1024. An invalid size is
+// rejected when the tree is unmarshaled, otherwise it is reported as not set.
+func (a FileAttrs) Size() (int64, bool) {
+	v, ok := a["size"]
+	if !ok {
+		return 0, false
+	}
+
+	size, err := parseFileSize(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return size, true
+}
+
+// Contains returns the expected substring of the content of the `contains` tag.
+func (a FileAttrs) Contains() (string, bool) {
+	v, ok := a["contains"]
+
+	return v, ok
+}
+
+// SHA256 returns the expected hex-encoded SHA-256 of the content of the `sha256` tag.
+func (a FileAttrs) SHA256() (string, bool) {
+	v, ok := a["sha256"]
+
+	return v, ok
+}
+
+// Xattrs returns the expected extended attributes of the `xattr` tag, for example `xattr:"user.a=1,user.b=2"`.
+func (a FileAttrs) Xattrs() map[string]string {
+	v, ok := a["xattr"]
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]string)
+
+	for _, kv := range strings.Split(v, ",") {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+
+		parts := strings.SplitN(kv, "=", 2)
+
+		if len(parts) == 1 {
+			result[parts[0]] = ""
+		} else {
+			result[parts[0]] = parts[1]
+		}
+	}
+
+	return result
+}
+
+// String returns attributes in struct tag format.
+func (a FileAttrs) String() string {
+	keys := make([]string, 0, len(a))
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	tags := &structtag.Tags{}
+
+	for _, k := range keys {
+		// nolint: errcheck
+		_ = tags.Set(&structtag.Tag{
+			Key:  k,
+			Name: a[k],
 		})
 	}
 
@@ -243,38 +576,87 @@ func unmarshalFileWithTags(value *yaml.Node) (*FileNode, error) {
 		return nil, ErrFileNameEmpty
 	}
 
-	tags, err := unmarshalTags(value, prepareTagsString(rawTags))
+	tags, modifiers, attrs, err := unmarshalTags(value, prepareTagsString(rawTags))
 	if err != nil {
 		return nil, err
 	}
 
 	n := &FileNode{
-		Name:     fileName,
-		Tags:     *tags,
-		Children: nil,
+		Name:      fileName,
+		Tags:      tags,
+		Modifiers: modifiers,
+		Attrs:     attrs,
+		Children:  nil,
 	}
 
 	return n, nil
 }
 
-func unmarshalTags(node *yaml.Node, s string) (*FileModeTags, error) {
+func unmarshalTags(node *yaml.Node, s string) (FileModeTags, FileModeModifiers, FileAttrs, error) {
 	tags, err := structtag.Parse(s)
 	if err != nil {
-		return nil, fmt.Errorf("%w at line %d", err, node.Line)
+		return nil, nil, nil, fmt.Errorf("%w at line %d", err, node.Line)
 	}
 
-	t := make(FileModeTags, tags.Len())
+	var (
+		t FileModeTags
+		m FileModeModifiers
+		a FileAttrs
+	)
 
 	for _, tag := range tags.Tags() {
-		value, err := parseTag(tag.Name)
+		if attrTags[tag.Key] {
+			if tag.Key == "size" {
+				if _, err := parseFileSize(tag.Value()); err != nil {
+					return nil, nil, nil, fmt.Errorf("%w in %q tag at line %d", err, tag.Key, node.Line)
+				}
+			}
+
+			if tag.Key == "if" {
+				if _, err := evalCondition(tag.Value()); err != nil {
+					return nil, nil, nil, fmt.Errorf("%w %q in %q tag at line %d", err, tag.Value(), tag.Key, node.Line)
+				}
+			}
+
+			if a == nil {
+				a = make(FileAttrs)
+			}
+
+			a[tag.Key] = tag.Value()
+
+			continue
+		}
+
+		if _, err := skipped(tag.Options); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w %q in %q tag at line %d", err, strings.Join(tag.Options, ","), tag.Key, node.Line)
+		}
+
+		parse := parseTag
+		if tag.Key == "perm" {
+			parse = parsePermTag
+		}
+
+		value, err := parse(tag.Name)
 		if err != nil {
-			return nil, fmt.Errorf("%w in %q tag at line %d", ErrInvalidFileMode, tag.Key, node.Line)
+			return nil, nil, nil, fmt.Errorf("%w in %q tag at line %d", ErrInvalidFileMode, tag.Key, node.Line)
+		}
+
+		if t == nil {
+			t = make(FileModeTags, tags.Len())
 		}
 
 		t[tag.Key] = value
+
+		if len(tag.Options) > 0 {
+			if m == nil {
+				m = make(FileModeModifiers)
+			}
+
+			m[tag.Key] = tag.Options
+		}
 	}
 
-	return &t, nil
+	return t, m, a, nil
 }
 
 func unmarshalFolder(value *yaml.Node) (*FileNode, error) {
@@ -328,6 +710,37 @@ func parseTag(tag string) (*os.FileMode, error) {
 	return &result, nil
 }
 
+// ParseFileMode parses a file mode like the mode tags do, either the names of the bits separated by "|", for example
+// "Dir|Sticky", or a number, which is octal if it starts with 0.
+func ParseFileMode(s string) (os.FileMode, error) {
+	m, err := parseTag(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", err, s)
+	}
+
+	return *m, nil
+}
+
+// parsePermTag parses the perm tag, the digits are always octal, so the special bits can be set with 4 digits, for
+// example "2755" or "04755".
+func parsePermTag(tag string) (*os.FileMode, error) {
+	if strings.Trim(tag, "0123456789") != "" {
+		m, err := parseTag(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return FileModePtr(normalizePerm(*m)), nil
+	}
+
+	perm, err := strconv.ParseUint(tag, 8, 32)
+	if err != nil || perm > 0o7777 {
+		return nil, ErrInvalidFileMode
+	}
+
+	return FileModePtr(normalizePerm(os.FileMode(perm))), nil
+}
+
 func fileModeFromString(s string) (*os.FileMode, error) {
 	for mode, name := range fileModeNames {
 		if name == s {
@@ -338,7 +751,9 @@ func fileModeFromString(s string) (*os.FileMode, error) {
 	return nil, ErrInvalidFileMode
 }
 
-func fileModeToString(mode os.FileMode) string {
+// FormatFileMode formats the bits of the file mode with the names of the mode tags, separated by "|" and sorted, for
+// example "Dir|Sticky". The permission bits are not formatted.
+func FormatFileMode(mode os.FileMode) string {
 	result := make([]string, 0)
 
 	for m, name := range fileModeNames {
@@ -352,6 +767,15 @@ func fileModeToString(mode os.FileMode) string {
 	return strings.Join(result, fileModeSeparator)
 }
 
+// formatFileType formats the type bits of the file mode, a regular file has none of them.
+func formatFileType(mode os.FileMode) string {
+	if mode&os.ModeType == 0 {
+		return "regular"
+	}
+
+	return FormatFileMode(mode & os.ModeType)
+}
+
 // FileModePtr returns pointer to file mode.
 func FileModePtr(mode os.FileMode) *os.FileMode {
 	return &mode
@@ -0,0 +1,412 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// tRunner is a TestingT that can run subtests, such as *testing.T.
+type tRunner interface {
+	Run(name string, f func(t *testing.T)) bool
+}
+
+// runner is a TestingT that can run subtests with any TestingT, see WithSubtests.
+type runner interface {
+	Run(name string, f func(t TestingT)) bool
+}
+
+// canRunSubtests tells whether a TestingT can run subtests.
+func canRunSubtests(t TestingT) bool {
+	switch t.(type) {
+	case tRunner, runner:
+		return true
+	}
+
+	return false
+}
+
+// errStopWalk stops the walk when there is nothing left to check.
+var errStopWalk = errors.New("stop walking")
+
+type treeAssertion struct {
+	t          TestingT
+	fs         afero.Fs
+	c          *config
+	root       string
+	exhaustive bool
+	msgAndArgs []interface{}
+
+	// expectations contains the expected nodes that have not been visited yet.
+	expectations map[string]FileNode
+	// pending counts the expectations that have not been visited yet under a directory.
+	pending map[string]int
+	result  bool
+	// dumped tells whether the actual tree was appended to a failure, see WithActualTree.
+	dumped bool
+}
+
+func assertTree(t TestingT, c *config, fs afero.Fs, tree FileTree, root string, exhaustive bool, msgAndArgs ...interface{}) bool {
+	if c.detectChanges {
+		return assertUnchangedDuring(t, c, fs, root, func() bool {
+			return assertTreeOnce(t, c, fs, tree, root, exhaustive, msgAndArgs...)
+		}, msgAndArgs...)
+	}
+
+	return assertTreeOnce(t, c, fs, tree, root, exhaustive, msgAndArgs...)
+}
+
+func assertTreeOnce(t TestingT, c *config, fs afero.Fs, tree FileTree, root string, exhaustive bool, msgAndArgs ...interface{}) bool {
+	a := &treeAssertion{
+		t:            t,
+		fs:           fs,
+		c:            c,
+		root:         filepath.Clean(root),
+		exhaustive:   exhaustive,
+		msgAndArgs:   msgAndArgs,
+		expectations: make(map[string]FileNode),
+		pending:      make(map[string]int),
+		result:       true,
+	}
+
+	for p, n := range tree.Flatten("") {
+		if c.ignored(p) {
+			continue
+		}
+
+		p = c.normalizePath(p)
+		a.expectations[p] = n
+
+		for dir := filepath.Dir(p); dir != "."; dir = filepath.Dir(dir) {
+			a.pending[dir]++
+		}
+	}
+
+	err := walk(c, fs, a.root, a.visit)
+	if errors.Is(err, errStopWalk) {
+		err = nil
+	}
+
+	if err != nil {
+		return a.fail(FailureInfo{Path: a.root, Message: fmt.Sprintf("could not walk through %q: %s", a.root, err)})
+	}
+
+	if c.failFast && !a.result {
+		return false
+	}
+
+	return a.reportMissing()
+}
+
+func (a *treeAssertion) fail(info FailureInfo) bool {
+	if a.c.failFast && !a.result {
+		return false
+	}
+
+	a.result = false
+
+	if a.c.dumpActualTree && !a.dumped {
+		a.dumped = true
+		info.Message += a.actualTree()
+	}
+
+	return a.c.fail(a.t, info, a.msgAndArgs...)
+}
+
+func (a *treeAssertion) visit(path string, info os.FileInfo, err error) error {
+	if a.c.failFast && !a.result {
+		return errStopWalk
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if path == a.root {
+		return nil
+	}
+
+	expectedPath := a.c.normalizePath(strings.TrimPrefix(path, a.root+string(os.PathSeparator)))
+
+	if a.c.ignored(expectedPath) {
+		return skip(info)
+	}
+
+	if expected, ok := a.expectations[expectedPath]; ok {
+		a.subtest(expectedPath, func() {
+			a.assertNode(path, expected, info)
+		})
+		a.visited(expectedPath)
+	} else if a.exhaustive {
+		a.fail(FailureInfo{Kind: FailureUnexpected, Path: path, Message: fmt.Sprintf("unexpected file %q", path)})
+	}
+
+	if a.exhaustive {
+		return nil
+	}
+
+	// In the non-exhaustive mode, there is no need to walk the directories that do not contain any expectation.
+	if len(a.expectations) == 0 {
+		return errStopWalk
+	}
+
+	if a.pending[expectedPath] == 0 {
+		return skip(info)
+	}
+
+	return nil
+}
+
+// subtest runs the checks of an expected path in a subtest when WithSubtests is set and the TestingT supports it.
+func (a *treeAssertion) subtest(name string, fn func()) {
+	if !a.c.subtests || !canRunSubtests(a.t) {
+		fn()
+
+		return
+	}
+
+	parent := a.t
+
+	defer func() {
+		a.t = parent
+	}()
+
+	switch r := parent.(type) {
+	case tRunner:
+		r.Run(name, func(t *testing.T) {
+			a.t = t
+
+			fn()
+		})
+
+	case runner:
+		r.Run(name, func(t TestingT) {
+			a.t = t
+
+			fn()
+		})
+	}
+}
+
+func (a *treeAssertion) visited(expectedPath string) {
+	delete(a.expectations, expectedPath)
+
+	for dir := filepath.Dir(expectedPath); dir != "."; dir = filepath.Dir(dir) {
+		a.pending[dir]--
+	}
+}
+
+func (a *treeAssertion) assertNode(path string, expected FileNode, info os.FileInfo) {
+	if expected.IsDir {
+		if !info.IsDir() {
+			a.fail(FailureInfo{Kind: FailureType, Path: path, Message: fmt.Sprintf("%q is not a directory", path)})
+
+			return
+		}
+	} else if info.IsDir() {
+		a.fail(FailureInfo{Kind: FailureType, Path: path, Message: fmt.Sprintf("%q is a directory", path)})
+
+		return
+	}
+
+	if m := expected.Tags.Mode(); m != nil {
+		expected := FormatFileMode(*m)
+		actual := FormatFileMode(info.Mode())
+
+		if expected != actual {
+			a.fail(FailureInfo{
+				Kind:     FailureMode,
+				Path:     path,
+				Expected: expected,
+				Actual:   actual,
+				Message:  fmt.Sprintf("%q mode is %s, expected %s", path, actual, expected),
+			})
+		}
+	}
+
+	if m := expected.Tags.Type(); m != nil {
+		expected := formatFileType(*m)
+		actual := formatFileType(info.Mode())
+
+		if expected != actual {
+			a.fail(FailureInfo{
+				Kind:     FailureType,
+				Path:     path,
+				Expected: expected,
+				Actual:   actual,
+				Message:  fmt.Sprintf("%q type is %s, expected %s", path, actual, expected),
+			})
+		}
+	}
+
+	if m := expected.Tags.Perm(); m != nil && !a.c.ignorePerm {
+		expected := normalizePerm(*m)
+		actual := info.Mode() & permBits
+
+		if !permMatches(a.c, a.fs, expected, actual) {
+			a.fail(FailureInfo{
+				Kind:     FailurePerm,
+				Path:     path,
+				Expected: expected,
+				Actual:   actual,
+				Message:  fmt.Sprintf("%q perm is %s, expected %s", path, formatPerm(actual), formatPerm(expected)),
+			})
+		}
+	}
+
+	if size, ok := expected.Attrs.Size(); ok && !info.IsDir() {
+		if msg, ok := checkSize(a.c, a.fs, path, info, size); !ok {
+			a.fail(FailureInfo{Kind: FailureSize, Path: path, Expected: size, Actual: info.Size(), Message: msg})
+		}
+	}
+
+	if content, ok := expected.Attrs.Content(); ok && !info.IsDir() {
+		if msg, ok := assertContent(a.fs, path, content, a.c); !ok {
+			a.fail(FailureInfo{Kind: FailureContent, Path: path, Expected: content, Message: msg})
+		}
+	}
+
+	if contentFile, ok := expected.Attrs.ContentFile(); ok && !info.IsDir() {
+		if msg, ok := checkContentFile(a.c, a.fs, path, contentFile); !ok {
+			a.fail(FailureInfo{Kind: FailureContent, Path: path, Expected: contentFile, Message: msg})
+		}
+	}
+
+	if substr, ok := expected.Attrs.Contains(); ok && !info.IsDir() {
+		if msg, ok := checkContains(a.c, a.fs, path, substr); !ok {
+			a.fail(FailureInfo{Kind: FailureContent, Path: path, Expected: substr, Message: msg})
+		}
+	}
+
+	if digest, ok := expected.Attrs.SHA256(); ok && !info.IsDir() {
+		if msg, ok := checkSHA256(a.c, a.fs, path, digest); !ok {
+			a.fail(FailureInfo{Kind: FailureContent, Path: path, Expected: digest, Message: msg})
+		}
+	}
+
+	if flavor, ok := expected.Attrs.Link(); ok {
+		if msg, ok := checkSymlinkFlavor(a.fs, path, flavor); !ok {
+			a.fail(FailureInfo{Kind: FailureLink, Path: path, Expected: flavor, Message: msg})
+		}
+	}
+
+	if target, ok := expected.Attrs.Target(); ok {
+		if msg, ok := checkSymlinkTarget(a.fs, path, target); !ok {
+			a.fail(FailureInfo{Kind: FailureTarget, Path: path, Expected: target, Message: msg})
+		}
+	}
+
+	if expected.Attrs.Valid() && !info.IsDir() {
+		if msg, ok := checkValid(a.c, a.fs, path); !ok {
+			a.fail(FailureInfo{Kind: FailureValid, Path: path, Message: msg})
+		}
+	}
+
+	for name, value := range expected.Attrs.Xattrs() {
+		if msg, ok := checkXattr(a.fs, path, name, value); !ok {
+			a.fail(FailureInfo{Kind: FailureXattr, Path: path, Expected: value, Message: msg})
+		}
+	}
+}
+
+func (a *treeAssertion) reportMissing() bool {
+	if len(a.expectations) == 0 {
+		return a.result
+	}
+
+	missing := make([]string, 0, len(a.expectations))
+
+	for k := range a.expectations {
+		missing = append(missing, k)
+	}
+
+	sort.Strings(missing)
+
+	if a.c.subtests && canRunSubtests(a.t) {
+		for _, p := range missing {
+			path := filepath.Join(a.root, p)
+
+			a.subtest(p, func() {
+				a.fail(FailureInfo{Kind: FailureNotFound, Path: path, Message: fmt.Sprintf("expected %q but not found%s", path, didYouMean(a.fs, path))})
+			})
+		}
+
+		return a.result
+	}
+
+	return a.fail(FailureInfo{
+		Kind:     FailureNotFound,
+		Path:     a.root,
+		Expected: missing,
+		Message: fmt.Sprintf("expected these files in %q%s but not found:\n%s", a.root, realPathHint(a.fs, a.root), formatMissingFiles(missing, func(p string) string {
+			return didYouMean(a.fs, filepath.Join(a.root, p))
+		})),
+	})
+}
+
+// actualTree renders the YAML snapshot of the actual tree that is appended to the first failure, see WithActualTree.
+func (a *treeAssertion) actualTree() string {
+	out, err := marshalTree(a.c, a.fs, a.root)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\nactual tree of %q:\n\n%s", a.root, out)
+}
+
+// skip skips the directory, or does nothing if it is a file.
+func skip(info os.FileInfo) error {
+	if info.IsDir() {
+		return filepath.SkipDir
+	}
+
+	return nil
+}
+
+// formatMissingFiles renders the missing paths sorted and grouped by their directory. The hint is appended to each path.
+func formatMissingFiles(paths []string, hint func(path string) string) string {
+	groups := make(map[string][]string)
+	dirs := make([]string, 0)
+
+	for _, p := range paths {
+		dir := filepath.Dir(p)
+
+		if _, ok := groups[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+
+		groups[dir] = append(groups[dir], filepath.Base(p))
+	}
+
+	sort.Strings(dirs)
+
+	var sb strings.Builder
+
+	for _, dir := range dirs {
+		names := groups[dir]
+
+		sort.Strings(names)
+
+		if dir == "." {
+			for _, name := range names {
+				_, _ = fmt.Fprintf(&sb, "- %s%s\n", name, hint(name))
+			}
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&sb, "- %s%c\n", dir, os.PathSeparator)
+
+		for _, name := range names {
+			_, _ = fmt.Fprintf(&sb, "    - %s%s\n", name, hint(filepath.Join(dir, name)))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,148 @@
+package aferoassert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// treeStats contains the stats of all the paths under a directory, the keys are relative to the directory.
+type treeStats map[string]fileStat
+
+// fileStat is a copy of the stat of a path, some file systems, such as afero.MemMapFs, return an os.FileInfo that
+// reflects the later changes.
+type fileStat struct {
+	mode    os.FileMode
+	size    int64
+	modTime time.Time
+}
+
+// assertUnchangedDuring runs the assertion and checks whether the tree was not modified while it was running, see
+// DetectConcurrentChanges.
+func assertUnchangedDuring(t TestingT, c *config, fs afero.Fs, root string, assert func() bool, msgAndArgs ...interface{}) bool {
+	root = filepath.Clean(root)
+
+	before, err := statTree(c, fs, root)
+	if err != nil {
+		// The assertion reports the error when it walks through the tree.
+		return assert()
+	}
+
+	result := assert()
+
+	after, err := statTree(c, fs, root)
+	if err != nil {
+		return c.fail(t, FailureInfo{
+			Path:    root,
+			Message: fmt.Sprintf("%q changed while being asserted, could not walk through it again: %s", root, err),
+		}, msgAndArgs...)
+	}
+
+	if changes := diffTreeStats(before, after); len(changes) > 0 {
+		return c.fail(t, FailureInfo{
+			Path:    root,
+			Actual:  changes,
+			Message: fmt.Sprintf("%q changed while being asserted, the result is not reliable:\n\n%s\n", root, strings.Join(changes, "\n")),
+		}, msgAndArgs...)
+	}
+
+	return result
+}
+
+// statTree stats all the paths under the root, bypassing the index of a Session because it would not see the changes.
+func statTree(c *config, fs afero.Fs, root string) (treeStats, error) {
+	sc := &config{followLinks: c.followLinks, ignorePatterns: c.ignorePatterns}
+	result := make(treeStats)
+
+	err := walk(sc, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(root, path)
+		if rel == "." {
+			return nil
+		}
+
+		if sc.ignored(rel) {
+			return skip(info)
+		}
+
+		result[rel] = fileStat{mode: info.Mode(), size: info.Size(), modTime: info.ModTime()}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// diffTreeStats renders the changes, one path per line, prefixed with "+" when added, "-" when removed and "~" when
+// modified, like FsDiff. The modification times of the directories are not compared because they change with their
+// entries, which are already reported.
+func diffTreeStats(before, after treeStats) []string {
+	paths := make([]string, 0, len(before))
+
+	for p := range before {
+		paths = append(paths, p)
+	}
+
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+
+	sort.Strings(paths)
+
+	changes := make([]string, 0)
+
+	for _, p := range paths {
+		b, inBefore := before[p]
+		a, inAfter := after[p]
+
+		switch {
+		case !inBefore:
+			changes = append(changes, fmt.Sprintf("+ %s", p))
+
+		case !inAfter:
+			changes = append(changes, fmt.Sprintf("- %s", p))
+
+		default:
+			if diff := diffFileStat(b, a); diff != "" {
+				changes = append(changes, fmt.Sprintf("~ %s (%s)", p, diff))
+			}
+		}
+	}
+
+	return changes
+}
+
+func diffFileStat(before, after fileStat) string {
+	changes := make([]string, 0, 3)
+
+	if before.mode != after.mode {
+		changes = append(changes, fmt.Sprintf("mode %s -> %s", before.mode, after.mode))
+	}
+
+	if before.mode.IsDir() || after.mode.IsDir() {
+		return strings.Join(changes, ", ")
+	}
+
+	if before.size != after.size {
+		changes = append(changes, fmt.Sprintf("size %d -> %d", before.size, after.size))
+	}
+
+	if !before.modTime.Equal(after.modTime) {
+		changes = append(changes, fmt.Sprintf("mtime %s -> %s", before.modTime, after.modTime))
+	}
+
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,104 @@
+package aferoassert_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// writingFs simulates a goroutine that is still writing: it modifies the tree when a file is read.
+type writingFs struct {
+	afero.Fs
+
+	trigger string
+}
+
+func (fs *writingFs) Open(name string) (afero.File, error) {
+	if name == fs.trigger {
+		_ = afero.WriteFile(fs.Fs, "root/logs/app.log", []byte("hello world"), 0o644) // nolint: errcheck
+		_ = afero.WriteFile(fs.Fs, "root/logs/app.log.1", nil, 0o644)                 // nolint: errcheck
+		_ = fs.Fs.Remove("root/tmp")                                                  // nolint: errcheck
+	}
+
+	return fs.Fs.Open(name)
+}
+
+func newWritingFs(t *testing.T, trigger string) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/tmp", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/logs/app.log", []byte("hello"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/config.yaml", []byte("debug=true"), 0o644))
+
+	return &writingFs{Fs: fs, trigger: filepath.FromSlash(trigger)}
+}
+
+func TestDetectConcurrentChanges(t *testing.T) {
+	t.Parallel()
+
+	const expected = `
+- config.yaml 'content:"debug=true"'
+- logs:
+    - app.log
+`
+
+	testCases := []struct {
+		scenario       string
+		trigger        string
+		options        []interface{}
+		expectedResult bool
+		expectedErrors []string
+	}{
+		{
+			scenario:       "unchanged",
+			options:        []interface{}{aferoassert.DetectConcurrentChanges()},
+			expectedResult: true,
+		},
+		{
+			scenario:       "changed without detection",
+			trigger:        "root/config.yaml",
+			expectedResult: true,
+		},
+		{
+			scenario: "changed",
+			trigger:  "root/config.yaml",
+			options:  []interface{}{aferoassert.DetectConcurrentChanges()},
+			expectedErrors: []string{
+				`"root" changed while being asserted, the result is not reliable:`,
+				"+ logs/app.log.1\n",
+				"- tmp\n",
+				"~ logs/app.log (size 5 -> 11, mtime ",
+			},
+		},
+		{
+			scenario:       "ignored",
+			trigger:        "root/config.yaml",
+			options:        []interface{}{aferoassert.DetectConcurrentChanges(), aferoassert.IgnorePaths("logs", "tmp")},
+			expectedResult: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newWritingFs(t, tc.trigger)
+			mockT := &testingT{}
+
+			assert.Equal(t, tc.expectedResult, aferoassert.YAMLTreeContains(mockT, fs, expected, "root", tc.options...), mockT.message())
+
+			for _, err := range tc.expectedErrors {
+				assert.Contains(t, mockT.message(), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package aferoassert
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// previewSize is the number of bytes of the actual content that are shown when a content tag fails.
+const previewSize = 64
+
+// contentPreview summarizes the content with its size and its first bytes.
+func contentPreview(head []byte, size int64) string {
+	if size == 0 {
+		return "0 bytes"
+	}
+
+	if int64(len(head)) > previewSize {
+		head = head[:previewSize]
+	}
+
+	if int64(len(head)) < size {
+		return fmt.Sprintf("%d bytes, starting with %q...", size, head)
+	}
+
+	return fmt.Sprintf("%d bytes: %q", size, head)
+}
+
+// previewFile summarizes the content of a file, or returns the error if it could not be read.
+func previewFile(c *config, fs afero.Fs, path string) string {
+	f, err := openFile(c, fs, path)
+	if err != nil {
+		return err.Error()
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	b := &previewBuffer{limit: previewSize}
+
+	if _, err := io.Copy(b, f); err != nil {
+		return err.Error()
+	}
+
+	return contentPreview(b.buf, b.size)
+}
+
+// checkSize checks whether a file has the size of the `size` tag.
+func checkSize(c *config, fs afero.Fs, path string, info os.FileInfo, expected int64) (string, bool) {
+	if info.Size() == expected {
+		return "", true
+	}
+
+	return fmt.Sprintf("%q size is %d, expected %d, actual content: %s", path, info.Size(), expected, previewFile(c, fs, path)), false
+}
+
+// checkContentFile checks whether a file has the content of the file of the `contentFile` tag.
+func checkContentFile(c *config, fs afero.Fs, path, contentFile string) (string, bool) {
+	expectationFs := c.expectationFs
+	if expectationFs == nil {
+		expectationFs = afero.NewOsFs()
+	}
+
+	expected, err := afero.ReadFile(expectationFs, contentFile)
+	if err != nil {
+		return fmt.Sprintf("could not read the expected content of %q: %s", path, err), false
+	}
+
+	return assertContent(fs, path, string(expected), c)
+}
+
+// checkContains checks whether a file contains the substring of the `contains` tag.
+func checkContains(c *config, fs afero.Fs, path, substr string) (string, bool) {
+	raw, err := readFile(c, fs, path)
+	if err != nil {
+		return fmt.Sprintf("could not read %q: %s", path, err), false
+	}
+
+	if strings.Contains(c.normalize(string(raw)), c.normalize(substr)) {
+		return "", true
+	}
+
+	return fmt.Sprintf("%q does not contain %q, actual content: %s", path, substr, contentPreview(raw, int64(len(raw)))), false
+}
+
+// checkSHA256 checks whether the hex-encoded SHA-256 of a file is the one of the `sha256` tag.
+func checkSHA256(c *config, fs afero.Fs, path, expected string) (string, bool) {
+	f, err := openFile(c, fs, path)
+	if err != nil {
+		return fmt.Sprintf("could not read %q: %s", path, err), false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	b := &previewBuffer{limit: previewSize}
+	h := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(h, b), f); err != nil {
+		return fmt.Sprintf("could not read %q: %s", path, err), false
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return fmt.Sprintf("%q SHA-256 is %s, expected %s, actual content: %s", path, actual, expected, contentPreview(b.buf, b.size)), false
+	}
+
+	return "", true
+}
@@ -0,0 +1,111 @@
+package aferoassert_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestTreeContains_ContentTags(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/hello.txt", []byte("hello world\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/large.txt", []byte(strings.Repeat("0123456789", 10)), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/empty.txt", nil, 0o644))
+
+	testCases := []struct {
+		scenario       string
+		tree           string
+		expectedResult bool
+		expectedError  string
+	}{
+		{
+			scenario: "match",
+			tree: `
+- hello.txt 'size:"12" contains:"world" sha256:"a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447"'
+- large.txt 'size:"100" contains:"789012"'
+- empty.txt 'size:"0" sha256:"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"'
+`,
+			expectedResult: true,
+		},
+		{
+			scenario:      "contains",
+			tree:          `- hello.txt 'contains:"bye"'`,
+			expectedError: `"dist/hello.txt" does not contain "bye", actual content: 12 bytes: "hello world\n"`,
+		},
+		{
+			scenario:      "contains with a long content",
+			tree:          `- large.txt 'contains:"abc"'`,
+			expectedError: `"dist/large.txt" does not contain "abc", actual content: 100 bytes, starting with "0123456789012345678901234567890123456789012345678901234567890123"...`,
+		},
+		{
+			scenario:      "sha256",
+			tree:          `- hello.txt 'sha256:"00"'`,
+			expectedError: `"dist/hello.txt" SHA-256 is a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447, expected 00, actual content: 12 bytes: "hello world\n"`,
+		},
+		{
+			scenario:      "size",
+			tree:          `- hello.txt 'size:"1KB"'`,
+			expectedError: `"dist/hello.txt" size is 12, expected 1024, actual content: 12 bytes: "hello world\n"`,
+		},
+		{
+			scenario:      "size of an empty file",
+			tree:          `- empty.txt 'size:"1"'`,
+			expectedError: `"dist/empty.txt" size is 0, expected 1, actual content: 0 bytes`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.Equal(t, tc.expectedResult, aferoassert.YAMLTreeContains(mockT, fs, tc.tree, "dist"), mockT.message())
+			assert.Contains(t, mockT.message(), tc.expectedError)
+		})
+	}
+}
+
+func TestTreeEqual_ContentFileTag(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "dist/config.yaml", []byte("port: 8080\nname: app\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "dist/README.md", []byte("hello\n"), 0o644))
+
+	tree := `
+- config.yaml 'contentFile:"testdata/expected/config.yaml"'
+- README.md
+`
+
+	// The expected contents are read from the OS file system by default.
+	mockT := &testingT{}
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist"), mockT.message())
+
+	expectationFs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(expectationFs, "testdata/expected/config.yaml", []byte("name: app\nport: 9090\n"), 0o644))
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist", aferoassert.WithExpectationFs(expectationFs)))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"dist/config.yaml" content is not as expected`)
+
+	tree = "- config.yaml 'contentFile:\"testdata/expected/unknown.yaml\"'\n- README.md\n"
+
+	mockT = &testingT{}
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, tree, "dist"))
+	assert.Contains(t, mockT.message(), `could not read the expected content of "`+filepath.Join("dist", "config.yaml")+`"`)
+}
@@ -0,0 +1,125 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotDirNode indicates that a node of a path in a file tree is not a directory.
+var ErrNotDirNode = errors.New("node is not a directory")
+
+// splitTreePath splits a path of a file tree, with either "/" or the separator of the OS, into its names.
+func splitTreePath(path string) []string {
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(path)), "/")
+	names := make([]string, 0, len(parts))
+
+	for _, p := range parts {
+		if p != "" && p != "." {
+			names = append(names, p)
+		}
+	}
+
+	return names
+}
+
+// Get returns the node at the path, for example "a/b/c".
+func (t FileTree) Get(path string) (FileNode, bool) {
+	names := splitTreePath(path)
+	if len(names) == 0 {
+		return FileNode{}, false
+	}
+
+	tree := t
+
+	for i, name := range names {
+		n, ok := tree[name]
+		if !ok {
+			return FileNode{}, false
+		}
+
+		if i == len(names)-1 {
+			return n, true
+		}
+
+		tree = n.Children
+	}
+
+	return FileNode{}, false
+}
+
+// Add adds the node at the path, for example "a/b/c", or replaces the node that is already there. The name of the node
+// is set to the last name of the path and the missing parent directories are created. It fails if a parent is not a
+// directory.
+func (t *FileTree) Add(path string, n FileNode) error {
+	names := splitTreePath(path)
+	if len(names) == 0 {
+		return fmt.Errorf("could not add %q: %w", path, ErrFileNameEmpty)
+	}
+
+	if *t == nil {
+		*t = make(FileTree)
+	}
+
+	n.Name = names[len(names)-1]
+
+	return addNode(*t, path, names, n)
+}
+
+func addNode(tree FileTree, path string, names []string, n FileNode) error {
+	if len(names) == 1 {
+		tree[n.Name] = n
+
+		return nil
+	}
+
+	parent, ok := tree[names[0]]
+	if !ok {
+		parent = FileNode{Name: names[0], IsDir: true}
+	}
+
+	if !parent.IsDir {
+		return fmt.Errorf("could not add %q: %w: %q", path, ErrNotDirNode, parent.Name)
+	}
+
+	if parent.Children == nil {
+		parent.Children = make(FileTree)
+	}
+
+	if err := addNode(parent.Children, path, names[1:], n); err != nil {
+		return err
+	}
+
+	tree[parent.Name] = parent
+
+	return nil
+}
+
+// Remove removes the node at the path, for example "a/b/c", with its children. It tells whether the node existed. The
+// parent directories are kept, even if they become empty.
+func (t FileTree) Remove(path string) bool {
+	names := splitTreePath(path)
+	if len(names) == 0 {
+		return false
+	}
+
+	tree := t
+
+	for _, name := range names[:len(names)-1] {
+		n, ok := tree[name]
+		if !ok {
+			return false
+		}
+
+		tree = n.Children
+	}
+
+	if _, ok := tree[names[len(names)-1]]; !ok {
+		return false
+	}
+
+	delete(tree, names[len(names)-1])
+
+	return true
+}
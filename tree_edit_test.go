@@ -0,0 +1,117 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func newBaseTree(t *testing.T) aferoassert.FileTree {
+	t.Helper()
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- README.md
+- bin:
+    - app 'perm:"0755"'
+- docs:
+    - guides:
+        - intro.md
+`), &tree))
+
+	return tree
+}
+
+func TestFileTree_Get(t *testing.T) {
+	t.Parallel()
+
+	tree := newBaseTree(t)
+
+	n, ok := tree.Get("bin/app")
+
+	require.True(t, ok)
+	assert.Equal(t, "app", n.Name)
+	assert.Equal(t, os.FileMode(0o755), *n.Tags.Perm())
+
+	n, ok = tree.Get("./docs/guides/")
+
+	require.True(t, ok)
+	assert.True(t, n.IsDir)
+	assert.Len(t, n.Children, 1)
+
+	for _, path := range []string{"", ".", "unknown", "bin/unknown", "README.md/unknown", "docs/guides/intro.md/unknown"} {
+		_, ok := tree.Get(path)
+
+		assert.False(t, ok, path)
+	}
+}
+
+func TestFileTree_Add(t *testing.T) {
+	t.Parallel()
+
+	tree := newBaseTree(t)
+
+	require.NoError(t, tree.Add("bin/app", aferoassert.FileNode{Tags: aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(0o700)}}))
+	require.NoError(t, tree.Add("docs/api/v1/index.md", aferoassert.FileNode{Name: "ignored"}))
+	require.NoError(t, tree.Add("LICENSE", aferoassert.FileNode{}))
+
+	out, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+
+	expected := `- LICENSE
+- README.md
+- bin:
+    - app 'perm:"0700"'
+- docs:
+    - api:
+        - v1:
+            - index.md
+    - guides:
+        - intro.md
+`
+
+	assert.Equal(t, expected, string(out))
+
+	err = tree.Add("README.md/section", aferoassert.FileNode{})
+
+	assert.ErrorIs(t, err, aferoassert.ErrNotDirNode)
+	assert.EqualError(t, err, `could not add "README.md/section": node is not a directory: "README.md"`)
+
+	assert.ErrorIs(t, tree.Add(".", aferoassert.FileNode{}), aferoassert.ErrFileNameEmpty)
+
+	var empty aferoassert.FileTree
+
+	require.NoError(t, empty.Add("a/b", aferoassert.FileNode{}))
+
+	_, ok := empty.Get("a/b")
+
+	assert.True(t, ok)
+}
+
+func TestFileTree_Remove(t *testing.T) {
+	t.Parallel()
+
+	tree := newBaseTree(t)
+
+	assert.True(t, tree.Remove("docs/guides/intro.md"))
+	assert.True(t, tree.Remove("bin"))
+	assert.False(t, tree.Remove("bin/app"))
+	assert.False(t, tree.Remove("unknown"))
+	assert.False(t, tree.Remove(""))
+
+	out, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+
+	expected := `- README.md
+- docs:
+    - guides: {}
+`
+
+	assert.Equal(t, expected, string(out))
+}
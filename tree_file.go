@@ -0,0 +1,182 @@
+package aferoassert
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLTreeEqualFile checks whether a directory is the same as the YAML expectation read from a file, so the large
+// expectations can live in testdata, for example:
+//
+//	aferoassert.YAMLTreeEqualFile(t, fs, afero.NewOsFs(), "testdata/layout.yaml", "root")
+func YAMLTreeEqualFile(t TestingT, fs, expectationFs afero.Fs, expectationPath, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return assertTreeFrom(t, c, "YAMLTreeEqualFile", func() (FileTree, string, error) {
+		ft, err := readTreeFile(expectationFs, expectationPath)
+
+		return ft, expectationPath, err
+	}, fs, path, true, msgAndArgs...)
+}
+
+// YAMLTreeContainsFile checks whether a directory contains the YAML expectation read from a file, see
+// YAMLTreeEqualFile.
+func YAMLTreeContainsFile(t TestingT, fs, expectationFs afero.Fs, expectationPath, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return assertTreeFrom(t, c, "YAMLTreeContainsFile", func() (FileTree, string, error) {
+		ft, err := readTreeFile(expectationFs, expectationPath)
+
+		return ft, expectationPath, err
+	}, fs, path, false, msgAndArgs...)
+}
+
+// assertTreeFrom loads the expectation and asserts the tree in c.run, so the failures of the loading honor the options,
+// such as WithFailNow. The load function returns the source of the expectation, to report the errors with it.
+func assertTreeFrom(t TestingT, c *config, assertion string, load func() (FileTree, string, error), fs afero.Fs, path string, exhaustive bool, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return c.run(t, assertion, func(t TestingT) bool {
+		ft, source, err := load()
+		if err != nil {
+			return c.fail(t, FailureInfo{Path: source, Message: err.Error()}, msgAndArgs...)
+		}
+
+		return assertTree(t, c, fs, ft, path, exhaustive, msgAndArgs...)
+	})
+}
+
+// readTreeFile reads and unmarshals the YAML expectation, the errors have the path of the file.
+func readTreeFile(fs afero.Fs, path string) (FileTree, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read expectation %q: %w", path, err)
+	}
+
+	return parseTree(path, data)
+}
+
+// TreeEqualFromReader checks whether a directory is the same as the YAML expectation read from a reader, for example,
+// a file embedded with go:embed. The errors are reported with the name of the reader if it has one, like *os.File.
+func TreeEqualFromReader(t TestingT, fs afero.Fs, r io.Reader, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	// The reader is read once, so the retries, see WithRetry, get the same expectation.
+	name, data, err := readTree(r)
+
+	return assertTreeFrom(t, c, "TreeEqualFromReader", func() (FileTree, string, error) {
+		if err != nil {
+			return nil, name, err
+		}
+
+		ft, err := parseTree(name, data)
+
+		return ft, name, err
+	}, fs, path, true, msgAndArgs...)
+}
+
+// TreeContainsFromReader checks whether a directory contains the YAML expectation read from a reader, see
+// TreeEqualFromReader.
+func TreeContainsFromReader(t TestingT, fs afero.Fs, r io.Reader, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	// The reader is read once, so the retries, see WithRetry, get the same expectation.
+	name, data, err := readTree(r)
+
+	return assertTreeFrom(t, c, "TreeContainsFromReader", func() (FileTree, string, error) {
+		if err != nil {
+			return nil, name, err
+		}
+
+		ft, err := parseTree(name, data)
+
+		return ft, name, err
+	}, fs, path, false, msgAndArgs...)
+}
+
+// TreeEqualFromBytes checks whether a directory is the same as the YAML expectation, for example, a []byte embedded
+// with go:embed or generated on the fly.
+func TreeEqualFromBytes(t TestingT, fs afero.Fs, expected []byte, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return assertTreeFrom(t, c, "TreeEqualFromBytes", func() (FileTree, string, error) {
+		ft, err := parseTree("", expected)
+
+		return ft, "", err
+	}, fs, path, true, msgAndArgs...)
+}
+
+// TreeContainsFromBytes checks whether a directory contains the YAML expectation, see TreeEqualFromBytes.
+func TreeContainsFromBytes(t TestingT, fs afero.Fs, expected []byte, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return assertTreeFrom(t, c, "TreeContainsFromBytes", func() (FileTree, string, error) {
+		ft, err := parseTree("", expected)
+
+		return ft, "", err
+	}, fs, path, false, msgAndArgs...)
+}
+
+// readTree reads the YAML expectation, the errors have the name of the reader, which is returned too.
+func readTree(r io.Reader) (string, []byte, error) {
+	var name string
+
+	if n, ok := r.(interface{ Name() string }); ok {
+		name = n.Name()
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return name, nil, fmt.Errorf("could not read expectation%s: %w", sourceName(name), err)
+	}
+
+	return name, data, nil
+}
+
+// parseTree unmarshals the YAML expectation, the errors have the name of the source, if it is not empty.
+func parseTree(name string, data []byte) (FileTree, error) {
+	var ft FileTree
+
+	if err := yaml.Unmarshal(data, &ft); err != nil {
+		return nil, fmt.Errorf("could not unmarshal expectation%s: %w", sourceName(name), err)
+	}
+
+	return ft, nil
+}
+
+func sourceName(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" %q", name)
+}
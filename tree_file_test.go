@@ -0,0 +1,103 @@
+package aferoassert_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestYAMLTreeEqualFile(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqualFile(mockT, fs, afero.NewOsFs(), "testdata/layout.yaml", "root"), mockT.message())
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 3", nil, 0o644))
+
+	assert.False(t, aferoassert.YAMLTreeEqualFile(mockT, fs, afero.NewOsFs(), "testdata/layout.yaml", "root"))
+	assert.Contains(t, mockT.message(), `unexpected file "root/file 3"`)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeContainsFile(mockT, fs, afero.NewOsFs(), "testdata/layout.yaml", "root"), mockT.message())
+}
+
+func TestYAMLTreeEqualFile_InvalidExpectation(t *testing.T) {
+	t.Parallel()
+
+	expectations := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(expectations, "invalid.yaml", []byte("- file: [\n"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.YAMLTreeEqualFile(mockT, newSourceFs(t), expectations, "invalid.yaml", "root"))
+	assert.Contains(t, mockT.message(), `could not unmarshal expectation "invalid.yaml"`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.YAMLTreeContainsFile(mockT, newSourceFs(t), expectations, "unknown.yaml", "root"))
+	assert.Contains(t, mockT.message(), `could not read expectation "unknown.yaml"`)
+}
+
+func TestTreeEqualFromReader(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	f, err := os.Open("testdata/layout.yaml")
+	require.NoError(t, err)
+
+	defer f.Close() // nolint: errcheck
+
+	assert.True(t, aferoassert.TreeEqualFromReader(mockT, fs, f, "root"), mockT.message())
+	assert.True(t, aferoassert.TreeContainsFromReader(mockT, fs, strings.NewReader("- file 1"), "root"), mockT.message())
+
+	assert.False(t, aferoassert.TreeEqualFromReader(mockT, fs, strings.NewReader("- file 1"), "root"))
+	assert.Contains(t, mockT.message(), `unexpected file "root/folder 2"`)
+
+	mockT = &testingT{}
+
+	invalid, err := afero.TempFile(afero.NewMemMapFs(), "", "invalid.yaml")
+	require.NoError(t, err)
+
+	_, err = invalid.WriteString("- file: [\n")
+	require.NoError(t, err)
+
+	_, err = invalid.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	assert.False(t, aferoassert.TreeContainsFromReader(mockT, fs, invalid, "root"))
+	assert.Contains(t, mockT.message(), fmt.Sprintf("could not unmarshal expectation %q:", invalid.Name()))
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.TreeEqualFromReader(mockT, fs, iotest.ErrReader(errors.New("read error")), "root"))
+	assert.Contains(t, mockT.message(), "could not read expectation: read error")
+}
+
+func TestTreeEqualFromBytes(t *testing.T) {
+	t.Parallel()
+
+	fs := newSourceFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.TreeEqualFromBytes(mockT, fs, []byte("- file 1\n- folder 2:\n    - file 2\n"), "root"), mockT.message())
+	assert.True(t, aferoassert.TreeContainsFromBytes(mockT, fs, []byte("- folder 2:\n    - file 2\n"), "root"), mockT.message())
+
+	assert.False(t, aferoassert.TreeContainsFromBytes(mockT, fs, []byte("- file: [\n"), "root"))
+	assert.Contains(t, mockT.message(), "could not unmarshal expectation: yaml:")
+}
@@ -0,0 +1,31 @@
+package aferoassert
+
+// Filter returns a new tree with the nodes for which the predicate returns true, the path is the same as the one of
+// Walk. The parent directories of the kept nodes are kept too, so the tree stays valid, while the children of a kept
+// directory are still filtered one by one, for example to keep only the files with a perm tag:
+//
+//	expected := tree.Filter(func(_ string, n aferoassert.FileNode) bool {
+//		return !n.IsDir && n.Tags.Perm() != nil
+//	})
+func (t FileTree) Filter(pred func(path string, n FileNode) bool) FileTree {
+	return t.filter("", pred)
+}
+
+func (t FileTree) filter(prefix string, pred func(path string, n FileNode) bool) FileTree {
+	result := make(FileTree)
+
+	for name, n := range t {
+		path := prefix + name
+		kept := n.withoutChildren()
+
+		if n.IsDir {
+			kept.Children = n.Children.filter(path+"/", pred)
+		}
+
+		if len(kept.Children) > 0 || pred(path, n) {
+			result[name] = kept
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+package aferoassert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileTree_Filter(t *testing.T) {
+	t.Parallel()
+
+	tree := unmarshalTree(t, `
+- README.md
+- bin:
+    - app 'perm:"0755"'
+- docs:
+    - guides:
+        - intro.md
+    - api:
+- scripts 'perm:"0700"':
+    - build.sh
+`)
+
+	testCases := []struct {
+		scenario string
+		pred     func(path string, n aferoassert.FileNode) bool
+		expected string
+	}{
+		{
+			scenario: "files",
+			pred: func(_ string, n aferoassert.FileNode) bool {
+				return !n.IsDir
+			},
+			expected: `- README.md
+- bin:
+    - app 'perm:"0755"'
+- docs:
+    - guides:
+        - intro.md
+- scripts 'perm:"0700"':
+    - build.sh
+`,
+		},
+		{
+			scenario: "subdirectory",
+			pred: func(path string, _ aferoassert.FileNode) bool {
+				return path == "docs" || strings.HasPrefix(path, "docs/")
+			},
+			expected: `- docs:
+    - api: {}
+    - guides:
+        - intro.md
+`,
+		},
+		{
+			scenario: "perm tags",
+			pred: func(_ string, n aferoassert.FileNode) bool {
+				return n.Tags.Perm() != nil
+			},
+			expected: `- bin:
+    - app 'perm:"0755"'
+- scripts 'perm:"0700"': {}
+`,
+		},
+		{
+			scenario: "nothing",
+			pred: func(string, aferoassert.FileNode) bool {
+				return false
+			},
+			expected: "{}\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, marshalTree(t, tree.Filter(tc.pred)))
+		})
+	}
+}
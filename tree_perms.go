@@ -0,0 +1,86 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// TreePerms checks whether every regular file under a directory has the file permission and every directory under it
+// has the directory permission, without describing the whole tree. The root itself and the other types of entries,
+// such as the symlinks, are not checked. The paths can be excluded with IgnorePaths, for example:
+//
+//	aferoassert.TreePerms(t, fs, "dist", 0o644, 0o755, aferoassert.IgnorePaths("bin/*"))
+func TreePerms(t TestingT, fs afero.Fs, root string, filePerm, dirPerm os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "TreePerms", func(t TestingT) bool {
+		return assertTreePerms(t, c, fs, root, filePerm, dirPerm, msgAndArgs...)
+	})
+}
+
+func assertTreePerms(t TestingT, c *config, fs afero.Fs, root string, filePerm, dirPerm os.FileMode, msgAndArgs ...interface{}) bool {
+	if c.ignorePerm {
+		return true
+	}
+
+	root = filepath.Clean(root)
+	mismatches := make([]string, 0)
+
+	err := walk(c, fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := relPath(root, path)
+		if rel == "." {
+			return nil
+		}
+
+		if c.ignored(rel) {
+			return skip(info)
+		}
+
+		var expected os.FileMode
+
+		switch {
+		case info.IsDir():
+			expected = dirPerm
+		case info.Mode().IsRegular():
+			expected = filePerm
+		default:
+			return nil
+		}
+
+		if actual := info.Mode().Perm(); !permMatches(c, fs, expected, actual) {
+			mismatches = append(mismatches, fmt.Sprintf("%q permission is 0%o, expected 0%o", path, actual, expected))
+
+			if c.failFast {
+				return errStopWalk
+			}
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopWalk) {
+		return c.fail(t, FailureInfo{Path: root, Message: fmt.Sprintf("could not walk through %q: %s", root, err)}, msgAndArgs...)
+	}
+
+	if len(mismatches) == 0 {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{
+		Path:    root,
+		Actual:  mismatches,
+		Message: fmt.Sprintf("%q has unexpected permissions:\n- %s", root, strings.Join(mismatches, "\n- ")),
+	}, msgAndArgs...)
+}
@@ -0,0 +1,50 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestTreePerms(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, fs.MkdirAll("root/dir/sub", 0o755))
+	require.NoError(t, afero.WriteFile(fs, "root/file", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/dir/sub/file", nil, 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.TreePerms(mockT, fs, "root", 0o644, 0o755), mockT.message())
+
+	require.NoError(t, fs.Chmod("root/dir/sub", 0o700))
+	require.NoError(t, afero.WriteFile(fs, "root/bin/run", nil, 0o755))
+	require.NoError(t, fs.Chmod("root/bin/run", 0o755))
+	require.NoError(t, fs.Chmod("root/file", 0o600))
+
+	assert.False(t, aferoassert.TreePerms(mockT, fs, "root", 0o644, 0o755))
+	assert.Contains(t, mockT.message(), `"root" has unexpected permissions:`)
+	assert.Contains(t, mockT.message(), `"root/bin/run" permission is 0755, expected 0644`)
+	assert.Contains(t, mockT.message(), `"root/dir/sub" permission is 0700, expected 0755`)
+	assert.Contains(t, mockT.message(), `"root/file" permission is 0600, expected 0644`)
+
+	mockT = &testingT{}
+
+	assert.False(t, aferoassert.TreePerms(mockT, fs, "root", 0o644, 0o755, aferoassert.IgnorePaths("bin", "sub")))
+	assert.NotContains(t, mockT.message(), `root/bin/run`)
+	assert.NotContains(t, mockT.message(), `root/dir/sub`)
+	assert.Contains(t, mockT.message(), `"root/file" permission is 0600, expected 0644`)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.TreePerms(mockT, fs, "root", 0o644, 0o755, aferoassert.IgnorePerm()), mockT.message())
+
+	assert.False(t, aferoassert.TreePerms(mockT, fs, "unknown", 0o644, 0o755))
+	assert.Contains(t, mockT.message(), `could not walk through "unknown"`)
+}
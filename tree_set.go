@@ -0,0 +1,90 @@
+package aferoassert
+
+// Union returns a new tree with the nodes of both trees. When a path is in both trees, the node of the other tree is
+// used, and the children of the directories are merged.
+//
+//	expected := base.Union(overrides)
+func (t FileTree) Union(other FileTree) FileTree {
+	result := make(FileTree, len(t)+len(other))
+
+	for name, n := range t {
+		result[name] = n.clone()
+	}
+
+	for name, o := range other {
+		n, ok := result[name]
+		if !ok || !n.IsDir || !o.IsDir {
+			result[name] = o.clone()
+
+			continue
+		}
+
+		merged := o.withoutChildren()
+		merged.Children = n.Children.Union(o.Children)
+
+		result[name] = merged
+	}
+
+	return result
+}
+
+// Intersect returns a new tree with the nodes whose paths are in both trees. The nodes of the tree are used, and the
+// nodes of the other tree only select the paths.
+func (t FileTree) Intersect(other FileTree) FileTree {
+	result := make(FileTree)
+
+	for name, n := range t {
+		o, ok := other[name]
+		if !ok {
+			continue
+		}
+
+		kept := n.withoutChildren()
+
+		if n.IsDir {
+			kept.Children = n.Children.Intersect(o.Children)
+		}
+
+		result[name] = kept
+	}
+
+	return result
+}
+
+// Subtract returns a new tree without the paths of the other tree. A file or an empty directory of the other tree
+// removes the node with its children, while a directory with children only removes these children, for example the
+// docs subtree is removed with:
+//
+//	expected := base.Subtract(aferoassert.FileTree{"docs": {Name: "docs", IsDir: true}})
+func (t FileTree) Subtract(other FileTree) FileTree {
+	result := make(FileTree, len(t))
+
+	for name, n := range t {
+		o, ok := other[name]
+
+		switch {
+		case !ok:
+			result[name] = n.clone()
+
+		case len(o.Children) > 0 && n.IsDir:
+			kept := n.withoutChildren()
+			kept.Children = n.Children.Subtract(o.Children)
+
+			result[name] = kept
+		}
+	}
+
+	return result
+}
+
+// withoutChildren copies the node deeply, except for its children.
+func (n FileNode) withoutChildren() FileNode {
+	n.Children = nil
+	n = n.clone()
+
+	if n.IsDir {
+		n.Children = make(FileTree)
+	}
+
+	return n
+}
@@ -0,0 +1,167 @@
+package aferoassert_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.nhat.io/aferoassert"
+)
+
+func unmarshalTree(t *testing.T, s string) aferoassert.FileTree {
+	t.Helper()
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, yaml.Unmarshal([]byte(s), &tree))
+
+	return tree
+}
+
+func marshalTree(t *testing.T, tree aferoassert.FileTree) string {
+	t.Helper()
+
+	out, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func TestFileTree_Union(t *testing.T) {
+	t.Parallel()
+
+	base := newBaseTree(t)
+	other := unmarshalTree(t, `
+- README.md 'perm:"0600"'
+- bin 'perm:"0700"':
+    - tool
+- docs:
+    - guides:
+        - advanced.md
+`)
+
+	expected := `- README.md 'perm:"0600"'
+- bin 'perm:"0700"':
+    - app 'perm:"0755"'
+    - tool
+- docs:
+    - guides:
+        - advanced.md
+        - intro.md
+`
+
+	union := base.Union(other)
+
+	assert.Equal(t, expected, marshalTree(t, union))
+
+	// The trees are not modified.
+	*union["bin"].Children["app"].Tags.Perm() = 0o700
+
+	assert.Equal(t, os.FileMode(0o755), *base["bin"].Children["app"].Tags.Perm())
+	assert.Len(t, base["bin"].Children, 1)
+}
+
+func TestFileTree_Union_ReplaceType(t *testing.T) {
+	t.Parallel()
+
+	base := newBaseTree(t)
+	other := unmarshalTree(t, `
+- bin
+- README.md:
+    - index.md
+`)
+
+	expected := `- README.md:
+    - index.md
+- bin
+- docs:
+    - guides:
+        - intro.md
+`
+
+	assert.Equal(t, expected, marshalTree(t, base.Union(other)))
+}
+
+func TestFileTree_Intersect(t *testing.T) {
+	t.Parallel()
+
+	base := newBaseTree(t)
+	other := unmarshalTree(t, `
+- README.md 'perm:"0600"'
+- bin
+- docs:
+    - guides:
+        - intro.md
+        - advanced.md
+- LICENSE
+`)
+
+	expected := `- README.md
+- bin: {}
+- docs:
+    - guides:
+        - intro.md
+`
+
+	assert.Equal(t, expected, marshalTree(t, base.Intersect(other)))
+	assert.Empty(t, base.Intersect(nil))
+}
+
+func TestFileTree_Subtract(t *testing.T) {
+	t.Parallel()
+
+	base := newBaseTree(t)
+
+	testCases := []struct {
+		scenario string
+		other    string
+		expected string
+	}{
+		{
+			scenario: "subtree",
+			other:    "- docs:\n",
+			expected: `- README.md
+- bin:
+    - app 'perm:"0755"'
+`,
+		},
+		{
+			scenario: "nested file",
+			other: `
+- docs:
+    - guides:
+        - intro.md
+- bin:
+    - unknown
+`,
+			expected: `- README.md
+- bin:
+    - app 'perm:"0755"'
+- docs:
+    - guides: {}
+`,
+		},
+		{
+			scenario: "file over directory",
+			other:    "- bin\n- README.md\n",
+			expected: `- docs:
+    - guides:
+        - intro.md
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, marshalTree(t, base.Subtract(unmarshalTree(t, tc.other))))
+		})
+	}
+
+	assert.Equal(t, marshalTree(t, base), marshalTree(t, base.Subtract(nil)))
+}
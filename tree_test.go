@@ -2,6 +2,8 @@ package aferoassert_test
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -81,6 +83,28 @@ func TestFileTree_Flatten(t *testing.T) {
 	assert.Equal(t, expected, ft.Flatten(""))
 }
 
+func TestFileTree_Flatten_Deep(t *testing.T) {
+	t.Parallel()
+
+	const depth = 2000
+
+	tree := aferoassert.FileTree{"leaf": {Name: "leaf"}}
+
+	for i := 0; i < depth; i++ {
+		tree = aferoassert.FileTree{"d": {Name: "d", IsDir: true, Children: tree}}
+	}
+
+	flat := tree.Flatten("root")
+
+	assert.Len(t, flat, depth+1)
+	assert.Contains(t, flat, filepath.Join("root", strings.Repeat("d"+string(filepath.Separator), depth)+"leaf"))
+
+	node := tree["d"].Flatten("")
+
+	assert.Len(t, node, depth+1)
+	assert.Contains(t, node, "d")
+}
+
 func TestNode_Serde(t *testing.T) {
 	t.Parallel()
 
@@ -114,6 +138,30 @@ func TestNode_Serde(t *testing.T) {
 	assert.Equal(t, expected, string(result))
 }
 
+func TestNode_Serde_Content(t *testing.T) {
+	t.Parallel()
+
+	text := `- file 1 'content:"hello\nworld"'
+- folder 2:
+    - file 2 'perm:"0644" content:"hello, \"world\"\n"'
+`
+
+	var ft aferoassert.FileTree
+
+	err := yaml.Unmarshal([]byte(text), &ft)
+	require.NoError(t, err)
+
+	content, ok := ft["file 1"].Attrs.Content()
+
+	assert.True(t, ok)
+	assert.Equal(t, "hello\nworld", content)
+
+	result, err := yaml.Marshal(ft)
+	require.NoError(t, err)
+
+	assert.Equal(t, text, string(result))
+}
+
 func TestNode_UnmarshalYAML(t *testing.T) {
 	t.Parallel()
 
@@ -192,6 +240,28 @@ file 2:
 `,
 			expectedError: `invalid file mode in "type" tag at line 3`,
 		},
+		{
+			scenario: "empty directory as mapping",
+			text:     `- folder 1: {}`,
+			expectedResult: aferoassert.FileTree{
+				"folder 1": {Name: "folder 1", IsDir: true},
+			},
+		},
+		{
+			scenario: "content tag",
+			text:     `- file 1 'perm:"0644" content:"hello, \"world\"\n"'`,
+			expectedResult: aferoassert.FileTree{
+				"file 1": {
+					Name: "file 1",
+					Tags: aferoassert.FileModeTags{
+						"perm": aferoassert.FileModeFromUint64(0o644),
+					},
+					Attrs: aferoassert.FileAttrs{
+						"content": "hello, \"world\"\n",
+					},
+				},
+			},
+		},
 		{
 			scenario: "valid with tags",
 			text: `
@@ -253,3 +323,71 @@ file 2:
 		})
 	}
 }
+
+func TestParseFileMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mode          string
+		expectedMode  os.FileMode
+		expectedError string
+	}{
+		{
+			scenario:     "names",
+			mode:         "Dir|Sticky",
+			expectedMode: os.ModeDir | os.ModeSticky,
+		},
+		{
+			scenario:     "octal",
+			mode:         "0755",
+			expectedMode: 0o755,
+		},
+		{
+			scenario:     "decimal",
+			mode:         "493",
+			expectedMode: 0o755,
+		},
+		{
+			scenario:      "unknown name",
+			mode:          "Dir|Unknown",
+			expectedError: `invalid file mode: "Dir|Unknown"`,
+		},
+		{
+			scenario:      "empty",
+			mode:          "",
+			expectedError: `invalid file mode: ""`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mode, err := aferoassert.ParseFileMode(tc.mode)
+
+			assert.Equal(t, tc.expectedMode, mode)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, aferoassert.ErrInvalidFileMode)
+				require.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestFormatFileMode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Dir|Sticky", aferoassert.FormatFileMode(os.ModeDir|os.ModeSticky|0o755))
+	assert.Equal(t, "Symlink", aferoassert.FormatFileMode(os.ModeSymlink))
+	assert.Equal(t, "", aferoassert.FormatFileMode(0o644))
+
+	mode, err := aferoassert.ParseFileMode(aferoassert.FormatFileMode(os.ModeNamedPipe | os.ModeSetgid))
+
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeNamedPipe|os.ModeSetgid, mode)
+}
@@ -0,0 +1,63 @@
+package aferoassert
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// WalkTreeFunc is called by FileTree.Walk for each node of the tree, the path is relative to the tree and uses "/" as
+// the separator, for example "a/b/c".
+type WalkTreeFunc func(path string, node FileNode) error
+
+type walkEntry struct {
+	path string
+	node FileNode
+}
+
+// Walk calls fn for each node of the tree, the parents before their children and the siblings in lexical order, so
+// the order is always the same. If fn returns filepath.SkipDir for a directory, its children are skipped, any other
+// error stops the walk and is returned.
+//
+//	err := tree.Walk(func(path string, n aferoassert.FileNode) error {
+//		fmt.Println(path, n.Tags)
+//
+//		return nil
+//	})
+func (t FileTree) Walk(fn WalkTreeFunc) error {
+	// The stack is used instead of recursion, so a deep tree does not grow the call stack.
+	stack := pushSorted(nil, "", t)
+
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if err := fn(e.path, e.node); err != nil {
+			if err == filepath.SkipDir { // nolint: errorlint
+				continue
+			}
+
+			return err
+		}
+
+		stack = pushSorted(stack, e.path+"/", e.node.Children)
+	}
+
+	return nil
+}
+
+// pushSorted pushes the nodes of the tree to the stack in reverse lexical order, so they are popped in lexical order.
+func pushSorted(stack []walkEntry, prefix string, t FileTree) []walkEntry {
+	names := make([]string, 0, len(t))
+
+	for name := range t {
+		names = append(names, name)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	for _, name := range names {
+		stack = append(stack, walkEntry{path: prefix + name, node: t[name]})
+	}
+
+	return stack
+}
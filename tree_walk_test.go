@@ -0,0 +1,109 @@
+package aferoassert_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileTree_Walk(t *testing.T) {
+	t.Parallel()
+
+	tree := newBaseTree(t)
+
+	require.NoError(t, tree.Add("docs/api/index.md", aferoassert.FileNode{}))
+	require.NoError(t, tree.Add("CHANGELOG.md", aferoassert.FileNode{}))
+
+	var paths []string
+
+	err := tree.Walk(func(path string, n aferoassert.FileNode) error {
+		paths = append(paths, path)
+
+		return nil
+	})
+
+	expected := []string{
+		"CHANGELOG.md",
+		"README.md",
+		"bin",
+		"bin/app",
+		"docs",
+		"docs/api",
+		"docs/api/index.md",
+		"docs/guides",
+		"docs/guides/intro.md",
+	}
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, paths)
+}
+
+func TestFileTree_Walk_SkipDir(t *testing.T) {
+	t.Parallel()
+
+	tree := newBaseTree(t)
+
+	var paths []string
+
+	err := tree.Walk(func(path string, n aferoassert.FileNode) error {
+		paths = append(paths, path)
+
+		if path == "docs" || path == "README.md" {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "bin", "bin/app", "docs"}, paths)
+}
+
+func TestFileTree_Walk_Error(t *testing.T) {
+	t.Parallel()
+
+	tree := newBaseTree(t)
+	errStop := errors.New("stop")
+
+	var paths []string
+
+	err := tree.Walk(func(path string, n aferoassert.FileNode) error {
+		paths = append(paths, path)
+
+		if strings.HasPrefix(path, "bin/") {
+			return errStop
+		}
+
+		return nil
+	})
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, []string{"README.md", "bin", "bin/app"}, paths)
+}
+
+func TestFileTree_Walk_Deep(t *testing.T) {
+	t.Parallel()
+
+	const depth = 2000
+
+	var tree aferoassert.FileTree
+
+	require.NoError(t, tree.Add(strings.Repeat("d/", depth)+"file", aferoassert.FileNode{}))
+
+	cnt := 0
+
+	err := tree.Walk(func(string, aferoassert.FileNode) error {
+		cnt++
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, depth+1, cnt)
+}
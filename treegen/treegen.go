@@ -0,0 +1,152 @@
+// Package treegen generates random file trees for the property-based and the fuzz tests of the afero backends and of
+// the aferoassert matchers, for example:
+//
+//	func FuzzBackend(f *testing.F) {
+//		f.Add(int64(1))
+//
+//		f.Fuzz(func(t *testing.T, seed int64) {
+//			treegen.Check(t, newBackend(), "root", rand.New(rand.NewSource(seed)), treegen.Config{})
+//		})
+//	}
+package treegen
+
+import (
+	"math/rand"
+	"os"
+
+	"github.com/spf13/afero"
+
+	"go.nhat.io/aferoassert"
+)
+
+const nameChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+type tHelper interface {
+	Helper()
+}
+
+var (
+	defaultFilePerms = []os.FileMode{0o400, 0o600, 0o640, 0o644, 0o700, 0o755}
+	defaultDirPerms  = []os.FileMode{0o700, 0o750, 0o755}
+)
+
+// Config configures the generated trees. The zero value uses the defaults.
+type Config struct {
+	// MaxDepth is the maximum number of nested directories, default is 3.
+	MaxDepth int
+	// MaxEntries is the maximum number of entries in a directory, default is 5.
+	MaxEntries int
+	// MaxNameLength is the maximum length of a name, default is 8.
+	MaxNameLength int
+	// MaxFileSize is the maximum size of a file, default is 64.
+	MaxFileSize int
+	// FilePerms are the permissions of the files, default are the common ones, from 0400 to 0755.
+	FilePerms []os.FileMode
+	// DirPerms are the permissions of the directories, default are 0700, 0750 and 0755. They must be accessible by the
+	// owner for the tree to be materialized.
+	DirPerms []os.FileMode
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxDepth <= 0 {
+		c.MaxDepth = 3
+	}
+
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = 5
+	}
+
+	if c.MaxNameLength <= 0 {
+		c.MaxNameLength = 8
+	}
+
+	if c.MaxFileSize <= 0 {
+		c.MaxFileSize = 64
+	}
+
+	if len(c.FilePerms) == 0 {
+		c.FilePerms = defaultFilePerms
+	}
+
+	if len(c.DirPerms) == 0 {
+		c.DirPerms = defaultDirPerms
+	}
+
+	return c
+}
+
+// Generate generates a random file tree. Every node has a `perm` tag and every file has a `content` attribute, so the
+// tree can be materialized and asserted with aferoassert.TreeEqual.
+func Generate(r *rand.Rand, cfg Config) aferoassert.FileTree {
+	cfg = cfg.withDefaults()
+
+	return generate(r, cfg, cfg.MaxDepth)
+}
+
+func generate(r *rand.Rand, cfg Config, depth int) aferoassert.FileTree {
+	n := r.Intn(cfg.MaxEntries + 1)
+	tree := make(aferoassert.FileTree, n)
+
+	for i := 0; i < n; i++ {
+		name := randomName(r, cfg.MaxNameLength)
+		if _, ok := tree[name]; ok {
+			continue
+		}
+
+		if depth > 0 && r.Intn(3) == 0 {
+			tree[name] = aferoassert.FileNode{
+				Name:     name,
+				IsDir:    true,
+				Tags:     aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(cfg.DirPerms[r.Intn(len(cfg.DirPerms))])},
+				Children: generate(r, cfg, depth-1),
+			}
+
+			continue
+		}
+
+		tree[name] = aferoassert.FileNode{
+			Name:  name,
+			Tags:  aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(cfg.FilePerms[r.Intn(len(cfg.FilePerms))])},
+			Attrs: aferoassert.FileAttrs{"content": randomString(r, r.Intn(cfg.MaxFileSize+1))},
+		}
+	}
+
+	return tree
+}
+
+func randomName(r *rand.Rand, maxLength int) string {
+	return randomString(r, 1+r.Intn(maxLength))
+}
+
+func randomString(r *rand.Rand, length int) string {
+	b := make([]byte, length)
+
+	for i := range b {
+		b[i] = nameChars[r.Intn(len(nameChars))]
+	}
+
+	return string(b)
+}
+
+// Materialize creates the files and the directories of the tree in the root directory, see aferoassert.BuildTree.
+func Materialize(fs afero.Fs, root string, tree aferoassert.FileTree) error {
+	return aferoassert.BuildTree(fs, root, tree)
+}
+
+// Check generates a random tree, materializes it in the root directory and asserts that the file system has exactly
+// that tree.
+func Check(t aferoassert.TestingT, fs afero.Fs, root string, r *rand.Rand, cfg Config, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	tree := Generate(r, cfg)
+
+	if err := Materialize(fs, root, tree); err != nil {
+		t.Errorf("could not materialize the tree in %q: %s", root, err)
+
+		return false
+	}
+
+	return aferoassert.TreeEqual(t, fs, tree, root, msgAndArgs...)
+}
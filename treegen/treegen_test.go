@@ -0,0 +1,109 @@
+package treegen_test
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+	"go.nhat.io/aferoassert/treegen"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	cfg := treegen.Config{MaxDepth: 2, MaxEntries: 4, MaxFileSize: 16}
+
+	// Deterministic.
+	assert.Equal(t, treegen.Generate(rand.New(rand.NewSource(42)), cfg), treegen.Generate(rand.New(rand.NewSource(42)), cfg)) //nolint: gosec
+
+	for seed := int64(0); seed < 50; seed++ {
+		tree := treegen.Generate(rand.New(rand.NewSource(seed)), cfg) //nolint: gosec
+
+		for path, n := range tree.Flatten(".") {
+			assert.LessOrEqual(t, strings.Count(path, string(os.PathSeparator)), 2, path)
+			require.NotNil(t, n.Tags.Perm(), path)
+
+			if n.IsDir {
+				assert.LessOrEqual(t, len(n.Children), 4)
+
+				continue
+			}
+
+			content, ok := n.Attrs.Content()
+
+			assert.True(t, ok)
+			assert.LessOrEqual(t, len(content), 16)
+		}
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	tree := aferoassert.FileTree{
+		"dir": {
+			Name:  "dir",
+			IsDir: true,
+			Tags:  aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(0o700)},
+			Children: aferoassert.FileTree{
+				"file": {
+					Name:  "file",
+					Tags:  aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(0o400)},
+					Attrs: aferoassert.FileAttrs{"content": "hello"},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, treegen.Materialize(fs, "root", tree))
+
+	content, err := afero.ReadFile(fs, "root/dir/file")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	info, err := fs.Stat("root/dir/file")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o400), info.Mode().Perm())
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	for seed := int64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewSource(seed)) //nolint: gosec
+
+		assert.True(t, treegen.Check(t, afero.NewMemMapFs(), "root", r, treegen.Config{}), "seed %d", seed)
+		assert.True(t, treegen.Check(t, afero.NewOsFs(), filepath.Join(t.TempDir(), "root"), r, treegen.Config{}), "seed %d", seed)
+	}
+}
+
+func TestCheck_MarshalTreeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for seed := int64(0); seed < 20; seed++ {
+		fs := afero.NewMemMapFs()
+
+		require.NoError(t, treegen.Materialize(fs, "root", treegen.Generate(rand.New(rand.NewSource(seed)), treegen.Config{}))) //nolint: gosec
+
+		result, err := aferoassert.MarshalTree(fs, "root")
+		require.NoError(t, err)
+
+		assert.True(t, aferoassert.YAMLTreeEqual(t, fs, string(result), "root"), "seed %d", seed)
+	}
+}
+
+func FuzzCheck(f *testing.F) {
+	f.Add(int64(1))
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		treegen.Check(t, afero.NewMemMapFs(), "root", rand.New(rand.NewSource(seed)), treegen.Config{}) //nolint: gosec
+	})
+}
@@ -0,0 +1,122 @@
+package aferoassert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// InLayer checks whether a path exists in a layer of a union file system, such as the layer of an
+// afero.CopyOnWriteFs, for example, to verify that a write was copied up.
+func InLayer(t TestingT, layer afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "InLayer", func(t TestingT) bool {
+		return assertInLayer(t, c, layer, path, msgAndArgs...)
+	})
+}
+
+func assertInLayer(t TestingT, c *config, layer afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if _, err := stat(c, layer, path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("%q is not in the layer %s", path, layer.Name())}, msgAndArgs...)
+		}
+
+		return c.fail(t, statFailureInfo(layer, path, err), msgAndArgs...)
+	}
+
+	return true
+}
+
+// NotInBase checks whether a path does not exist in the base of a union file system, such as the base of an
+// afero.CopyOnWriteFs, for example, to verify that a write did not leak to the base.
+func NotInBase(t TestingT, base afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "NotInBase", func(t TestingT) bool {
+		return assertNotInBase(t, c, base, path, msgAndArgs...)
+	})
+}
+
+func assertNotInBase(t TestingT, c *config, base afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if _, err := stat(c, base, path); err != nil {
+		return true
+	}
+
+	return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("%q is in the base %s", path, base.Name())}, msgAndArgs...)
+}
+
+// UnionResolvesTo checks whether a read of a path in a union file system is served from the expected layer, the
+// union and the layer must have the same mode and, for a regular file, the same content.
+func UnionResolvesTo(t TestingT, union, layer afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "UnionResolvesTo", func(t TestingT) bool {
+		return assertUnionResolvesTo(t, c, union, layer, path, msgAndArgs...)
+	})
+}
+
+func assertUnionResolvesTo(t TestingT, c *config, union, layer afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if !assertInLayer(t, c, layer, path, msgAndArgs...) {
+		return false
+	}
+
+	expected, err := stat(c, layer, path)
+	if err != nil {
+		return c.fail(t, statFailureInfo(layer, path, err), msgAndArgs...)
+	}
+
+	actual, err := stat(c, union, path)
+	if err != nil {
+		return c.fail(t, statFailureInfo(union, path, err), msgAndArgs...)
+	}
+
+	if expected.Mode() != actual.Mode() {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: expected.Mode(),
+			Actual:   actual.Mode(),
+			Message:  fmt.Sprintf("%q is not served from the layer %s, mode is %s, expected %s", path, layer.Name(), actual.Mode(), expected.Mode()),
+		}, msgAndArgs...)
+	}
+
+	if !expected.Mode().IsRegular() {
+		return true
+	}
+
+	e, err := readFile(c, layer, path)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	a, err := readFile(c, union, path)
+	if err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: fmt.Sprintf("could not read %q: %s", path, err)}, msgAndArgs...)
+	}
+
+	if !bytes.Equal(e, a) {
+		return c.fail(t, FailureInfo{
+			Path:     path,
+			Expected: string(e),
+			Actual:   string(a),
+			Message:  fmt.Sprintf("%q is not served from the layer %s, content is not the same", path, layer.Name()),
+		}, msgAndArgs...)
+	}
+
+	return true
+}
@@ -0,0 +1,56 @@
+package aferoassert_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestCopyOnWriteFs(t *testing.T) {
+	t.Parallel()
+
+	base := afero.NewMemMapFs()
+	layer := afero.NewMemMapFs()
+	union := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), layer)
+
+	require.NoError(t, afero.WriteFile(base, "base.txt", []byte("base"), 0o644))
+	require.NoError(t, afero.WriteFile(union, "new.txt", []byte("new"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.InLayer(mockT, layer, "new.txt"), mockT.message())
+	assert.True(t, aferoassert.NotInBase(mockT, base, "new.txt"), mockT.message())
+	assert.True(t, aferoassert.UnionResolvesTo(mockT, union, layer, "new.txt"), mockT.message())
+	assert.True(t, aferoassert.UnionResolvesTo(mockT, union, base, "base.txt"), mockT.message())
+
+	assert.False(t, aferoassert.InLayer(mockT, layer, "base.txt"))
+	assert.False(t, aferoassert.NotInBase(mockT, base, "base.txt"))
+	assert.False(t, aferoassert.UnionResolvesTo(mockT, union, layer, "base.txt"))
+	require.Len(t, mockT.errors, 3)
+	assert.Contains(t, mockT.errors[0], `"base.txt" is not in the layer MemMapFS`)
+	assert.Contains(t, mockT.errors[1], `"base.txt" is in the base MemMapFS`)
+	assert.Contains(t, mockT.errors[2], `"base.txt" is not in the layer MemMapFS`)
+}
+
+func TestUnionResolvesTo_Shadowed(t *testing.T) {
+	t.Parallel()
+
+	base := afero.NewMemMapFs()
+	layer := afero.NewMemMapFs()
+	union := afero.NewCopyOnWriteFs(base, layer)
+
+	require.NoError(t, afero.WriteFile(base, "file.txt", []byte("base"), 0o644))
+	require.NoError(t, afero.WriteFile(layer, "file.txt", []byte("layer"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.UnionResolvesTo(mockT, union, layer, "file.txt"), mockT.message())
+
+	assert.False(t, aferoassert.UnionResolvesTo(mockT, union, base, "file.txt"))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"file.txt" is not served from the layer MemMapFS, content is not the same`)
+}
@@ -0,0 +1,60 @@
+package aferoassert
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// ValidationError contains the failures found by Validate and ValidateContains.
+type ValidationError struct {
+	Failures []FailureInfo
+}
+
+// Error satisfies the error interface.
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+
+	for _, f := range e.Failures {
+		messages = append(messages, f.Message)
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks whether a directory is the same as the expectation, like TreeEqual, but without a TestingT, so the
+// applications can verify a directory layout at runtime. It returns a *ValidationError with all the failures.
+func Validate(fs afero.Fs, tree FileTree, root string, opts ...Option) error {
+	return validate("Validate", fs, tree, root, true, opts)
+}
+
+// ValidateContains checks whether a directory contains a file tree, like TreeContains, but without a TestingT. It
+// returns a *ValidationError with all the failures.
+func ValidateContains(fs afero.Fs, tree FileTree, root string, opts ...Option) error {
+	return validate("ValidateContains", fs, tree, root, false, opts)
+}
+
+func validate(name string, fs afero.Fs, tree FileTree, root string, exhaustive bool, opts []Option) error {
+	c := configFromOptions(opts)
+
+	var failures []FailureInfo
+
+	c.onFailure = append(c.onFailure, func(info FailureInfo) {
+		failures = append(failures, info)
+	})
+
+	c.run(discardT{}, name, func(t TestingT) bool {
+		return assertTree(t, c, fs, tree, root, exhaustive)
+	})
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Failures: failures}
+}
+
+// discardT discards the failures, they are collected by an OnFailure hook instead.
+type discardT struct{}
+
+func (discardT) Errorf(string, ...interface{}) {}
@@ -0,0 +1,66 @@
+package aferoassert_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file 2", nil, 0o644))
+
+	tree := aferoassert.FileTree{
+		"file 1": {Name: "file 1", Tags: aferoassert.FileModeTags{"perm": aferoassert.FileModePtr(0o600)}},
+		"file 3": {Name: "file 3"},
+	}
+
+	err := aferoassert.Validate(fs, tree, "root")
+	require.Error(t, err)
+
+	var verr *aferoassert.ValidationError
+
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Failures, 3)
+
+	assert.Equal(t, "Validate", verr.Failures[0].Assertion)
+	assert.Equal(t, `"root/file 1" perm is 0644, expected 0600`, verr.Failures[0].Message)
+	assert.Equal(t, `unexpected file "root/file 2"`, verr.Failures[1].Message)
+	assert.Contains(t, verr.Failures[2].Message, "expected these files in \"root\" but not found:\n- file 3")
+	assert.Contains(t, err.Error(), `unexpected file "root/file 2"`)
+
+	assert.NoError(t, aferoassert.Validate(fs, tree, "root", aferoassert.IgnorePerm(), aferoassert.IgnorePaths("file 2", "file 3")))
+}
+
+func TestValidateContains(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/file 1", nil, 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/file 2", nil, 0o644))
+
+	tree := aferoassert.FileTree{"file 1": {Name: "file 1"}}
+
+	assert.NoError(t, aferoassert.ValidateContains(fs, tree, "root"))
+
+	tree["file 3"] = aferoassert.FileNode{Name: "file 3"}
+
+	err := aferoassert.ValidateContains(fs, tree, "root", aferoassert.WithRetry(2, time.Millisecond))
+	require.Error(t, err)
+
+	var verr *aferoassert.ValidationError
+
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Failures, 1)
+}
@@ -0,0 +1,151 @@
+package aferoassert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// Validator checks whether the content of a file is in the expected format, for example, that it parses. It returns
+// an error describing why the content is not valid.
+type Validator func(r io.Reader) error
+
+var validators = struct {
+	mu  sync.RWMutex
+	ext map[string]Validator
+}{
+	ext: map[string]Validator{
+		".json": JSONValidator,
+		".yaml": YAMLValidator,
+		".yml":  YAMLValidator,
+	},
+}
+
+// RegisterValidator registers a validator for the files with the given extension, for example ".proto". The validator
+// is used by FileValid and the `valid:"true"` tag of the tree assertions. Registering a nil validator removes the
+// existing one.
+func RegisterValidator(ext string, v Validator) {
+	ext = strings.ToLower(ext)
+
+	validators.mu.Lock()
+	defer validators.mu.Unlock()
+
+	if v == nil {
+		delete(validators.ext, ext)
+
+		return
+	}
+
+	validators.ext[ext] = v
+}
+
+func validatorFor(path string) (Validator, bool) {
+	validators.mu.RLock()
+	defer validators.mu.RUnlock()
+
+	v, ok := validators.ext[strings.ToLower(filepath.Ext(path))]
+
+	return v, ok
+}
+
+// JSONValidator checks whether the content is a single JSON document.
+func JSONValidator(r io.Reader) error {
+	dec := json.NewDecoder(r)
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&v); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after the json document") // nolint: goerr113
+	}
+
+	return nil
+}
+
+// YAMLValidator checks whether the content is a stream of valid YAML documents.
+func YAMLValidator(r io.Reader) error {
+	dec := yaml.NewDecoder(r)
+
+	for {
+		var v interface{}
+
+		if err := dec.Decode(&v); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// FileValid checks whether a file is valid according to the validator registered for its extension, see
+// RegisterValidator. It fails if there is no validator for the extension.
+func FileValid(t TestingT, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "FileValid", func(t TestingT) bool {
+		return assertFileValid(t, c, fs, path, msgAndArgs...)
+	})
+}
+
+func assertFileValid(t TestingT, c *config, fs afero.Fs, path string, msgAndArgs ...interface{}) bool {
+	v, ok := validatorFor(path)
+	if !ok {
+		return c.fail(t, FailureInfo{Path: path, Message: noValidatorMessage(path)}, msgAndArgs...)
+	}
+
+	f, ok := openRegularFile(t, c, fs, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	if err := v(f); err != nil {
+		return c.fail(t, FailureInfo{Path: path, Message: invalidFileMessage(path, err)}, msgAndArgs...)
+	}
+
+	return true
+}
+
+// checkValid validates a file for the `valid` tag of the tree assertions.
+func checkValid(c *config, fs afero.Fs, path string) (string, bool) {
+	v, ok := validatorFor(path)
+	if !ok {
+		return noValidatorMessage(path), false
+	}
+
+	f, err := openFile(c, fs, path)
+	if err != nil {
+		return fmt.Sprintf("could not open %q: %s", path, err), false
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	if err := v(f); err != nil {
+		return invalidFileMessage(path, err), false
+	}
+
+	return "", true
+}
+
+func noValidatorMessage(path string) string {
+	return fmt.Sprintf("no validator is registered for the extension %q of %q", filepath.Ext(path), path)
+}
+
+func invalidFileMessage(path string, err error) string {
+	return fmt.Sprintf("%q is not valid: %s", path, err)
+}
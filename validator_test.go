@@ -0,0 +1,103 @@
+package aferoassert_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestFileValid(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "valid.json", []byte(`{"key": "value"}`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "invalid.json", []byte(`{"key": }`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "trailing.json", []byte(`{} {}`), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "valid.yaml", []byte("key: value\n---\nother: value\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "invalid.yml", []byte("key: [\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "file.txt", nil, 0o644))
+
+	testCases := []struct {
+		scenario string
+		path     string
+		message  string
+	}{
+		{scenario: "valid json", path: "valid.json"},
+		{scenario: "invalid json", path: "invalid.json", message: `"invalid.json" is not valid: invalid character '}'`},
+		{scenario: "trailing json", path: "trailing.json", message: `"trailing.json" is not valid: unexpected data after the json document`},
+		{scenario: "valid yaml", path: "valid.yaml"},
+		{scenario: "invalid yaml", path: "invalid.yml", message: `"invalid.yml" is not valid: yaml:`},
+		{scenario: "no validator", path: "file.txt", message: `no validator is registered for the extension ".txt" of "file.txt"`},
+		{scenario: "not found", path: "unknown.json", message: `unable to find file "unknown.json"`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := &testingT{}
+
+			assert.Equal(t, tc.message == "", aferoassert.FileValid(mockT, fs, tc.path))
+
+			if tc.message == "" {
+				assert.Empty(t, mockT.message())
+			} else {
+				assert.Contains(t, mockT.message(), tc.message)
+			}
+		})
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	t.Parallel()
+
+	aferoassert.RegisterValidator(".Conf", func(r io.Reader) error {
+		s := bufio.NewScanner(r)
+
+		for s.Scan() {
+			if !strings.HasSuffix(s.Text(), ";") {
+				return errors.New("missing semicolon")
+			}
+		}
+
+		return s.Err()
+	})
+
+	t.Cleanup(func() {
+		aferoassert.RegisterValidator(".conf", nil)
+	})
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "root/valid.conf", []byte("listen 80;\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "root/invalid.conf", []byte("listen 80\n"), 0o644))
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.FileValid(mockT, fs, "root/valid.conf"), mockT.message())
+
+	assert.False(t, aferoassert.FileValid(mockT, fs, "root/invalid.conf"))
+	assert.Contains(t, mockT.message(), `"root/invalid.conf" is not valid: missing semicolon`)
+
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeContains(mockT, fs, `- valid.conf 'valid:"true"'`, "root"), mockT.message())
+
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, `
+- valid.conf 'valid:"true"'
+- invalid.conf 'valid:"true"'
+`, "root"))
+	assert.Contains(t, mockT.message(), `"root/invalid.conf" is not valid: missing semicolon`)
+	assert.NotContains(t, mockT.message(), `"root/valid.conf"`)
+}
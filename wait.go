@@ -0,0 +1,113 @@
+package aferoassert
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+)
+
+const (
+	waitMinInterval = 10 * time.Millisecond
+	waitMaxInterval = 250 * time.Millisecond
+)
+
+// WaitForPath waits until a path exists, for example, when an external process writes the file. With an afero.OsFs,
+// the nearest existing ancestor of the path is watched with fsnotify, and the watch moves down as the missing parents
+// are created, the polling only is a fallback. The other file systems are polled with an increasing interval. It fails
+// if the path does not exist before the timeout.
+func WaitForPath(t TestingT, fs afero.Fs, path string, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "WaitForPath", func(t TestingT) bool {
+		return assertWaitForPath(t, c, fs, path, timeout, msgAndArgs...)
+	})
+}
+
+func assertWaitForPath(t TestingT, c *config, fs afero.Fs, path string, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	var (
+		w       *fsnotify.Watcher
+		events  <-chan fsnotify.Event
+		errs    <-chan error
+		watched string
+	)
+
+	if isOsFs(fs) {
+		if nw, err := fsnotify.NewWatcher(); err == nil {
+			defer nw.Close() // nolint: errcheck
+
+			w, events, errs = nw, nw.Events, nw.Errors
+		}
+	}
+
+	interval := waitMinInterval
+
+	for {
+		if _, err := stat(c, fs, path); err == nil {
+			return true
+		}
+
+		if w != nil {
+			watched = watchNearestDir(w, watched, filepath.Dir(path))
+		}
+
+		poll := time.NewTimer(interval)
+
+		select {
+		case <-deadline.C:
+			poll.Stop()
+
+			if _, err := stat(c, fs, path); err == nil {
+				return true
+			}
+
+			return c.fail(t, FailureInfo{Kind: FailureNotFound, Path: path, Message: fmt.Sprintf("%q did not appear within %s", path, timeout)}, msgAndArgs...)
+
+		case <-events:
+			poll.Stop()
+
+		case <-errs:
+			poll.Stop()
+
+		case <-poll.C:
+		}
+
+		if interval *= 2; interval > waitMaxInterval {
+			interval = waitMaxInterval
+		}
+	}
+}
+
+// watchNearestDir watches the nearest existing ancestor of the directory, including itself, instead of the watched one,
+// so the creation of the next missing parent wakes WaitForPath up. It returns the watched directory.
+func watchNearestDir(w *fsnotify.Watcher, watched, dir string) string {
+	for {
+		if dir == watched {
+			return watched
+		}
+
+		if err := w.Add(dir); err == nil {
+			if watched != "" {
+				_ = w.Remove(watched) // nolint: errcheck
+			}
+
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return watched
+		}
+
+		dir = parent
+	}
+}
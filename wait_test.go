@@ -0,0 +1,95 @@
+package aferoassert_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestWaitForPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		fs       afero.Fs
+		root     func(t *testing.T) string
+	}{
+		{
+			scenario: "polling",
+			fs:       afero.NewMemMapFs(),
+			root: func(*testing.T) string {
+				return "root"
+			},
+		},
+		{
+			scenario: "fsnotify",
+			fs:       afero.NewOsFs(),
+			root: func(t *testing.T) string {
+				t.Helper()
+
+				return t.TempDir()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			root := tc.root(t)
+			path := filepath.Join(root, "file")
+
+			require.NoError(t, tc.fs.MkdirAll(root, 0o755))
+
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+
+				_ = afero.WriteFile(tc.fs, path, nil, 0o644) // nolint: errcheck
+			}()
+
+			mockT := &testingT{}
+
+			assert.True(t, aferoassert.WaitForPath(mockT, tc.fs, path, 5*time.Second), mockT.message())
+		})
+	}
+}
+
+func TestWaitForPath_MissingParents(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewOsFs()
+	root := t.TempDir()
+	path := filepath.Join(root, "a", "b", "file")
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		_ = fs.Mkdir(filepath.Join(root, "a"), 0o755) // nolint: errcheck
+
+		time.Sleep(50 * time.Millisecond)
+
+		_ = fs.Mkdir(filepath.Join(root, "a", "b"), 0o755) // nolint: errcheck
+		_ = afero.WriteFile(fs, path, nil, 0o644)          // nolint: errcheck
+	}()
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.WaitForPath(mockT, fs, path, 5*time.Second), mockT.message())
+}
+
+func TestWaitForPath_Timeout(t *testing.T) {
+	t.Parallel()
+
+	mockT := &testingT{}
+
+	assert.False(t, aferoassert.WaitForPath(mockT, afero.NewOsFs(), filepath.Join(t.TempDir(), "unknown"), 50*time.Millisecond))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `unknown" did not appear within 50ms`)
+}
@@ -0,0 +1,189 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// ErrSymlinkCycle indicates that a symlink points to one of its parent directories.
+var ErrSymlinkCycle = errors.New("symlink cycle detected")
+
+// walk walks the file tree rooted at root like afero.Walk. When the links are followed, see FollowLinks, or with
+// TraverseSymlinkDirs, the symlinked directories are walked too and a cycle is reported as ErrSymlinkCycle.
+func walk(c *config, fs afero.Fs, root string, walkFn filepath.WalkFunc) error {
+	info, err := stat(c, fs, root)
+	if err != nil {
+		return walkFn(root, nil, err)
+	}
+
+	info = resolveSymlinkDir(c, fs, root, info)
+
+	return walkDir(c, fs, root, info, nil, walkFn)
+}
+
+type walkedDir struct {
+	path string
+	info os.FileInfo
+}
+
+func walkDir(c *config, fs afero.Fs, path string, info os.FileInfo, parents []walkedDir, walkFn filepath.WalkFunc) error {
+	if err := walkFn(path, info, nil); err != nil {
+		if info.IsDir() && errors.Is(err, filepath.SkipDir) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !info.IsDir() {
+		return nil
+	}
+
+	if err := checkCycle(path, info, parents); err != nil {
+		return err
+	}
+
+	names, err := readDir(c, fs, path)
+
+	if err != nil {
+		return walkFn(path, info, err)
+	}
+
+	parents = append(parents, walkedDir{path: path, info: info})
+
+	for _, name := range names {
+		filename := filepath.Join(path, name)
+
+		fileInfo, err := stat(c, fs, filename)
+		if err != nil {
+			if err := walkFn(filename, fileInfo, err); err != nil && !errors.Is(err, filepath.SkipDir) {
+				return err
+			}
+
+			continue
+		}
+
+		fileInfo = resolveSymlinkDir(c, fs, filename, fileInfo)
+
+		if err := walkDir(c, fs, filename, fileInfo, parents, walkFn); err != nil {
+			if !fileInfo.IsDir() || !errors.Is(err, filepath.SkipDir) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkCycle checks whether the directory is one of its parents, which happens when a symlink is followed.
+func checkCycle(path string, info os.FileInfo, parents []walkedDir) error {
+	for i, p := range parents {
+		if !os.SameFile(targetInfo(p.info), targetInfo(info)) {
+			continue
+		}
+
+		chain := make([]string, 0, len(parents)-i+1)
+
+		for _, d := range parents[i:] {
+			chain = append(chain, d.path)
+		}
+
+		chain = append(chain, path)
+
+		return fmt.Errorf("%w: %s", ErrSymlinkCycle, strings.Join(chain, " -> "))
+	}
+
+	return nil
+}
+
+// symlinkDirInfo is the info of a symlink to a directory, see TraverseSymlinkDirs. It has the name of the symlink and
+// the mode of the directory with the Symlink bit.
+type symlinkDirInfo struct {
+	os.FileInfo
+
+	name string
+}
+
+func (i symlinkDirInfo) Name() string {
+	return i.name
+}
+
+func (i symlinkDirInfo) Mode() os.FileMode {
+	return i.FileInfo.Mode() | os.ModeSymlink
+}
+
+// targetInfo returns the info of the directory of a symlink, or the info itself if it is not a symlink to a directory.
+func targetInfo(info os.FileInfo) os.FileInfo {
+	if i, ok := info.(symlinkDirInfo); ok {
+		return i.FileInfo
+	}
+
+	return info
+}
+
+// resolveSymlinkDir reads the target of a symlink with TraverseSymlinkDirs and returns a symlinkDirInfo if it is a
+// directory, so the directory is walked. The info is returned as is otherwise.
+func resolveSymlinkDir(c *config, fs afero.Fs, path string, info os.FileInfo) os.FileInfo {
+	if !c.traverseSymlinkDirs || info.Mode()&os.ModeSymlink == 0 {
+		return info
+	}
+
+	target, _, ok := readSymlink(fs, path)
+	if !ok {
+		return info
+	}
+
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+
+	dirInfo, err := fs.Stat(target)
+	if err != nil || !dirInfo.IsDir() {
+		return info
+	}
+
+	c.tracef("readlink(%q): %q", path, target)
+
+	return symlinkDirInfo{FileInfo: dirInfo, name: info.Name()}
+}
+
+// readDir lists the directory, or returns the cached names when the assertion runs in a Session.
+func readDir(c *config, fs afero.Fs, dir string) ([]string, error) {
+	if c.index != nil && !c.followLinks {
+		if names, err, ok := c.index.readDirNames(dir); ok {
+			c.tracef("readdir(%q): %d entries, err: %v (cached)", dir, len(names), err)
+
+			return names, err
+		}
+	}
+
+	names, err := readDirNames(fs, dir)
+
+	c.tracef("readdir(%q): %d entries, err: %v", dir, len(names), err)
+
+	return names, err
+}
+
+func readDirNames(fs afero.Fs, dir string) ([]string, error) {
+	f, err := fs.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -0,0 +1,94 @@
+package aferoassert
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	// ErrXattrNotSupported indicates that the file system does not support the extended attributes.
+	ErrXattrNotSupported = errors.New("extended attributes are not supported")
+	// ErrXattrNotFound indicates that the extended attribute is not set.
+	ErrXattrNotFound = errors.New("extended attribute not found")
+)
+
+// XattrFs is implemented by the file systems that support the extended attributes. The afero.OsFs is supported on
+// Linux without implementing it.
+type XattrFs interface {
+	// Getxattr returns the value of the extended attribute, or ErrXattrNotFound if it is not set.
+	Getxattr(path, name string) ([]byte, error)
+}
+
+type tSkipper interface {
+	Skipf(format string, args ...interface{})
+}
+
+func getXattr(fs afero.Fs, path, name string) ([]byte, error) {
+	if x, ok := fs.(XattrFs); ok {
+		return x.Getxattr(path, name)
+	}
+
+	if isOsFs(fs) {
+		return osGetxattr(path, name)
+	}
+
+	return nil, ErrXattrNotSupported
+}
+
+// checkXattr compares the extended attribute with the expectation and returns the failure message if they are not
+// equal. The check passes if the extended attributes are not supported.
+func checkXattr(fs afero.Fs, path, name, expected string) (string, bool) {
+	value, err := getXattr(fs, path, name)
+
+	switch {
+	case errors.Is(err, ErrXattrNotSupported):
+		return "", true
+
+	case errors.Is(err, ErrXattrNotFound):
+		return fmt.Sprintf("%q has no xattr %q, expected %q", path, name, expected), false
+
+	case err != nil:
+		return fmt.Sprintf("could not get xattr %q of %q: %s", name, path, err), false
+
+	case string(value) != expected:
+		return fmt.Sprintf("%q xattr %q is %q, expected %q", path, name, value, expected), false
+	}
+
+	return "", true
+}
+
+// Xattr checks whether a path has the extended attribute with the expected value, for example, a security label or a
+// user attribute. The test is skipped if the file system does not support the extended attributes, see XattrFs.
+func Xattr(t TestingT, fs afero.Fs, path, name, value string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, msgAndArgs := newConfig(msgAndArgs)
+
+	return c.run(t, "Xattr", func(t TestingT) bool {
+		return assertXattr(t, c, fs, path, name, value, msgAndArgs...)
+	})
+}
+
+func assertXattr(t TestingT, c *config, fs afero.Fs, path, name, value string, msgAndArgs ...interface{}) bool {
+	if !assertExists(t, c, fs, path, msgAndArgs...) {
+		return false
+	}
+
+	if _, err := getXattr(fs, path, name); errors.Is(err, ErrXattrNotSupported) {
+		if s, ok := t.(tSkipper); ok {
+			s.Skipf("%s: %s", fs.Name(), err)
+		}
+
+		return true
+	}
+
+	if msg, ok := checkXattr(fs, path, name, value); !ok {
+		return c.fail(t, FailureInfo{Path: path, Expected: value, Message: msg}, msgAndArgs...)
+	}
+
+	return true
+}
@@ -0,0 +1,37 @@
+//go:build linux
+// +build linux
+
+package aferoassert
+
+import (
+	"errors"
+	"syscall"
+)
+
+func osGetxattr(path, name string) ([]byte, error) {
+	size, err := syscall.Getxattr(path, name, nil)
+	if err != nil {
+		return nil, xattrError(err)
+	}
+
+	buf := make([]byte, size)
+
+	n, err := syscall.Getxattr(path, name, buf)
+	if err != nil {
+		return nil, xattrError(err)
+	}
+
+	return buf[:n], nil
+}
+
+func xattrError(err error) error {
+	switch {
+	case errors.Is(err, syscall.ENODATA):
+		return ErrXattrNotFound
+
+	case errors.Is(err, syscall.ENOTSUP):
+		return ErrXattrNotSupported
+	}
+
+	return err
+}
@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package aferoassert_test
+
+import (
+	"errors"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+func TestXattr_OsFs(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "file")
+	fs := afero.NewOsFs()
+
+	require.NoError(t, afero.WriteFile(fs, path, nil, 0o644))
+
+	if err := syscall.Setxattr(path, "user.origin", []byte("tool"), 0); errors.Is(err, syscall.ENOTSUP) {
+		t.Skip("user xattrs are not supported by the temp dir")
+	} else {
+		require.NoError(t, err)
+	}
+
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Xattr(mockT, fs, path, "user.origin", "tool"), mockT.message())
+
+	assert.False(t, aferoassert.Xattr(mockT, fs, path, "user.unknown", "tool"))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `has no xattr "user.unknown"`)
+}
+
+func TestXattrTag_FailureKind(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	path := filepath.Join(root, "file")
+	fs := afero.NewOsFs()
+
+	require.NoError(t, afero.WriteFile(fs, path, nil, 0o644))
+
+	if err := syscall.Setxattr(path, "user.origin", []byte("tool"), 0); errors.Is(err, syscall.ENOTSUP) {
+		t.Skip("user xattrs are not supported by the temp dir")
+	} else {
+		require.NoError(t, err)
+	}
+
+	var failures []aferoassert.FailureInfo
+
+	mockT := &testingT{}
+	onFailure := aferoassert.WithOnFailure(func(info aferoassert.FailureInfo) {
+		failures = append(failures, info)
+	})
+
+	assert.False(t, aferoassert.YAMLTreeContains(mockT, fs, `- file 'xattr:"user.origin=other"'`, root, onFailure))
+	require.Len(t, failures, 1)
+	assert.Equal(t, aferoassert.FailureXattr, failures[0].Kind)
+}
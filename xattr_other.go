@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package aferoassert
+
+func osGetxattr(string, string) ([]byte, error) {
+	return nil, ErrXattrNotSupported
+}
@@ -0,0 +1,106 @@
+package aferoassert_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+)
+
+// xattrFs is a file system with extended attributes.
+type xattrFs struct {
+	afero.Fs
+
+	xattrs map[string]map[string]string
+}
+
+func (fs xattrFs) Getxattr(path, name string) ([]byte, error) {
+	v, ok := fs.xattrs[filepath.Clean(path)][name]
+	if !ok {
+		return nil, aferoassert.ErrXattrNotFound
+	}
+
+	return []byte(v), nil
+}
+
+type skippingT struct {
+	testingT
+
+	skipped bool
+}
+
+func (t *skippingT) Skipf(string, ...interface{}) {
+	t.skipped = true
+}
+
+func newXattrFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := xattrFs{
+		Fs: afero.NewMemMapFs(),
+		xattrs: map[string]map[string]string{
+			"root/file": {"user.origin": "tool", "security.selinux": "system_u:object_r:etc_t:s0"},
+		},
+	}
+
+	require.NoError(t, afero.WriteFile(fs, "root/file", nil, 0o644))
+
+	return fs
+}
+
+func TestXattr(t *testing.T) {
+	t.Parallel()
+
+	fs := newXattrFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.Xattr(mockT, fs, "root/file", "user.origin", "tool"), mockT.message())
+
+	assert.False(t, aferoassert.Xattr(mockT, fs, "root/file", "user.origin", "other"))
+	assert.False(t, aferoassert.Xattr(mockT, fs, "root/file", "user.unknown", "value"))
+	assert.False(t, aferoassert.Xattr(mockT, fs, "root/unknown", "user.origin", "tool"))
+	require.Len(t, mockT.errors, 3)
+	assert.Contains(t, mockT.errors[0], `"root/file" xattr "user.origin" is "tool", expected "other"`)
+	assert.Contains(t, mockT.errors[1], `"root/file" has no xattr "user.unknown", expected "value"`)
+	assert.Contains(t, mockT.errors[2], `unable to find file "root/unknown"`)
+}
+
+func TestXattr_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "file", nil, 0o644))
+
+	mockT := &skippingT{}
+
+	assert.True(t, aferoassert.Xattr(mockT, fs, "file", "user.origin", "tool"))
+	assert.True(t, mockT.skipped)
+	assert.Empty(t, mockT.errors)
+}
+
+func TestYAMLTreeEqual_Xattr(t *testing.T) {
+	t.Parallel()
+
+	fs := newXattrFs(t)
+	mockT := &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, fs, `- file 'xattr:"user.origin=tool, security.selinux=system_u:object_r:etc_t:s0"'`, "root"), mockT.message())
+
+	assert.False(t, aferoassert.YAMLTreeEqual(mockT, fs, `- file 'xattr:"user.origin=other"'`, "root"))
+	require.Len(t, mockT.errors, 1)
+	assert.Contains(t, mockT.errors[0], `"root/file" xattr "user.origin" is "tool", expected "other"`)
+
+	// Not supported.
+	mockT = &testingT{}
+
+	assert.True(t, aferoassert.YAMLTreeEqual(mockT, newSourceFs(t), `
+- file 1 'xattr:"user.origin=tool"'
+- folder 2:
+    - file 2
+`, "root"), mockT.message())
+}
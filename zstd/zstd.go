@@ -0,0 +1,27 @@
+// Package zstd registers a Zstandard decompressor, so aferoassert.FsFromTar can load the .tar.zst archives. The
+// decompressor lives in its own package to keep the dependency on github.com/klauspost/compress opt-in:
+//
+//	import _ "go.nhat.io/aferoassert/zstd"
+package zstd
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"go.nhat.io/aferoassert"
+)
+
+func init() { // nolint: gochecknoinits
+	aferoassert.RegisterDecompressor(aferoassert.Zstd, Decompress)
+}
+
+// Decompress decompresses a Zstandard stream, it is registered as the aferoassert.Zstd decompressor.
+func Decompress(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.IOReadCloser(), nil
+}
@@ -0,0 +1,45 @@
+package zstd_test
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.nhat.io/aferoassert"
+	_ "go.nhat.io/aferoassert/zstd"
+)
+
+func TestFsFromTar(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "archive.tar.zst")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	zw, err := zstd.NewWriter(f)
+	require.NoError(t, err)
+
+	w := tar.NewWriter(zw)
+
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "dist/", Mode: 0o755, Typeflag: tar.TypeDir}))
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "dist/README.md", Mode: 0o644, Size: 6, Typeflag: tar.TypeReg}))
+
+	_, err = w.Write([]byte("# App\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	fs, err := aferoassert.FsFromTar(path)
+	require.NoError(t, err)
+
+	assert.True(t, aferoassert.FileContent(t, fs, "dist/README.md", "# App\n"))
+	assert.True(t, aferoassert.Perm(t, fs, "dist/README.md", 0o644))
+}